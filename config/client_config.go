@@ -0,0 +1,429 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// pgParamKeys is the keywords of pg_service.conf that are passed to the driver as Params.
+var pgParamKeys = []string{"sslmode", "sslrootcert", "sslcert", "sslkey", "connect_timeout", "application_name"}
+
+// ImportClientConnections returns the connections that are set up for the psql and mysql clients, so that
+// they can be selected without being saved in dbms.yml. They are read from:
+//
+//	DATABASE_URL, PGHOST, PGPORT, PGUSER, PGPASSWORD, PGDATABASE and PGSSLMODE
+//	MYSQL_HOST, MYSQL_TCP_PORT and MYSQL_PWD
+//	pg_service.conf (PGSERVICEFILE, ~/.pg_service.conf and PGSYSCONFDIR/pg_service.conf)
+//	.pgpass (PGPASSFILE or ~/.pgpass)
+//	the [client] and [mysql] groups of ~/.my.cnf, and the groups with a suffix such as [client_prod]
+//
+// A missing file is skipped. The connections of the other files are returned with the error of a file
+// that cannot be read.
+func ImportClientConnections() ([]DBConnection, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+	return importClientConnections(home, os.Getenv)
+}
+
+// importClientConnections imports the connections from the home directory and the environment variables.
+func importClientConnections(home string, getenv func(string) string) ([]DBConnection, error) {
+	var errs []error
+
+	pgpassPath := getenv("PGPASSFILE")
+	if pgpassPath == "" {
+		pgpassPath = filepath.Join(home, ".pgpass")
+	}
+	pgpass, err := readPgpass(pgpassPath)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	pg := pgDefaults{user: getenv("USER"), pgpass: pgpass}
+
+	conns := []DBConnection{}
+	if dsn := getenv("DATABASE_URL"); dsn != "" {
+		conn, err := ParseDSN(dsn)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("DATABASE_URL: %w", err))
+		} else {
+			conn.Name = "env: DATABASE_URL"
+			conn.Source = "$DATABASE_URL"
+			conns = append(conns, conn)
+		}
+	}
+	if conn, ok := pgEnvConnection(getenv, pg); ok {
+		conns = append(conns, conn)
+	}
+	if conn, ok := mysqlEnvConnection(getenv); ok {
+		conns = append(conns, conn)
+	}
+
+	services, err := pgServiceConnections(home, getenv, pg)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	conns = append(conns, services...)
+
+	for _, entry := range pgpass {
+		conn, ok := entry.connection(pgpassPath)
+		if !ok || slices.ContainsFunc(conns, func(c DBConnection) bool { return c.sameServer(conn) }) {
+			continue
+		}
+		conns = append(conns, conn)
+	}
+
+	myCnf, err := myCnfConnections(filepath.Join(home, ".my.cnf"))
+	if err != nil {
+		errs = append(errs, err)
+	}
+	conns = append(conns, myCnf...)
+
+	return conns, errors.Join(errs...)
+}
+
+// sameServer returns true if the connections connect to the same database as the same user.
+func (c DBConnection) sameServer(other DBConnection) bool {
+	return c.Type == other.Type && c.Host == other.Host && c.Port == other.Port &&
+		c.User == other.User && c.Database == other.Database
+}
+
+// pgDefaults completes the PostgreSQL settings as libpq does.
+type pgDefaults struct {
+	user   string
+	pgpass []pgpassEntry
+}
+
+// complete sets the default host, port, user and database, and the password in .pgpass.
+func (d pgDefaults) complete(conn *DBConnection) {
+	conn.Type = PostgreSQL
+	if conn.Host == "" {
+		conn.Host = "localhost"
+	}
+	if conn.Port == 0 {
+		conn.Port = defaultPorts[PostgreSQL]
+	}
+	if conn.User == "" {
+		conn.User = d.user
+	}
+	if conn.Database == "" {
+		conn.Database = conn.User
+	}
+	if conn.Password != "" {
+		return
+	}
+	for _, entry := range d.pgpass {
+		if entry.matches(*conn) {
+			conn.Password = entry.password
+			return
+		}
+	}
+}
+
+// pgEnvConnection returns the connection of the PG* environment variables.
+func pgEnvConnection(getenv func(string) string, pg pgDefaults) (DBConnection, bool) {
+	if getenv("PGHOST") == "" && getenv("PGUSER") == "" && getenv("PGDATABASE") == "" && getenv("PGPORT") == "" {
+		return DBConnection{}, false
+	}
+	conn := DBConnection{
+		Name:     "env: PGHOST",
+		Host:     getenv("PGHOST"),
+		User:     getenv("PGUSER"),
+		Password: getenv("PGPASSWORD"),
+		Database: getenv("PGDATABASE"),
+		Source:   "$PGHOST, $PGUSER, ...",
+	}
+	conn.Port, _ = strconv.Atoi(getenv("PGPORT")) //nolint:errcheck // An invalid port is the default port as in libpq.
+	if sslmode := getenv("PGSSLMODE"); sslmode != "" {
+		conn.Params = map[string]string{"sslmode": sslmode}
+	}
+	pg.complete(&conn)
+	return conn, true
+}
+
+// mysqlEnvConnection returns the connection of the MYSQL_* environment variables. The user is USER
+// as in the mysql client.
+func mysqlEnvConnection(getenv func(string) string) (DBConnection, bool) {
+	if getenv("MYSQL_HOST") == "" && getenv("MYSQL_PWD") == "" && getenv("MYSQL_TCP_PORT") == "" {
+		return DBConnection{}, false
+	}
+	conn := DBConnection{
+		Name:     "env: MYSQL_HOST",
+		Type:     MySQL,
+		Host:     getenv("MYSQL_HOST"),
+		User:     getenv("USER"),
+		Password: getenv("MYSQL_PWD"),
+		Source:   "$MYSQL_HOST, $MYSQL_PWD, ...",
+	}
+	if conn.Host == "" {
+		conn.Host = "localhost"
+	}
+	conn.Port, _ = strconv.Atoi(getenv("MYSQL_TCP_PORT")) //nolint:errcheck // An invalid port is the default port.
+	if conn.Port == 0 {
+		conn.Port = defaultPorts[MySQL]
+	}
+	return conn, true
+}
+
+// pgpassEntry is a line of .pgpass. "*" matches any value.
+type pgpassEntry struct {
+	host, port, database, user, password string
+}
+
+// readPgpass reads the .pgpass file. As in libpq, the file is ignored if others can read it.
+func readPgpass(path string) ([]pgpassEntry, error) {
+	info, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if runtime.GOOS != "windows" && info.Mode().Perm()&0077 != 0 {
+		return nil, fmt.Errorf("%s is ignored because others can read it; run chmod 0600 %s", path, path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parsePgpass(data), nil
+}
+
+// parsePgpass parses the lines of hostname:port:database:username:password.
+// ":" and "\" in a field are escaped with "\". Comments and malformed lines are skipped.
+func parsePgpass(data []byte) []pgpassEntry {
+	entries := []pgpassEntry{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := splitPgpassLine(line)
+		if len(fields) != 5 {
+			continue
+		}
+		entries = append(entries, pgpassEntry{
+			host: fields[0], port: fields[1], database: fields[2], user: fields[3], password: fields[4],
+		})
+	}
+	return entries
+}
+
+// splitPgpassLine splits the line with ":" that is not escaped. The password is the rest of the line.
+func splitPgpassLine(line string) []string {
+	fields := []string{}
+	var field strings.Builder
+	escaped := false
+	for _, r := range line {
+		switch {
+		case escaped:
+			field.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == ':' && len(fields) < 4:
+			fields = append(fields, field.String())
+			field.Reset()
+		default:
+			field.WriteRune(r)
+		}
+	}
+	return append(fields, field.String())
+}
+
+// matches returns true if the entry has the password of the connection.
+func (e pgpassEntry) matches(conn DBConnection) bool {
+	match := func(pattern, value string) bool {
+		return pattern == "*" || pattern == value
+	}
+	return match(e.host, conn.Host) && match(e.port, strconv.Itoa(conn.Port)) &&
+		match(e.database, conn.Database) && match(e.user, conn.User)
+}
+
+// connection returns the connection of the entry. The wildcards of the host, port and database are
+// the defaults of libpq. An entry for any user is not a connection.
+func (e pgpassEntry) connection(source string) (DBConnection, bool) {
+	if e.user == "*" {
+		return DBConnection{}, false
+	}
+	conn := DBConnection{User: e.user, Password: e.password, Source: source}
+	if e.host != "*" {
+		conn.Host = e.host
+	}
+	if e.port != "*" {
+		port, err := strconv.Atoi(e.port)
+		if err != nil {
+			return DBConnection{}, false
+		}
+		conn.Port = port
+	}
+	if e.database != "*" {
+		conn.Database = e.database
+	}
+	pgDefaults{}.complete(&conn)
+	conn.Name = fmt.Sprintf("pgpass: %s@%s:%d/%s", conn.User, conn.Host, conn.Port, conn.Database)
+	return conn, true
+}
+
+// pgServiceConnections returns the connections of the services in the user and system pg_service.conf.
+// A service in the user file overrides the service of the same name in the system file.
+func pgServiceConnections(home string, getenv func(string) string, pg pgDefaults) ([]DBConnection, error) {
+	paths := []string{}
+	if path := getenv("PGSERVICEFILE"); path != "" {
+		paths = append(paths, path)
+	} else {
+		paths = append(paths, filepath.Join(home, ".pg_service.conf"))
+	}
+	if dir := getenv("PGSYSCONFDIR"); dir != "" {
+		paths = append(paths, filepath.Join(dir, "pg_service.conf"))
+	}
+
+	conns := []DBConnection{}
+	var errs []error
+	for _, path := range paths {
+		sections, err := readINI(path)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		for _, section := range sections {
+			name := "pg_service: " + section.name
+			if slices.ContainsFunc(conns, func(c DBConnection) bool { return c.Name == name }) {
+				continue
+			}
+			conn := DBConnection{
+				Name:     name,
+				Host:     section.values["host"],
+				User:     section.values["user"],
+				Password: section.values["password"],
+				Database: section.values["dbname"],
+				Source:   path,
+			}
+			if conn.Host == "" {
+				conn.Host = section.values["hostaddr"]
+			}
+			conn.Port, _ = strconv.Atoi(section.values["port"]) //nolint:errcheck // An invalid port is the default port.
+			params := map[string]string{}
+			for _, key := range pgParamKeys {
+				if value, ok := section.values[key]; ok {
+					params[key] = value
+				}
+			}
+			conn.Params = paramsOrNil(params)
+			pg.complete(&conn)
+			conns = append(conns, conn)
+		}
+	}
+	return conns, errors.Join(errs...)
+}
+
+// myCnfConnections returns the connections of the option groups of the mysql client. [client] and [mysql]
+// are a connection, and the groups with a suffix (e.g. [client_prod] and [mysql_prod], which are read by
+// mysql --defaults-group-suffix=_prod) override them.
+func myCnfConnections(path string) ([]DBConnection, error) {
+	sections, err := readINI(path)
+	if err != nil || len(sections) == 0 {
+		return nil, err
+	}
+	groups := map[string]map[string]string{}
+	suffixes := []string{""}
+	for _, section := range sections {
+		groups[section.name] = section.values
+		for _, prefix := range []string{"client", "mysql"} {
+			suffix, ok := strings.CutPrefix(section.name, prefix)
+			if ok && (strings.HasPrefix(suffix, "_") || strings.HasPrefix(suffix, "-")) && !slices.Contains(suffixes, suffix) {
+				suffixes = append(suffixes, suffix)
+			}
+		}
+	}
+
+	conns := []DBConnection{}
+	for _, suffix := range suffixes {
+		values := map[string]string{}
+		for _, group := range []string{"client", "mysql", "client" + suffix, "mysql" + suffix} {
+			maps.Copy(values, groups[group])
+		}
+		if values["host"] == "" && values["user"] == "" && values["password"] == "" && values["database"] == "" {
+			continue
+		}
+		conn := DBConnection{
+			Name:     fmt.Sprintf("my.cnf: [client%s]", suffix),
+			Type:     MySQL,
+			Host:     values["host"],
+			User:     values["user"],
+			Password: values["password"],
+			Database: values["database"],
+			Source:   path,
+		}
+		if conn.Host == "" {
+			conn.Host = "localhost"
+		}
+		conn.Port, _ = strconv.Atoi(values["port"]) //nolint:errcheck // An invalid port is the default port.
+		if conn.Port == 0 {
+			conn.Port = defaultPorts[MySQL]
+		}
+		conns = append(conns, conn)
+	}
+	return conns, nil
+}
+
+// iniSection is a [section] of an INI file such as pg_service.conf and my.cnf.
+type iniSection struct {
+	name   string
+	values map[string]string
+}
+
+// readINI reads the sections of the INI file. It returns nil if the file does not exist.
+func readINI(path string) ([]iniSection, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return parseINI(data), nil
+}
+
+// parseINI parses "key=value" lines in [section]s. The keys are lower case, and "-" in them is "_"
+// as in my.cnf. Quotes around the values are removed. Comments (# and ;), directives such as !include
+// and lines outside a section are skipped.
+func parseINI(data []byte) []iniSection {
+	sections := []iniSection{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "", strings.HasPrefix(line, "#"), strings.HasPrefix(line, ";"), strings.HasPrefix(line, "!"):
+			continue
+		case strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]"):
+			name := strings.TrimSpace(line[1 : len(line)-1])
+			sections = append(sections, iniSection{name: name, values: map[string]string{}})
+			continue
+		case len(sections) == 0:
+			continue
+		}
+		key, value, _ := strings.Cut(line, "=")
+		key = strings.ReplaceAll(strings.ToLower(strings.TrimSpace(key)), "-", "_")
+		sections[len(sections)-1].values[key] = unquote(strings.TrimSpace(value))
+	}
+	return sections
+}
+
+// unquote removes the single or double quotes around the value.
+func unquote(value string) string {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
@@ -23,6 +23,19 @@ type ColorScheme struct {
 	ButtonFocus     string `yaml:"button_focus"`
 	ButtonText      string `yaml:"button_text"`
 	ButtonTextFocus string `yaml:"button_text_focus"`
+	// The colors of the syntax highlighting in the query text area.
+	SyntaxKeyword    string `yaml:"syntax_keyword"`
+	SyntaxIdentifier string `yaml:"syntax_identifier"`
+	SyntaxString     string `yaml:"syntax_string"`
+	SyntaxNumber     string `yaml:"syntax_number"`
+	SyntaxComment    string `yaml:"syntax_comment"`
+	SyntaxParameter  string `yaml:"syntax_parameter"`
+	// SyntaxError is the background of an unclosed quote or comment and an unbalanced parenthesis.
+	SyntaxError string `yaml:"syntax_error"`
+	// The colors of the added, removed and changed rows in data diffs and table edits.
+	DiffAdded   string `yaml:"diff_added"`
+	DiffRemoved string `yaml:"diff_removed"`
+	DiffChanged string `yaml:"diff_changed"`
 }
 
 // GetTcellColor converts a color string to a tcell.Color
@@ -63,228 +76,388 @@ type ColorConfig struct {
 func DefaultColorSchemes() map[string]*ColorScheme {
 	return map[string]*ColorScheme{
 		"default": {
-			Name:            "Default",
-			Background:      "black",
-			Foreground:      "white",
-			Border:          "white",
-			BorderFocus:     "green",
-			Selection:       "green",
-			SelectionText:   "black",
-			Header:          "yellow",
-			Button:          "white",
-			ButtonFocus:     "lightgray",
-			ButtonText:      "black",
-			ButtonTextFocus: "black",
+			Name:             "Default",
+			Background:       "black",
+			Foreground:       "white",
+			Border:           "white",
+			BorderFocus:      "green",
+			Selection:        "green",
+			SelectionText:    "black",
+			Header:           "yellow",
+			Button:           "white",
+			ButtonFocus:      "lightgray",
+			ButtonText:       "black",
+			ButtonTextFocus:  "black",
+			SyntaxKeyword:    "#5fafff",
+			SyntaxIdentifier: "white",
+			SyntaxString:     "green",
+			SyntaxNumber:     "#ff87ff",
+			SyntaxComment:    "gray",
+			SyntaxParameter:  "#00d7d7",
+			SyntaxError:      "red",
+			DiffAdded:        "green",
+			DiffRemoved:      "red",
+			DiffChanged:      "yellow",
 		},
 		"dark": {
-			Name:            "Dark",
-			Background:      "#222222",
-			Foreground:      "#e0e0e0",
-			Border:          "#555555",
-			BorderFocus:     "#00cc00",
-			Selection:       "#004400",
-			SelectionText:   "#00ff00",
-			Header:          "#ffcc00",
-			Button:          "#555555",
-			ButtonFocus:     "#888888",
-			ButtonText:      "#ffffff",
-			ButtonTextFocus: "#ffffff",
+			Name:             "Dark",
+			Background:       "#222222",
+			Foreground:       "#e0e0e0",
+			Border:           "#555555",
+			BorderFocus:      "#00cc00",
+			Selection:        "#004400",
+			SelectionText:    "#00ff00",
+			Header:           "#ffcc00",
+			Button:           "#555555",
+			ButtonFocus:      "#888888",
+			ButtonText:       "#ffffff",
+			ButtonTextFocus:  "#ffffff",
+			SyntaxKeyword:    "#569cd6",
+			SyntaxIdentifier: "#e0e0e0",
+			SyntaxString:     "#ce9178",
+			SyntaxNumber:     "#b5cea8",
+			SyntaxComment:    "#6a9955",
+			SyntaxParameter:  "#4ec9b0",
+			SyntaxError:      "#f44747",
+			DiffAdded:        "#89d185",
+			DiffRemoved:      "#f44747",
+			DiffChanged:      "#dcdcaa",
 		},
 		"light": {
-			Name:            "Light",
-			Background:      "#f0f0f0",
-			Foreground:      "#222222",
-			Border:          "#999999",
-			BorderFocus:     "#0066cc",
-			Selection:       "#ccddff",
-			SelectionText:   "#000000",
-			Header:          "#333399",
-			Button:          "#dddddd",
-			ButtonFocus:     "#0066cc",
-			ButtonText:      "#222222",
-			ButtonTextFocus: "#ffffff",
+			Name:             "Light",
+			Background:       "#f0f0f0",
+			Foreground:       "#222222",
+			Border:           "#999999",
+			BorderFocus:      "#0066cc",
+			Selection:        "#ccddff",
+			SelectionText:    "#000000",
+			Header:           "#333399",
+			Button:           "#dddddd",
+			ButtonFocus:      "#0066cc",
+			ButtonText:       "#222222",
+			ButtonTextFocus:  "#ffffff",
+			SyntaxKeyword:    "#0000cc",
+			SyntaxIdentifier: "#222222",
+			SyntaxString:     "#a31515",
+			SyntaxNumber:     "#098658",
+			SyntaxComment:    "#008000",
+			SyntaxParameter:  "#267f99",
+			SyntaxError:      "#cd3131",
+			DiffAdded:        "#008000",
+			DiffRemoved:      "#cd3131",
+			DiffChanged:      "#bf8803",
 		},
 		"solarized": {
-			Name:            "Solarized",
-			Background:      "#002b36",
-			Foreground:      "#839496",
-			Border:          "#586e75",
-			BorderFocus:     "#cb4b16",
-			Selection:       "#073642",
-			SelectionText:   "#93a1a1",
-			Header:          "#b58900",
-			Button:          "#073642",
-			ButtonFocus:     "#586e75",
-			ButtonText:      "#839496",
-			ButtonTextFocus: "#fdf6e3",
+			Name:             "Solarized",
+			Background:       "#002b36",
+			Foreground:       "#839496",
+			Border:           "#586e75",
+			BorderFocus:      "#cb4b16",
+			Selection:        "#073642",
+			SelectionText:    "#93a1a1",
+			Header:           "#b58900",
+			Button:           "#073642",
+			ButtonFocus:      "#586e75",
+			ButtonText:       "#839496",
+			ButtonTextFocus:  "#fdf6e3",
+			SyntaxKeyword:    "#859900",
+			SyntaxIdentifier: "#268bd2",
+			SyntaxString:     "#2aa198",
+			SyntaxNumber:     "#d33682",
+			SyntaxComment:    "#586e75",
+			SyntaxParameter:  "#b58900",
+			SyntaxError:      "#dc322f",
+			DiffAdded:        "#859900",
+			DiffRemoved:      "#dc322f",
+			DiffChanged:      "#b58900",
 		},
 		"monokai": {
-			Name:            "Monokai",
-			Background:      "#272822",
-			Foreground:      "#f8f8f2",
-			Border:          "#75715e",
-			BorderFocus:     "#f92672",
-			Selection:       "#49483e",
-			SelectionText:   "#f8f8f2",
-			Header:          "#66d9ef",
-			Button:          "#75715e",
-			ButtonFocus:     "#f92672",
-			ButtonText:      "#f8f8f2",
-			ButtonTextFocus: "#ffffff",
+			Name:             "Monokai",
+			Background:       "#272822",
+			Foreground:       "#f8f8f2",
+			Border:           "#75715e",
+			BorderFocus:      "#f92672",
+			Selection:        "#49483e",
+			SelectionText:    "#f8f8f2",
+			Header:           "#66d9ef",
+			Button:           "#75715e",
+			ButtonFocus:      "#f92672",
+			ButtonText:       "#f8f8f2",
+			ButtonTextFocus:  "#ffffff",
+			SyntaxKeyword:    "#f92672",
+			SyntaxIdentifier: "#f8f8f2",
+			SyntaxString:     "#e6db74",
+			SyntaxNumber:     "#ae81ff",
+			SyntaxComment:    "#75715e",
+			SyntaxParameter:  "#fd971f",
+			SyntaxError:      "#ff5555",
+			DiffAdded:        "#a6e22e",
+			DiffRemoved:      "#f92672",
+			DiffChanged:      "#e6db74",
 		},
 		"dracula": {
-			Name:            "Dracula",
-			Background:      "#282a36",
-			Foreground:      "#f8f8f2",
-			Border:          "#6272a4",
-			BorderFocus:     "#ff79c6",
-			Selection:       "#44475a",
-			SelectionText:   "#f8f8f2",
-			Header:          "#8be9fd",
-			Button:          "#6272a4",
-			ButtonFocus:     "#ff79c6",
-			ButtonText:      "#f8f8f2",
-			ButtonTextFocus: "#f8f8f2",
+			Name:             "Dracula",
+			Background:       "#282a36",
+			Foreground:       "#f8f8f2",
+			Border:           "#6272a4",
+			BorderFocus:      "#ff79c6",
+			Selection:        "#44475a",
+			SelectionText:    "#f8f8f2",
+			Header:           "#8be9fd",
+			Button:           "#6272a4",
+			ButtonFocus:      "#ff79c6",
+			ButtonText:       "#f8f8f2",
+			ButtonTextFocus:  "#f8f8f2",
+			SyntaxKeyword:    "#ff79c6",
+			SyntaxIdentifier: "#f8f8f2",
+			SyntaxString:     "#f1fa8c",
+			SyntaxNumber:     "#bd93f9",
+			SyntaxComment:    "#6272a4",
+			SyntaxParameter:  "#ffb86c",
+			SyntaxError:      "#ff5555",
+			DiffAdded:        "#50fa7b",
+			DiffRemoved:      "#ff5555",
+			DiffChanged:      "#f1fa8c",
 		},
 		"nord": {
-			Name:            "Nord",
-			Background:      "#2e3440",
-			Foreground:      "#d8dee9",
-			Border:          "#4c566a",
-			BorderFocus:     "#88c0d0",
-			Selection:       "#3b4252",
-			SelectionText:   "#eceff4",
-			Header:          "#5e81ac",
-			Button:          "#4c566a",
-			ButtonFocus:     "#88c0d0",
-			ButtonText:      "#e5e9f0",
-			ButtonTextFocus: "#2e3440",
+			Name:             "Nord",
+			Background:       "#2e3440",
+			Foreground:       "#d8dee9",
+			Border:           "#4c566a",
+			BorderFocus:      "#88c0d0",
+			Selection:        "#3b4252",
+			SelectionText:    "#eceff4",
+			Header:           "#5e81ac",
+			Button:           "#4c566a",
+			ButtonFocus:      "#88c0d0",
+			ButtonText:       "#e5e9f0",
+			ButtonTextFocus:  "#2e3440",
+			SyntaxKeyword:    "#81a1c1",
+			SyntaxIdentifier: "#d8dee9",
+			SyntaxString:     "#a3be8c",
+			SyntaxNumber:     "#b48ead",
+			SyntaxComment:    "#616e88",
+			SyntaxParameter:  "#ebcb8b",
+			SyntaxError:      "#bf616a",
+			DiffAdded:        "#a3be8c",
+			DiffRemoved:      "#bf616a",
+			DiffChanged:      "#ebcb8b",
 		},
 		"gruvbox": {
-			Name:            "Gruvbox",
-			Background:      "#282828",
-			Foreground:      "#ebdbb2",
-			Border:          "#665c54",
-			BorderFocus:     "#fe8019",
-			Selection:       "#504945",
-			SelectionText:   "#ebdbb2",
-			Header:          "#b8bb26",
-			Button:          "#665c54",
-			ButtonFocus:     "#fe8019",
-			ButtonText:      "#ebdbb2",
-			ButtonTextFocus: "#fbf1c7",
+			Name:             "Gruvbox",
+			Background:       "#282828",
+			Foreground:       "#ebdbb2",
+			Border:           "#665c54",
+			BorderFocus:      "#fe8019",
+			Selection:        "#504945",
+			SelectionText:    "#ebdbb2",
+			Header:           "#b8bb26",
+			Button:           "#665c54",
+			ButtonFocus:      "#fe8019",
+			ButtonText:       "#ebdbb2",
+			ButtonTextFocus:  "#fbf1c7",
+			SyntaxKeyword:    "#fb4934",
+			SyntaxIdentifier: "#ebdbb2",
+			SyntaxString:     "#b8bb26",
+			SyntaxNumber:     "#d3869b",
+			SyntaxComment:    "#928374",
+			SyntaxParameter:  "#fabd2f",
+			SyntaxError:      "#cc241d",
+			DiffAdded:        "#b8bb26",
+			DiffRemoved:      "#fb4934",
+			DiffChanged:      "#fabd2f",
 		},
 		"tokyo-night": {
-			Name:            "Tokyo Night",
-			Background:      "#1a1b26",
-			Foreground:      "#a9b1d6",
-			Border:          "#414868",
-			BorderFocus:     "#7aa2f7",
-			Selection:       "#24283b",
-			SelectionText:   "#c0caf5",
-			Header:          "#bb9af7",
-			Button:          "#414868",
-			ButtonFocus:     "#7aa2f7",
-			ButtonText:      "#c0caf5",
-			ButtonTextFocus: "#1a1b26",
+			Name:             "Tokyo Night",
+			Background:       "#1a1b26",
+			Foreground:       "#a9b1d6",
+			Border:           "#414868",
+			BorderFocus:      "#7aa2f7",
+			Selection:        "#24283b",
+			SelectionText:    "#c0caf5",
+			Header:           "#bb9af7",
+			Button:           "#414868",
+			ButtonFocus:      "#7aa2f7",
+			ButtonText:       "#c0caf5",
+			ButtonTextFocus:  "#1a1b26",
+			SyntaxKeyword:    "#bb9af7",
+			SyntaxIdentifier: "#c0caf5",
+			SyntaxString:     "#9ece6a",
+			SyntaxNumber:     "#ff9e64",
+			SyntaxComment:    "#565f89",
+			SyntaxParameter:  "#7dcfff",
+			SyntaxError:      "#f7768e",
+			DiffAdded:        "#9ece6a",
+			DiffRemoved:      "#f7768e",
+			DiffChanged:      "#e0af68",
 		},
 		"catppuccin": {
-			Name:            "Catppuccin",
-			Background:      "#1e1e2e",
-			Foreground:      "#cdd6f4",
-			Border:          "#585b70",
-			BorderFocus:     "#f5c2e7",
-			Selection:       "#313244",
-			SelectionText:   "#cdd6f4",
-			Header:          "#89b4fa",
-			Button:          "#45475a",
-			ButtonFocus:     "#f5c2e7",
-			ButtonText:      "#cdd6f4",
-			ButtonTextFocus: "#1e1e2e",
+			Name:             "Catppuccin",
+			Background:       "#1e1e2e",
+			Foreground:       "#cdd6f4",
+			Border:           "#585b70",
+			BorderFocus:      "#f5c2e7",
+			Selection:        "#313244",
+			SelectionText:    "#cdd6f4",
+			Header:           "#89b4fa",
+			Button:           "#45475a",
+			ButtonFocus:      "#f5c2e7",
+			ButtonText:       "#cdd6f4",
+			ButtonTextFocus:  "#1e1e2e",
+			SyntaxKeyword:    "#cba6f7",
+			SyntaxIdentifier: "#cdd6f4",
+			SyntaxString:     "#a6e3a1",
+			SyntaxNumber:     "#fab387",
+			SyntaxComment:    "#6c7086",
+			SyntaxParameter:  "#89dceb",
+			SyntaxError:      "#f38ba8",
+			DiffAdded:        "#a6e3a1",
+			DiffRemoved:      "#f38ba8",
+			DiffChanged:      "#f9e2af",
 		},
 		"vscode": {
-			Name:            "VS Code",
-			Background:      "#1e1e1e",
-			Foreground:      "#d4d4d4",
-			Border:          "#3c3c3c",
-			BorderFocus:     "#007acc",
-			Selection:       "#264f78",
-			SelectionText:   "#ffffff",
-			Header:          "#569cd6",
-			Button:          "#3c3c3c",
-			ButtonFocus:     "#007acc",
-			ButtonText:      "#d4d4d4",
-			ButtonTextFocus: "#ffffff",
+			Name:             "VS Code",
+			Background:       "#1e1e1e",
+			Foreground:       "#d4d4d4",
+			Border:           "#3c3c3c",
+			BorderFocus:      "#007acc",
+			Selection:        "#264f78",
+			SelectionText:    "#ffffff",
+			Header:           "#569cd6",
+			Button:           "#3c3c3c",
+			ButtonFocus:      "#007acc",
+			ButtonText:       "#d4d4d4",
+			ButtonTextFocus:  "#ffffff",
+			SyntaxKeyword:    "#569cd6",
+			SyntaxIdentifier: "#9cdcfe",
+			SyntaxString:     "#ce9178",
+			SyntaxNumber:     "#b5cea8",
+			SyntaxComment:    "#6a9955",
+			SyntaxParameter:  "#4fc1ff",
+			SyntaxError:      "#f44747",
+			DiffAdded:        "#89d185",
+			DiffRemoved:      "#f48771",
+			DiffChanged:      "#dcdcaa",
 		},
 		"atom": {
-			Name:            "Atom",
-			Background:      "#282c34",
-			Foreground:      "#abb2bf",
-			Border:          "#3b4048",
-			BorderFocus:     "#528bff",
-			Selection:       "#3e4451",
-			SelectionText:   "#abb2bf",
-			Header:          "#61afef",
-			Button:          "#3b4048",
-			ButtonFocus:     "#528bff",
-			ButtonText:      "#abb2bf",
-			ButtonTextFocus: "#ffffff",
+			Name:             "Atom",
+			Background:       "#282c34",
+			Foreground:       "#abb2bf",
+			Border:           "#3b4048",
+			BorderFocus:      "#528bff",
+			Selection:        "#3e4451",
+			SelectionText:    "#abb2bf",
+			Header:           "#61afef",
+			Button:           "#3b4048",
+			ButtonFocus:      "#528bff",
+			ButtonText:       "#abb2bf",
+			ButtonTextFocus:  "#ffffff",
+			SyntaxKeyword:    "#c678dd",
+			SyntaxIdentifier: "#e06c75",
+			SyntaxString:     "#98c379",
+			SyntaxNumber:     "#d19a66",
+			SyntaxComment:    "#5c6370",
+			SyntaxParameter:  "#56b6c2",
+			SyntaxError:      "#be5046",
+			DiffAdded:        "#98c379",
+			DiffRemoved:      "#e06c75",
+			DiffChanged:      "#e5c07b",
 		},
 		"sublime": {
-			Name:            "Sublime Text",
-			Background:      "#272822",
-			Foreground:      "#f8f8f2",
-			Border:          "#75715e",
-			BorderFocus:     "#a6e22e",
-			Selection:       "#49483e",
-			SelectionText:   "#f8f8f2",
-			Header:          "#66d9ef",
-			Button:          "#75715e",
-			ButtonFocus:     "#a6e22e",
-			ButtonText:      "#f8f8f2",
-			ButtonTextFocus: "#272822",
+			Name:             "Sublime Text",
+			Background:       "#272822",
+			Foreground:       "#f8f8f2",
+			Border:           "#75715e",
+			BorderFocus:      "#a6e22e",
+			Selection:        "#49483e",
+			SelectionText:    "#f8f8f2",
+			Header:           "#66d9ef",
+			Button:           "#75715e",
+			ButtonFocus:      "#a6e22e",
+			ButtonText:       "#f8f8f2",
+			ButtonTextFocus:  "#272822",
+			SyntaxKeyword:    "#f92672",
+			SyntaxIdentifier: "#f8f8f2",
+			SyntaxString:     "#e6db74",
+			SyntaxNumber:     "#ae81ff",
+			SyntaxComment:    "#75715e",
+			SyntaxParameter:  "#fd971f",
+			SyntaxError:      "#ff5555",
+			DiffAdded:        "#a6e22e",
+			DiffRemoved:      "#f92672",
+			DiffChanged:      "#e6db74",
 		},
 		"cyber-neon": {
-			Name:            "Cyber Neon",
-			Background:      "#0d0d0d",
-			Foreground:      "#00ffcc",
-			Border:          "#ff00ff",
-			BorderFocus:     "#ff0099",
-			Selection:       "#003366",
-			SelectionText:   "#00ffcc",
-			Header:          "#ff0066",
-			Button:          "#330033",
-			ButtonFocus:     "#ff00ff",
-			ButtonText:      "#00ffcc",
-			ButtonTextFocus: "#000000",
+			Name:             "Cyber Neon",
+			Background:       "#0d0d0d",
+			Foreground:       "#00ffcc",
+			Border:           "#ff00ff",
+			BorderFocus:      "#ff0099",
+			Selection:        "#003366",
+			SelectionText:    "#00ffcc",
+			Header:           "#ff0066",
+			Button:           "#330033",
+			ButtonFocus:      "#ff00ff",
+			ButtonText:       "#00ffcc",
+			ButtonTextFocus:  "#000000",
+			SyntaxKeyword:    "#ff00ff",
+			SyntaxIdentifier: "#00ffcc",
+			SyntaxString:     "#ffff00",
+			SyntaxNumber:     "#ff9900",
+			SyntaxComment:    "#666699",
+			SyntaxParameter:  "#00ccff",
+			SyntaxError:      "#ff0033",
+			DiffAdded:        "#00ff66",
+			DiffRemoved:      "#ff0033",
+			DiffChanged:      "#ffff00",
 		},
 		"earthy-tones": {
-			Name:            "Earthy Tones",
-			Background:      "#3b2f2f",
-			Foreground:      "#e3caa5",
-			Border:          "#8b5a2b",
-			BorderFocus:     "#c19a6b",
-			Selection:       "#5c4033",
-			SelectionText:   "#f5deb3",
-			Header:          "#d2b48c",
-			Button:          "#8b5a2b",
-			ButtonFocus:     "#a0522d",
-			ButtonText:      "#e3caa5",
-			ButtonTextFocus: "#3b2f2f",
+			Name:             "Earthy Tones",
+			Background:       "#3b2f2f",
+			Foreground:       "#e3caa5",
+			Border:           "#8b5a2b",
+			BorderFocus:      "#c19a6b",
+			Selection:        "#5c4033",
+			SelectionText:    "#f5deb3",
+			Header:           "#d2b48c",
+			Button:           "#8b5a2b",
+			ButtonFocus:      "#a0522d",
+			ButtonText:       "#e3caa5",
+			ButtonTextFocus:  "#3b2f2f",
+			SyntaxKeyword:    "#cd853f",
+			SyntaxIdentifier: "#e3caa5",
+			SyntaxString:     "#9acd32",
+			SyntaxNumber:     "#deb887",
+			SyntaxComment:    "#8b7d6b",
+			SyntaxParameter:  "#f4a460",
+			SyntaxError:      "#ff4500",
+			DiffAdded:        "#9acd32",
+			DiffRemoved:      "#cd5c5c",
+			DiffChanged:      "#daa520",
 		},
 		"royal-inferno": {
-			Name:            "Royal Inferno",
-			Background:      "#1a0a02",
-			Foreground:      "#ffddaa",
-			Border:          "#8b0000",
-			BorderFocus:     "#ff4500",
-			Selection:       "#330000",
-			SelectionText:   "#ffbb77",
-			Header:          "#ff6600",
-			Button:          "#661a00",
-			ButtonFocus:     "#ff3300",
-			ButtonText:      "#ffcc99",
-			ButtonTextFocus: "#1a0a02",
+			Name:             "Royal Inferno",
+			Background:       "#1a0a02",
+			Foreground:       "#ffddaa",
+			Border:           "#8b0000",
+			BorderFocus:      "#ff4500",
+			Selection:        "#330000",
+			SelectionText:    "#ffbb77",
+			Header:           "#ff6600",
+			Button:           "#661a00",
+			ButtonFocus:      "#ff3300",
+			ButtonText:       "#ffcc99",
+			ButtonTextFocus:  "#1a0a02",
+			SyntaxKeyword:    "#ff6600",
+			SyntaxIdentifier: "#ffddaa",
+			SyntaxString:     "#ffcc00",
+			SyntaxNumber:     "#ff9966",
+			SyntaxComment:    "#996655",
+			SyntaxParameter:  "#ffaa33",
+			SyntaxError:      "#ff0000",
+			DiffAdded:        "#99cc33",
+			DiffRemoved:      "#ff0000",
+			DiffChanged:      "#ffcc00",
 		},
 	}
 }
@@ -325,10 +498,51 @@ func (cm *ColorConfig) loadSavedScheme() (*ColorScheme, error) {
 	if err != nil {
 		return nil, err
 	}
+	scheme.fillMissingColors(cm.Schemes)
 
 	return &scheme, nil
 }
 
+// fillMissingColors sets the syntax highlighting and diff colors that are not set, e.g. in a scheme saved by
+// an older version of sqluv. They are taken from the default scheme of the same name, or else the text
+// is not highlighted, errors are red, and diffs are green, red and yellow.
+func (s *ColorScheme) fillMissingColors(defaults map[string]*ColorScheme) {
+	fallback := &ColorScheme{
+		SyntaxKeyword:    s.Foreground,
+		SyntaxIdentifier: s.Foreground,
+		SyntaxString:     s.Foreground,
+		SyntaxNumber:     s.Foreground,
+		SyntaxComment:    s.Foreground,
+		SyntaxParameter:  s.Foreground,
+		SyntaxError:      "red",
+		DiffAdded:        "green",
+		DiffRemoved:      "red",
+		DiffChanged:      "yellow",
+	}
+	for _, d := range defaults {
+		if d.Name == s.Name {
+			fallback = d
+			break
+		}
+	}
+
+	fill := func(color *string, fallback string) {
+		if *color == "" {
+			*color = fallback
+		}
+	}
+	fill(&s.SyntaxKeyword, fallback.SyntaxKeyword)
+	fill(&s.SyntaxIdentifier, fallback.SyntaxIdentifier)
+	fill(&s.SyntaxString, fallback.SyntaxString)
+	fill(&s.SyntaxNumber, fallback.SyntaxNumber)
+	fill(&s.SyntaxComment, fallback.SyntaxComment)
+	fill(&s.SyntaxParameter, fallback.SyntaxParameter)
+	fill(&s.SyntaxError, fallback.SyntaxError)
+	fill(&s.DiffAdded, fallback.DiffAdded)
+	fill(&s.DiffRemoved, fallback.DiffRemoved)
+	fill(&s.DiffChanged, fallback.DiffChanged)
+}
+
 // SaveCurrentScheme saves the current color scheme to the configuration file
 func (cm *ColorConfig) SaveCurrentScheme() error {
 	if cm.CurrentScheme == nil {
@@ -0,0 +1,101 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDefaultColorSchemesHaveSyntaxAndDiffColors(t *testing.T) {
+	t.Parallel()
+
+	for key, scheme := range DefaultColorSchemes() {
+		for role, color := range map[string]string{
+			"keyword":      scheme.SyntaxKeyword,
+			"identifier":   scheme.SyntaxIdentifier,
+			"string":       scheme.SyntaxString,
+			"number":       scheme.SyntaxNumber,
+			"comment":      scheme.SyntaxComment,
+			"parameter":    scheme.SyntaxParameter,
+			"error":        scheme.SyntaxError,
+			"diff added":   scheme.DiffAdded,
+			"diff removed": scheme.DiffRemoved,
+			"diff changed": scheme.DiffChanged,
+		} {
+			if color == "" {
+				t.Errorf("color scheme %q has no %s color", key, role)
+			}
+		}
+	}
+}
+
+func TestColorConfigLoadSavedScheme(t *testing.T) {
+	t.Parallel()
+
+	nord := DefaultColorSchemes()["nord"]
+	tests := []struct {
+		name  string
+		saved string
+		want  ColorScheme
+	}{
+		{
+			name:  "scheme saved before the syntax highlighting and the diff colors takes the colors of the default scheme",
+			saved: "name: Nord\nbackground: '#2e3440'\nforeground: '#d8dee9'\n",
+			want: ColorScheme{
+				Name:             "Nord",
+				Background:       "#2e3440",
+				Foreground:       "#d8dee9",
+				SyntaxKeyword:    nord.SyntaxKeyword,
+				SyntaxIdentifier: nord.SyntaxIdentifier,
+				SyntaxString:     nord.SyntaxString,
+				SyntaxNumber:     nord.SyntaxNumber,
+				SyntaxComment:    nord.SyntaxComment,
+				SyntaxParameter:  nord.SyntaxParameter,
+				SyntaxError:      nord.SyntaxError,
+				DiffAdded:        nord.DiffAdded,
+				DiffRemoved:      nord.DiffRemoved,
+				DiffChanged:      nord.DiffChanged,
+			},
+		},
+		{
+			name:  "unknown scheme is not highlighted and has the basic diff colors",
+			saved: "name: Mine\nforeground: white\nsyntax_string: green\ndiff_added: '#00ff00'\n",
+			want: ColorScheme{
+				Name:             "Mine",
+				Foreground:       "white",
+				SyntaxKeyword:    "white",
+				SyntaxIdentifier: "white",
+				SyntaxString:     "green",
+				SyntaxNumber:     "white",
+				SyntaxComment:    "white",
+				SyntaxParameter:  "white",
+				SyntaxError:      "red",
+				DiffAdded:        "#00ff00",
+				DiffRemoved:      "red",
+				DiffChanged:      "yellow",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			configPath := filepath.Join(t.TempDir(), "color_scheme.yml")
+			if err := os.WriteFile(configPath, []byte(tt.saved), 0600); err != nil {
+				t.Fatal(err)
+			}
+			cm := &ColorConfig{Schemes: DefaultColorSchemes(), configPath: configPath}
+
+			got, err := cm.loadSavedScheme()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(&tt.want, got); diff != "" {
+				t.Errorf("loadSavedScheme() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -1,15 +1,25 @@
 package config
 
 import (
+	"context"
 	"database/sql"
 	"database/sql/driver"
 	"errors"
 	"fmt"
+	"maps"
+	"net"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/go-sql-driver/mysql"
-	_ "github.com/lib/pq"
-	_ "github.com/microsoft/go-mssqldb"
+	"github.com/lib/pq"
+	mssql "github.com/microsoft/go-mssqldb"
+	"github.com/microsoft/go-mssqldb/msdsn"
+	goora "github.com/sijms/go-ora/v2"
 	"modernc.org/sqlite"
 )
 
@@ -80,6 +90,9 @@ type MySQLConfig struct {
 	user     string
 	password string
 	database string
+	params   map[string]string
+	tls      TLSConfig
+	dial     DialContextFunc
 }
 
 // NewMySQLConfig creates MySQLConfig.
@@ -99,24 +112,78 @@ func NewMySQLConfig(
 	}
 }
 
+// WithParams returns a copy of the configuration with the DSN parameters of the driver,
+// e.g. tls, timeout and collation. They override the defaults of sqluv.
+func (c MySQLConfig) WithParams(params map[string]string) MySQLConfig {
+	c.params = params
+	return c
+}
+
+// WithTLS returns a copy of the configuration with the TLS settings. The "tls" parameter overrides them.
+func (c MySQLConfig) WithTLS(tlsConfig TLSConfig) MySQLConfig {
+	c.tls = tlsConfig
+	return c
+}
+
+// WithDialer returns a copy of the configuration that connects to the server with dial, e.g. through an SSH tunnel.
+func (c MySQLConfig) WithDialer(dial DialContextFunc) MySQLConfig {
+	c.dial = dial
+	return c
+}
+
+// config returns the driver configuration. The collation is utf8mb4_unicode_ci by default.
+func (c MySQLConfig) config() (*mysql.Config, error) {
+	cfg := mysql.NewConfig()
+	cfg.DBName = c.database
+	cfg.User = c.user
+	cfg.Passwd = c.password
+	cfg.Addr = net.JoinHostPort(c.host, strconv.Itoa(c.port))
+	cfg.Net = "tcp"
+	cfg.ParseTime = true
+	cfg.Collation = "utf8mb4_unicode_ci"
+	cfg.AllowNativePasswords = true
+	if len(c.params) > 0 {
+		// The driver reads the parameters in order, so the parameters after the defaults win.
+		values := url.Values{}
+		for key, value := range c.params {
+			values.Set(key, value)
+		}
+		dsn := cfg.FormatDSN()
+		sep := "?"
+		if strings.Contains(dsn, "?") {
+			sep = "&"
+		}
+		parsed, err := mysql.ParseDSN(dsn + sep + values.Encode())
+		if err != nil {
+			return nil, fmt.Errorf("invalid MySQL parameters: %w", err)
+		}
+		cfg = parsed
+	}
+	cfg.DialFunc = c.dial
+
+	if _, ok := c.params["tls"]; ok || c.tls.Mode == "" {
+		return cfg, nil
+	}
+	tlsConfig, err := c.tls.goTLSConfig(c.host)
+	if err != nil {
+		return nil, err
+	}
+	cfg.TLS = tlsConfig
+	return cfg, nil
+}
+
 // NewMySQLDB creates *sql.DB for MySQL.
 // The return function is the function to close the DB.
 func NewMySQLDB(config MySQLConfig) (MySQLDB, func(), error) {
-	c := mysql.Config{
-		DBName:               config.database,
-		User:                 config.user,
-		Passwd:               config.password,
-		Addr:                 fmt.Sprintf("%s:%d", config.host, config.port),
-		Net:                  "tcp",
-		ParseTime:            true,
-		Collation:            "utf8mb4_unicode_ci",
-		AllowNativePasswords: true,
-	}
-
-	db, err := sql.Open("mysql", c.FormatDSN())
+	cfg, err := config.config()
+	if err != nil {
+		return nil, nil, err
+	}
+	connector, err := mysql.NewConnector(cfg)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to connect to MySQL: %w", err)
 	}
+	db := sql.OpenDB(connector)
 
 	if err := db.Ping(); err != nil {
 		db.Close()
@@ -132,16 +199,21 @@ type PostgreSQLConfig struct {
 	user     string
 	password string
 	database string
-	sslMode  string
+	schema   string
+	params   map[string]string
+	tls      TLSConfig
+	dial     DialContextFunc
 }
 
 // NewPostgreSQLConfig creates PostgreSQLConfig.
+// If schema is not empty, it is set to search_path of the session.
 func NewPostgreSQLConfig(
 	host string,
 	port int,
 	user string,
 	password string,
 	database string,
+	schema string,
 ) PostgreSQLConfig {
 	return PostgreSQLConfig{
 		host:     host,
@@ -149,27 +221,84 @@ func NewPostgreSQLConfig(
 		user:     user,
 		password: password,
 		database: database,
-		sslMode:  "disable", // Default to disable for development
+		schema:   schema,
+	}
+}
+
+// WithParams returns a copy of the configuration with the connection parameters of the driver,
+// e.g. sslmode, connect_timeout and application_name. The parameters that the driver does not know
+// are sent to the server as run-time parameters. They override the defaults of sqluv.
+func (c PostgreSQLConfig) WithParams(params map[string]string) PostgreSQLConfig {
+	c.params = params
+	return c
+}
+
+// WithTLS returns a copy of the configuration with the TLS settings. The ssl* parameters override them.
+func (c PostgreSQLConfig) WithTLS(tlsConfig TLSConfig) PostgreSQLConfig {
+	c.tls = tlsConfig
+	return c
+}
+
+// WithDialer returns a copy of the configuration that connects to the server with dial, e.g. through an SSH tunnel.
+func (c PostgreSQLConfig) WithDialer(dial DialContextFunc) PostgreSQLConfig {
+	c.dial = dial
+	return c
+}
+
+// dsn returns the keyword/value connection string of the configuration.
+// sslmode is "disable" by default for development.
+func (c PostgreSQLConfig) dsn() string {
+	options := map[string]string{
+		"host":     c.host,
+		"port":     strconv.Itoa(c.port),
+		"user":     c.user,
+		"password": c.password,
+		"dbname":   c.database,
+		"sslmode":  "disable",
+	}
+	if c.schema != "" {
+		// search_path is sent to the server as a run-time parameter.
+		options["search_path"] = `"` + strings.ReplaceAll(c.schema, `"`, `""`) + `"`
+	}
+	if c.tls.Mode != "" {
+		options["sslmode"] = string(c.tls.Mode)
+		for key, file := range map[string]string{"sslrootcert": c.tls.CAFile, "sslcert": c.tls.CertFile, "sslkey": c.tls.KeyFile} {
+			if file != "" {
+				options[key] = file
+			}
+		}
+		if c.tls.ServerName != "" {
+			// The driver verifies the certificate for the host, so the host is the server name,
+			// and the dialer connects to the actual host.
+			options["host"] = c.tls.ServerName
+		}
 	}
+	maps.Copy(options, c.params)
+
+	quote := strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+	pairs := make([]string, 0, len(options))
+	for _, key := range slices.Sorted(maps.Keys(options)) {
+		pairs = append(pairs, fmt.Sprintf("%s='%s'", key, quote.Replace(options[key])))
+	}
+	return strings.Join(pairs, " ")
 }
 
 // NewPostgreSQLDB creates *sql.DB for PostgreSQL.
 // The return function is the function to close the DB.
 func NewPostgreSQLDB(config PostgreSQLConfig) (PostgreSQLDB, func(), error) {
-	connStr := fmt.Sprintf(
-		"host='%s' port='%d' user='%s' password='%s' dbname='%s' sslmode='%s'",
-		config.host,
-		config.port,
-		config.user,
-		config.password,
-		config.database,
-		config.sslMode,
-	)
-
-	db, err := sql.Open("postgres", connStr)
+	if err := config.tls.Validate(); err != nil {
+		return nil, nil, err
+	}
+	connector, err := pq.NewConnector(config.dsn())
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to connect to PostgreSQL: %w", err)
 	}
+	dialer := pqDialer{dial: config.dial}
+	if config.tls.Mode != "" && config.tls.ServerName != "" {
+		dialer.address = net.JoinHostPort(config.host, strconv.Itoa(config.port))
+	}
+	connector.Dialer(dialer)
+	db := sql.OpenDB(connector)
 
 	if err := db.Ping(); err != nil {
 		db.Close()
@@ -178,18 +307,86 @@ func NewPostgreSQLDB(config PostgreSQLConfig) (PostgreSQLDB, func(), error) {
 	return PostgreSQLDB(db), func() { db.Close() }, nil
 }
 
+// pqDialer is the dialer of the PostgreSQL driver. It connects to address instead of the address that
+// the driver dials if address is not empty, and connects with dial if dial is not nil.
+// The driver verifies the certificate for the host it dials, so address lets it verify a server name
+// that differs from the host.
+type pqDialer struct {
+	dial    DialContextFunc
+	address string
+}
+
+// Dial connects to the server.
+func (d pqDialer) Dial(network, address string) (net.Conn, error) {
+	return d.DialContext(context.Background(), network, address)
+}
+
+// DialTimeout connects to the server with a timeout.
+func (d pqDialer) DialTimeout(network, address string, timeout time.Duration) (net.Conn, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return d.DialContext(ctx, network, address)
+}
+
+// DialContext connects to the server.
+func (d pqDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	if d.address != "" {
+		address = d.address
+	}
+	if d.dial != nil {
+		return d.dial(ctx, network, address)
+	}
+	var dialer net.Dialer
+	return dialer.DialContext(ctx, network, address)
+}
+
 // SQLite3Config holds the configuration for SQLite3 connection.
 type SQLite3Config struct {
 	filepath string
+	readOnly bool
+	params   map[string]string
 }
 
 // NewSQLite3Config creates SQLite3Config.
-func NewSQLite3Config(filepath string) SQLite3Config {
+// If readOnly is true, the database file is opened in read-only mode.
+func NewSQLite3Config(filepath string, readOnly bool) SQLite3Config {
 	return SQLite3Config{
 		filepath: filepath,
+		readOnly: readOnly,
 	}
 }
 
+// WithParams returns a copy of the configuration with the URI parameters of the driver,
+// e.g. _pragma=busy_timeout(5000).
+func (c SQLite3Config) WithParams(params map[string]string) SQLite3Config {
+	c.params = params
+	return c
+}
+
+// dsn returns the file path, or the file URI if the file is opened read-only, has parameters,
+// or has a path that is not read as a file path.
+func (c SQLite3Config) dsn() string {
+	values := url.Values{}
+	for key, value := range c.params {
+		values.Set(key, value)
+	}
+	if c.readOnly {
+		// The sqlite driver ignores read-only transactions, so the file itself is opened read-only.
+		values.Set("mode", "ro")
+	}
+	// The driver reads the parameters after "?", and then "#" and "%" in the path would start the fragment
+	// and an escape of the URI, so such a path is escaped in the file URI.
+	if len(values) == 0 && !strings.ContainsAny(c.filepath, "?#%") {
+		return c.filepath
+	}
+	segments := strings.Split(c.filepath, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	u := url.URL{Scheme: "file", Opaque: strings.Join(segments, "/"), RawQuery: values.Encode()}
+	return u.String()
+}
+
 // SQLite3DB is *sql.DB for executing SQL with SQLite3.
 type SQLite3DB = DBMS
 
@@ -200,7 +397,7 @@ func NewSQLite3DB(config SQLite3Config) (SQLite3DB, func(), error) {
 	// Make sure it's initialized
 	initSQLite3()
 
-	db, err := sql.Open("sqlite3", config.filepath)
+	db, err := sql.Open("sqlite3", config.dsn())
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to connect to SQLite3: %w", err)
 	}
@@ -219,8 +416,9 @@ type SQLServerConfig struct {
 	user     string
 	password string
 	database string
-	// Additional SQL Server specific options can be added here
-	trustServerCertificate bool
+	params   map[string]string
+	tls      TLSConfig
+	dial     DialContextFunc
 }
 
 // NewSQLServerConfig creates SQLServerConfig.
@@ -232,13 +430,73 @@ func NewSQLServerConfig(
 	database string,
 ) SQLServerConfig {
 	return SQLServerConfig{
-		host:                   host,
-		port:                   port,
-		user:                   user,
-		password:               password,
-		database:               database,
-		trustServerCertificate: true, // For development purposes
+		host:     host,
+		port:     port,
+		user:     user,
+		password: password,
+		database: database,
+	}
+}
+
+// WithParams returns a copy of the configuration with the connection parameters of the driver,
+// e.g. encrypt, trustservercertificate, connection timeout and app name. They override the defaults of sqluv.
+func (c SQLServerConfig) WithParams(params map[string]string) SQLServerConfig {
+	c.params = params
+	return c
+}
+
+// WithTLS returns a copy of the configuration with the TLS settings. The encrypt, trustservercertificate,
+// certificate and hostnameincertificate parameters override them.
+func (c SQLServerConfig) WithTLS(tlsConfig TLSConfig) SQLServerConfig {
+	c.tls = tlsConfig
+	return c
+}
+
+// WithDialer returns a copy of the configuration that connects to the server with dial, e.g. through an SSH tunnel.
+// The host name is resolved by dial, i.e. by the SSH server.
+func (c SQLServerConfig) WithDialer(dial DialContextFunc) SQLServerConfig {
+	c.dial = dial
+	return c
+}
+
+// dsn returns the sqlserver:// URL of the configuration. The connection is encrypted, and the server
+// certificate is trusted by default for development. "host\instance" connects to the named instance.
+func (c SQLServerConfig) dsn() string {
+	values := url.Values{}
+	values.Set("database", c.database)
+	values.Set("encrypt", "true")
+	values.Set("trustservercertificate", "true")
+	switch c.tls.Mode {
+	case TLSDisable:
+		values.Set("encrypt", "disable")
+	case TLSVerifyCA:
+		// The chain is verified by the TLS configuration of the connector.
+		values.Set("certificate", c.tls.CAFile)
+	case TLSVerifyFull:
+		values.Set("trustservercertificate", "false")
+		values.Set("certificate", c.tls.CAFile)
+		values.Set("hostnameincertificate", c.tls.ServerName)
+	}
+	for key, value := range values {
+		if value[0] == "" {
+			delete(values, key)
+		}
+	}
+	for key, value := range c.params {
+		values.Set(key, value)
+	}
+
+	host, instance, _ := strings.Cut(c.host, `\`)
+	u := &url.URL{
+		Scheme:   "sqlserver",
+		User:     url.UserPassword(c.user, c.password),
+		Host:     net.JoinHostPort(host, strconv.Itoa(c.port)),
+		RawQuery: values.Encode(),
 	}
+	if instance != "" {
+		u.Path = "/" + instance
+	}
+	return u.String()
 }
 
 // SQLServerDB is *sql.DB for executing SQL with SQL Server.
@@ -247,21 +505,23 @@ type SQLServerDB = DBMS
 // NewSQLServerDB creates *sql.DB for SQL Server.
 // The return function is the function to close the DB.
 func NewSQLServerDB(config SQLServerConfig) (SQLServerDB, func(), error) {
-	// SQL Server connection string format
-	connStr := fmt.Sprintf(
-		"server=%s;user id=%s;password=%s;port=%d;database=%s;encrypt=true;trustservercertificate=%t",
-		config.host,
-		config.user,
-		config.password,
-		config.port,
-		config.database,
-		config.trustServerCertificate,
-	)
-
-	db, err := sql.Open("sqlserver", connStr)
+	if err := config.tls.Validate(); err != nil {
+		return nil, nil, err
+	}
+	params, err := msdsn.Parse(config.dsn())
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to connect to SQL Server: %w", err)
 	}
+	if config.tls.Mode != "" && params.TLSConfig != nil {
+		if err := config.tls.completeTLSConfig(params.TLSConfig); err != nil {
+			return nil, nil, err
+		}
+	}
+	connector := mssql.NewConnectorConfig(params)
+	if config.dial != nil {
+		connector.Dialer = sqlServerDialer{dial: config.dial, host: params.Host}
+	}
+	db := sql.OpenDB(connector)
 
 	if err := db.Ping(); err != nil {
 		db.Close()
@@ -270,6 +530,156 @@ func NewSQLServerDB(config SQLServerConfig) (SQLServerDB, func(), error) {
 	return SQLServerDB(db), func() { db.Close() }, nil
 }
 
+// sqlServerDialer is the dialer of the SQL Server driver. It implements mssql.HostDialer,
+// so that the driver does not resolve the host name before dialing.
+type sqlServerDialer struct {
+	dial DialContextFunc
+	host string
+}
+
+// DialContext connects to the server.
+func (d sqlServerDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	return d.dial(ctx, network, address)
+}
+
+// HostName returns the host of the server.
+func (d sqlServerDialer) HostName() string {
+	return d.host
+}
+
+// OracleConfig holds the configuration for Oracle Database connection.
+type OracleConfig struct {
+	host     string
+	port     int
+	user     string
+	password string
+	service  string
+	sid      string
+	schema   string
+	params   map[string]string
+	tls      TLSConfig
+	dial     DialContextFunc
+}
+
+// NewOracleConfig creates OracleConfig. service is the service name of the database.
+func NewOracleConfig(
+	host string,
+	port int,
+	user string,
+	password string,
+	service string,
+) OracleConfig {
+	return OracleConfig{
+		host:     host,
+		port:     port,
+		user:     user,
+		password: password,
+		service:  service,
+	}
+}
+
+// WithSID returns a copy of the configuration that connects to the instance of the SID instead of the service name.
+func (c OracleConfig) WithSID(sid string) OracleConfig {
+	c.sid = sid
+	return c
+}
+
+// WithSchema returns a copy of the configuration that uses the schema as the current schema of the session.
+// If it is empty, the schema of the user is used.
+func (c OracleConfig) WithSchema(schema string) OracleConfig {
+	c.schema = schema
+	return c
+}
+
+// WithParams returns a copy of the configuration with the URL options of the driver,
+// e.g. "CONNECTION TIMEOUT", "PREFETCH_ROWS" and "WALLET". They override the defaults of sqluv.
+func (c OracleConfig) WithParams(params map[string]string) OracleConfig {
+	c.params = params
+	return c
+}
+
+// WithTLS returns a copy of the configuration with the TLS settings. The server certificate is verified
+// for the host, so ServerName is ignored.
+func (c OracleConfig) WithTLS(tlsConfig TLSConfig) OracleConfig {
+	c.tls = tlsConfig
+	return c
+}
+
+// WithDialer returns a copy of the configuration that connects to the server with dial, e.g. through an SSH tunnel.
+func (c OracleConfig) WithDialer(dial DialContextFunc) OracleConfig {
+	c.dial = dial
+	return c
+}
+
+// dsn returns the oracle:// URL of the configuration. The path is the service name, and the URL options
+// (e.g. SID and SSL) are query parameters.
+func (c OracleConfig) dsn() string {
+	values := url.Values{}
+	if c.sid != "" {
+		values.Set("SID", c.sid)
+	}
+	if c.tls.Mode != "" && c.tls.Mode != TLSDisable {
+		values.Set("SSL", "true")
+	}
+	for key, value := range c.params {
+		values.Set(key, value)
+	}
+	u := &url.URL{
+		Scheme:   "oracle",
+		User:     url.UserPassword(c.user, c.password),
+		Host:     net.JoinHostPort(c.host, strconv.Itoa(c.port)),
+		Path:     "/" + c.service,
+		RawQuery: values.Encode(),
+	}
+	return u.String()
+}
+
+// OracleDB is *sql.DB for executing SQL with Oracle Database.
+type OracleDB = DBMS
+
+// NewOracleDB creates *sql.DB for Oracle Database.
+// The return function is the function to close the DB.
+func NewOracleDB(config OracleConfig) (OracleDB, func(), error) {
+	tlsConfig, err := config.tls.goTLSConfig(config.host)
+	if err != nil {
+		return nil, nil, err
+	}
+	connector, ok := goora.NewConnector(config.dsn()).(*goora.OracleConnector)
+	if !ok {
+		return nil, nil, errors.New("failed to create the connector of Oracle Database")
+	}
+	if tlsConfig != nil {
+		connector.WithTLSConfig(tlsConfig)
+	}
+	if config.dial != nil {
+		connector.Dialer(oracleDialer{dial: config.dial})
+	}
+	db := sql.OpenDB(connector)
+
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, nil, fmt.Errorf("failed to ping Oracle Database: %w", err)
+	}
+	if config.schema != "" {
+		// The session parameter is set to every connection of the pool.
+		if err := goora.AddSessionParam(db, "CURRENT_SCHEMA", pq.QuoteIdentifier(config.schema)); err != nil {
+			db.Close()
+			return nil, nil, fmt.Errorf("failed to set the current schema to %s: %w", config.schema, err)
+		}
+	}
+	return OracleDB(db), func() { db.Close() }, nil
+}
+
+// oracleDialer is the dialer of the Oracle Database driver.
+type oracleDialer struct {
+	dial DialContextFunc
+}
+
+// DialContext connects to the server.
+func (d oracleDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	return d.dial(ctx, network, address)
+}
+
 // HistoryDB is *sql.DB for sqluv shell history.
 type HistoryDB *sql.DB
 
@@ -282,3 +692,127 @@ func NewHistoryDB(cfg *DBConfig) (HistoryDB, func(), error) {
 	}
 	return HistoryDB(db), func() { db.Close() }, nil
 }
+
+// NewDBMS opens the database of the connection settings. The returned function closes the database.
+// If the connection has PasswordCommand, the password is its output. If the connection has an SSH server,
+// the database is connected through an SSH tunnel, which is closed with the database. The pool settings of
+// the connection are applied to the database.
+func NewDBMS(conn *DBConnection) (DBMS, func(), error) {
+	if err := conn.Pool.Validate(); err != nil {
+		return nil, nil, err
+	}
+	if conn.PasswordCommand != "" {
+		password, err := runPasswordCommand(conn.PasswordCommand)
+		if err != nil {
+			return nil, nil, err
+		}
+		withPassword := *conn
+		withPassword.Password = password
+		conn = &withPassword
+	}
+
+	var dial DialContextFunc
+	closeTunnel := func() {}
+	if conn.SSH.Enabled() {
+		if conn.Type == SQLite3 {
+			return nil, nil, errors.New("SQLite3 database files cannot be opened through an SSH tunnel")
+		}
+		tunnel, err := newSSHTunnel(conn.SSH)
+		if err != nil {
+			return nil, nil, err
+		}
+		dial = tunnel.DialContext
+		closeTunnel = tunnel.Close
+	}
+
+	db, closeDB, err := openDBMS(conn, dial)
+	if err != nil {
+		closeTunnel()
+		return nil, nil, err
+	}
+	conn.Pool.apply(db)
+	return db, func() {
+		closeDB()
+		closeTunnel()
+	}, nil
+}
+
+// NewTableEditDBMS returns the database for writing the rows edited in the result table. MySQL returns
+// the number of rows whose values are actually changed by UPDATE, so a MySQL connection is opened again
+// with clientFoundRows, which returns the number of the matched rows. For the other DBMS, it returns db.
+func NewTableEditDBMS(conn *DBConnection, db DBMS) (DBMS, func(), error) {
+	if conn.Type != MySQL || conn.ReadOnly {
+		return db, func() {}, nil
+	}
+	editConn := *conn
+	editConn.Params = maps.Clone(conn.Params)
+	if editConn.Params == nil {
+		editConn.Params = map[string]string{}
+	}
+	editConn.Params["clientFoundRows"] = "true"
+	return NewDBMS(&editConn)
+}
+
+// PingConnection connects to the database of the connection, and returns the round-trip time of a ping
+// on the established connection. The connection is closed before it returns.
+func PingConnection(ctx context.Context, conn *DBConnection) (time.Duration, error) {
+	db, closeDB, err := NewDBMS(conn)
+	if err != nil {
+		return 0, err
+	}
+	defer closeDB()
+
+	start := time.Now()
+	if err := (*sql.DB)(db).PingContext(ctx); err != nil {
+		return 0, fmt.Errorf("failed to ping the database: %w", err)
+	}
+	return time.Since(start), nil
+}
+
+// openDBMS opens the database of the connection settings with dial. dial is nil for the default dialer.
+func openDBMS(conn *DBConnection, dial DialContextFunc) (DBMS, func(), error) {
+	switch conn.Type {
+	case MySQL:
+		mysqlConfig := NewMySQLConfig(
+			conn.Host,
+			conn.Port,
+			conn.User,
+			conn.Password,
+			conn.Database,
+		).WithParams(conn.Params).WithTLS(conn.TLS).WithDialer(dial)
+		return NewMySQLDB(mysqlConfig)
+	case PostgreSQL:
+		pgConfig := NewPostgreSQLConfig(
+			conn.Host,
+			conn.Port,
+			conn.User,
+			conn.Password,
+			conn.Database,
+			conn.Schema,
+		).WithParams(conn.Params).WithTLS(conn.TLS).WithDialer(dial)
+		return NewPostgreSQLDB(pgConfig)
+	case SQLite3:
+		sqliteConfig := NewSQLite3Config(conn.Database, conn.ReadOnly).WithParams(conn.Params)
+		return NewSQLite3DB(sqliteConfig)
+	case SQLServer:
+		sqlserverConfig := NewSQLServerConfig(
+			conn.Host,
+			conn.Port,
+			conn.User,
+			conn.Password,
+			conn.Database,
+		).WithParams(conn.Params).WithTLS(conn.TLS).WithDialer(dial)
+		return NewSQLServerDB(sqlserverConfig)
+	case Oracle:
+		oracleConfig := NewOracleConfig(
+			conn.Host,
+			conn.Port,
+			conn.User,
+			conn.Password,
+			conn.Database,
+		).WithSID(conn.SID).WithSchema(conn.Schema).WithParams(conn.Params).WithTLS(conn.TLS).WithDialer(dial)
+		return NewOracleDB(oracleConfig)
+	default:
+		return nil, nil, fmt.Errorf("unsupported database type: %s", conn.Type)
+	}
+}
@@ -1,6 +1,9 @@
 package config
 
 import (
+	"database/sql"
+	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -24,3 +27,277 @@ func TestNewMemoryDB(t *testing.T) {
 		})
 	}
 }
+
+func TestPostgreSQLConfigDSN(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		config PostgreSQLConfig
+		want   string
+	}{
+		{
+			name:   "defaults",
+			config: NewPostgreSQLConfig("localhost", 5432, "admin", "secret", "shop", ""),
+			want:   "dbname='shop' host='localhost' password='secret' port='5432' sslmode='disable' user='admin'",
+		},
+		{
+			name: "TLS",
+			config: NewPostgreSQLConfig("10.0.0.5", 5432, "admin", "secret", "shop", "").
+				WithTLS(TLSConfig{Mode: TLSVerifyFull, CAFile: "/etc/ca.pem", CertFile: "client.pem", KeyFile: "client.key", ServerName: "db.internal"}),
+			want: "dbname='shop' host='db.internal' password='secret' port='5432' sslcert='client.pem' sslkey='client.key' " +
+				"sslmode='verify-full' sslrootcert='/etc/ca.pem' user='admin'",
+		},
+		{
+			name: "parameters override the defaults",
+			config: NewPostgreSQLConfig("localhost", 5432, "admin", `it's\`, "shop", "sales").
+				WithParams(map[string]string{"sslmode": "require", "application_name": "sqluv"}),
+			want: `application_name='sqluv' dbname='shop' host='localhost' password='it\'s\\' port='5432' ` +
+				`search_path='"sales"' sslmode='require' user='admin'`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.config.dsn(); got != tt.want {
+				t.Errorf("dsn() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMySQLConfigConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		config  MySQLConfig
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "defaults",
+			config: NewMySQLConfig("localhost", 3306, "root", "secret", "app"),
+			want:   "root:secret@tcp(localhost:3306)/app?collation=utf8mb4_unicode_ci&parseTime=true",
+		},
+		{
+			name: "parameters override the defaults",
+			config: NewMySQLConfig("localhost", 3306, "root", "secret", "app").
+				WithParams(map[string]string{"collation": "utf8mb4_general_ci", "tls": "skip-verify"}),
+			want: "root:secret@tcp(localhost:3306)/app?collation=utf8mb4_general_ci&parseTime=true&tls=skip-verify",
+		},
+		{
+			name: "invalid parameter",
+			config: NewMySQLConfig("localhost", 3306, "root", "secret", "app").
+				WithParams(map[string]string{"timeout": "soon"}),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tt.config.config()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("config() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if dsn := got.FormatDSN(); dsn != tt.want {
+				t.Errorf("config().FormatDSN() = %s, want %s", dsn, tt.want)
+			}
+		})
+	}
+}
+
+func TestSQLServerConfigDSN(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		config SQLServerConfig
+		want   string
+	}{
+		{
+			name:   "defaults",
+			config: NewSQLServerConfig("db", 1433, "sa", "p@ss", "sales"),
+			want:   "sqlserver://sa:p%40ss@db:1433?database=sales&encrypt=true&trustservercertificate=true",
+		},
+		{
+			name: "TLS with verification",
+			config: NewSQLServerConfig("db", 1433, "sa", "secret", "sales").
+				WithTLS(TLSConfig{Mode: TLSVerifyFull, CAFile: "/etc/ca.pem", ServerName: "db.internal"}),
+			want: "sqlserver://sa:secret@db:1433?certificate=%2Fetc%2Fca.pem&database=sales&encrypt=true" +
+				"&hostnameincertificate=db.internal&trustservercertificate=false",
+		},
+		{
+			name:   "TLS disabled",
+			config: NewSQLServerConfig("db", 1433, "sa", "secret", "sales").WithTLS(TLSConfig{Mode: TLSDisable}),
+			want:   "sqlserver://sa:secret@db:1433?database=sales&encrypt=disable&trustservercertificate=true",
+		},
+		{
+			name: "instance and parameters",
+			config: NewSQLServerConfig(`db\SQLEXPRESS`, 1433, "sa", "secret", "sales").
+				WithParams(map[string]string{"trustservercertificate": "false", "app name": "sqluv"}),
+			want: "sqlserver://sa:secret@db:1433/SQLEXPRESS?app+name=sqluv&database=sales&encrypt=true&trustservercertificate=false",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.config.dsn(); got != tt.want {
+				t.Errorf("dsn() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSQLite3ConfigDSN(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		config SQLite3Config
+		want   string
+	}{
+		{
+			name:   "file path",
+			config: NewSQLite3Config("app.db", false),
+			want:   "app.db",
+		},
+		{
+			name:   "read-only",
+			config: NewSQLite3Config("app.db", true),
+			want:   "file:app.db?mode=ro",
+		},
+		{
+			name:   "parameters",
+			config: NewSQLite3Config("app.db", true).WithParams(map[string]string{"_pragma": "busy_timeout(5000)", "mode": "rw"}),
+			want:   "file:app.db?_pragma=busy_timeout%285000%29&mode=ro",
+		},
+		{
+			name:   "path with the characters of a URI",
+			config: NewSQLite3Config("/data/what?#100%/app db.sqlite", true),
+			want:   "file:/data/what%3F%23100%25/app%20db.sqlite?mode=ro",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.config.dsn(); got != tt.want {
+				t.Errorf("dsn() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewSQLite3DBReadOnlyPath(t *testing.T) {
+	t.Parallel()
+
+	dir := filepath.Join(t.TempDir(), "what?#100%")
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "app db.sqlite")
+	db, closeDB, err := NewSQLite3DB(NewSQLite3Config(path, false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := (*sql.DB)(db).Exec("CREATE TABLE t (id INTEGER)"); err != nil {
+		t.Fatal(err)
+	}
+	closeDB()
+
+	db, closeDB, err = NewSQLite3DB(NewSQLite3Config(path, true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer closeDB()
+	if _, err := (*sql.DB)(db).Exec("SELECT id FROM t"); err != nil {
+		t.Errorf("the table of %s is not read: %v", path, err)
+	}
+	if _, err := (*sql.DB)(db).Exec("INSERT INTO t VALUES (1)"); err == nil {
+		t.Error("the read-only database is written")
+	}
+}
+
+func TestPingConnection(t *testing.T) {
+	t.Parallel()
+
+	conn := &DBConnection{Type: SQLite3, Database: filepath.Join(t.TempDir(), "app.db")}
+	latency, err := PingConnection(t.Context(), conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if latency < 0 {
+		t.Errorf("PingConnection() latency = %v, want >= 0", latency)
+	}
+
+	if _, err := PingConnection(t.Context(), &DBConnection{Type: "Unknown"}); err == nil {
+		t.Error("PingConnection() error = nil, want an error for an unsupported database")
+	}
+}
+
+func TestNewDBMSPool(t *testing.T) {
+	t.Parallel()
+
+	conn := &DBConnection{
+		Type:     SQLite3,
+		Database: filepath.Join(t.TempDir(), "app.db"),
+		Pool:     PoolConfig{MaxOpen: 3},
+	}
+	db, closeDB, err := NewDBMS(conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer closeDB()
+	if got := (*sql.DB)(db).Stats().MaxOpenConnections; got != 3 {
+		t.Errorf("MaxOpenConnections = %d, want 3", got)
+	}
+
+	conn.Pool = PoolConfig{MaxIdle: -1}
+	if _, _, err := NewDBMS(conn); err == nil {
+		t.Error("NewDBMS() error = nil, want an error for a negative pool setting")
+	}
+}
+
+func TestOracleConfigDSN(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		config OracleConfig
+		want   string
+	}{
+		{
+			name:   "service name",
+			config: NewOracleConfig("db", 1521, "scott", "p@ss", "ORCLPDB1"),
+			want:   "oracle://scott:p%40ss@db:1521/ORCLPDB1",
+		},
+		{
+			name:   "SID",
+			config: NewOracleConfig("db", 1521, "scott", "tiger", "").WithSID("ORCL"),
+			want:   "oracle://scott:tiger@db:1521/?SID=ORCL",
+		},
+		{
+			name: "TLS and parameters",
+			config: NewOracleConfig("db", 2484, "scott", "tiger", "ORCLPDB1").
+				WithTLS(TLSConfig{Mode: TLSRequire}).
+				WithParams(map[string]string{"CONNECTION TIMEOUT": "5"}),
+			want: "oracle://scott:tiger@db:2484/ORCLPDB1?CONNECTION+TIMEOUT=5&SSL=true",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.config.dsn(); got != tt.want {
+				t.Errorf("dsn() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
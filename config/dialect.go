@@ -0,0 +1,219 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/nao1215/sqluv/domain/model"
+)
+
+// Dialect is the SQL dialect of a DBMS. The queries generated by sqluv, e.g. the SELECT statement
+// of the sidebar, DDL and the statements of the import and the table editor, are written with it,
+// so that they are valid for the DBMS even if the names are mixed-case or reserved words.
+type Dialect struct {
+	dbmsType DBMSType
+}
+
+// NewDialect returns the SQL dialect of the DBMS. The local workspace is SQLite3.
+func NewDialect(dbmsType DBMSType) Dialect {
+	return Dialect{dbmsType: dbmsType}
+}
+
+// QuoteIdentifier quotes the identifier with backquotes in MySQL, brackets in SQL Server
+// and double quotes in the other DBMS.
+func (d Dialect) QuoteIdentifier(name string) string {
+	switch d.dbmsType {
+	case MySQL:
+		return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+	case SQLServer:
+		return "[" + strings.ReplaceAll(name, "]", "]]") + "]"
+	default:
+		return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+	}
+}
+
+// QuoteIdentifiers quotes the identifiers and joins them with a comma.
+func (d Dialect) QuoteIdentifiers(names []string) string {
+	quoted := make([]string, 0, len(names))
+	for _, name := range names {
+		quoted = append(quoted, d.QuoteIdentifier(name))
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// QualifiedName returns the quoted name qualified with the quoted schema, e.g. "public"."user".
+// If schema is empty, it returns the quoted name.
+func (d Dialect) QualifiedName(schema, name string) string {
+	if schema == "" {
+		return d.QuoteIdentifier(name)
+	}
+	return d.QuoteIdentifier(schema) + "." + d.QuoteIdentifier(name)
+}
+
+// QuoteLiteral returns the string as a SQL literal. MySQL also escapes backslashes, and SQL Server
+// uses a Unicode literal (N'...').
+func (d Dialect) QuoteLiteral(s string) string {
+	s = strings.ReplaceAll(s, "'", "''")
+	switch d.dbmsType {
+	case MySQL:
+		return "'" + strings.ReplaceAll(s, `\`, `\\`) + "'"
+	case SQLServer:
+		return "N'" + s + "'"
+	default:
+		return "'" + s + "'"
+	}
+}
+
+// Placeholder returns the n-th (1-origin) bind parameter.
+func (d Dialect) Placeholder(n int) string {
+	switch d.dbmsType {
+	case PostgreSQL:
+		return "$" + strconv.Itoa(n)
+	case SQLServer:
+		return "@p" + strconv.Itoa(n)
+	case Oracle:
+		return ":" + strconv.Itoa(n)
+	default:
+		return "?"
+	}
+}
+
+// SelectRows returns the query that selects the first limit rows of the table. The table must be
+// quoted, e.g. by QualifiedName. If limit is 0 or less, all rows are selected. SQL Server uses TOP
+// and Oracle Database uses FETCH FIRST because they do not support LIMIT.
+func (d Dialect) SelectRows(table string, limit int) string {
+	if limit <= 0 {
+		return "SELECT * FROM " + table
+	}
+	switch d.dbmsType {
+	case SQLServer:
+		return fmt.Sprintf("SELECT TOP %d * FROM %s", limit, table)
+	case Oracle:
+		return fmt.Sprintf("SELECT * FROM %s FETCH FIRST %d ROWS ONLY", table, limit)
+	default:
+		return fmt.Sprintf("SELECT * FROM %s LIMIT %d", table, limit)
+	}
+}
+
+// QuoteIdentifierIfNeeded returns the name as it is written in a query. It is quoted only if it is not
+// a plain identifier, is a reserved word of the DBMS, or would be folded to another case by the DBMS:
+// PostgreSQL folds unquoted names to lower case and Oracle Database folds them to upper case.
+func (d Dialect) QuoteIdentifierIfNeeded(name string) string {
+	plain := name != "" && !reservedWords[d.dbmsType][strings.ToUpper(name)]
+	for i, r := range name {
+		if r != '_' && !(r >= 'a' && r <= 'z') && !(r >= 'A' && r <= 'Z') && (i == 0 || !(r >= '0' && r <= '9')) {
+			plain = false
+		}
+	}
+	if plain && name == d.FoldIdentifier(name) {
+		return name
+	}
+	return d.QuoteIdentifier(name)
+}
+
+// FoldIdentifier returns the name of an unquoted identifier as the DBMS stores it: PostgreSQL folds it
+// to lower case and Oracle Database folds it to upper case. The other DBMS keep the name.
+func (d Dialect) FoldIdentifier(name string) string {
+	switch d.dbmsType {
+	case PostgreSQL:
+		return strings.ToLower(name)
+	case Oracle:
+		return strings.ToUpper(name)
+	default:
+		return name
+	}
+}
+
+// SQLDialect returns the rules of the string literals of the DBMS, which the queries are tokenized with:
+// MySQL escapes a quote with a backslash, and PostgreSQL quotes a string with dollar quotes.
+func (d Dialect) SQLDialect() model.SQLDialect {
+	switch d.dbmsType {
+	case MySQL:
+		return model.SQLDialectMySQL
+	case PostgreSQL:
+		return model.SQLDialectPostgreSQL
+	default:
+		return model.SQLDialectStandard
+	}
+}
+
+// commonKeywords are the keywords of the SQL standard that all DBMS support.
+var commonKeywords = []string{
+	"ALL", "ALTER", "AND", "AS", "ASC", "BETWEEN", "BY", "CASE", "COLUMN", "COMMIT", "CONSTRAINT", "CREATE",
+	"CROSS", "DEFAULT", "DELETE", "DESC", "DISTINCT", "DROP", "ELSE", "END", "EXCEPT", "EXISTS", "FOREIGN",
+	"FROM", "FULL", "GROUP", "HAVING", "IN", "INDEX", "INNER", "INSERT", "INTERSECT", "INTO", "IS", "JOIN",
+	"KEY", "LEFT", "LIKE", "NATURAL", "NOT", "NULL", "ON", "OR", "ORDER", "OUTER", "PRIMARY", "REFERENCES",
+	"RIGHT", "ROLLBACK", "SELECT", "SET", "TABLE", "THEN", "UNION", "UNIQUE", "UPDATE", "USING", "VALUES",
+	"VIEW", "WHEN", "WHERE", "WITH",
+}
+
+// dialectKeywords are the keywords of each DBMS in addition to commonKeywords.
+var dialectKeywords = map[DBMSType][]string{
+	MySQL: {
+		"AUTO_INCREMENT", "DESCRIBE", "DUPLICATE", "EXPLAIN", "IGNORE", "LIMIT", "OFFSET", "REGEXP", "REPLACE",
+		"SHOW", "STRAIGHT_JOIN",
+	},
+	PostgreSQL: {
+		"ANALYZE", "CONFLICT", "DO", "EXPLAIN", "ILIKE", "LATERAL", "LIMIT", "NOTHING", "OFFSET", "RETURNING",
+		"SIMILAR", "TRUNCATE",
+	},
+	SQLite3: {
+		"AUTOINCREMENT", "EXPLAIN", "GLOB", "LIMIT", "OFFSET", "PRAGMA", "REPLACE", "RETURNING", "VACUUM",
+	},
+	SQLServer: {
+		"APPLY", "FETCH", "MERGE", "NEXT", "NOLOCK", "OFFSET", "ONLY", "OUTPUT", "ROWS", "TOP", "TRUNCATE",
+	},
+	Oracle: {
+		"CONNECT", "DUAL", "FETCH", "FIRST", "MERGE", "MINUS", "NOCYCLE", "OFFSET", "ONLY", "PRIOR", "ROWNUM",
+		"ROWS", "START", "TRUNCATE",
+	},
+}
+
+// commonFunctions are the functions that all DBMS support.
+var commonFunctions = []string{
+	"ABS", "AVG", "CAST", "COALESCE", "COUNT", "CURRENT_DATE", "CURRENT_TIMESTAMP", "LOWER", "MAX", "MIN",
+	"NULLIF", "REPLACE", "ROUND", "SUM", "TRIM", "UPPER",
+}
+
+// dialectFunctions are the functions of each DBMS in addition to commonFunctions.
+var dialectFunctions = map[DBMSType][]string{
+	MySQL: {
+		"CONCAT", "CONCAT_WS", "CURDATE", "DATE_FORMAT", "DATEDIFF", "GROUP_CONCAT", "IFNULL", "JSON_EXTRACT",
+		"LAST_INSERT_ID", "LENGTH", "NOW", "STR_TO_DATE", "SUBSTRING",
+	},
+	PostgreSQL: {
+		"ARRAY_AGG", "CONCAT", "DATE_TRUNC", "EXTRACT", "GENERATE_SERIES", "JSONB_BUILD_OBJECT", "LENGTH", "NOW",
+		"REGEXP_REPLACE", "SPLIT_PART", "STRING_AGG", "SUBSTRING", "TO_CHAR",
+	},
+	SQLite3: {
+		"DATE", "DATETIME", "GROUP_CONCAT", "IFNULL", "INSTR", "JSON_EXTRACT", "JULIANDAY", "LENGTH", "PRINTF",
+		"RANDOM", "STRFTIME", "SUBSTR", "TOTAL", "TYPEOF",
+	},
+	SQLServer: {
+		"CHARINDEX", "CONCAT", "CONVERT", "DATEADD", "DATEDIFF", "FORMAT", "GETDATE", "ISNULL", "LEN", "NEWID",
+		"STRING_AGG", "SUBSTRING",
+	},
+	Oracle: {
+		"DECODE", "INSTR", "LENGTH", "LISTAGG", "NVL", "NVL2", "SUBSTR", "SYSDATE", "SYSTIMESTAMP", "TO_CHAR",
+		"TO_DATE", "TO_NUMBER", "TRUNC",
+	},
+}
+
+// Keywords returns the sorted upper-cased keywords of the dialect.
+func (d Dialect) Keywords() []string {
+	return sortedWords(commonKeywords, dialectKeywords[d.dbmsType])
+}
+
+// Functions returns the sorted upper-cased functions of the dialect.
+func (d Dialect) Functions() []string {
+	return sortedWords(commonFunctions, dialectFunctions[d.dbmsType])
+}
+
+// sortedWords returns the sorted words of the lists without duplicates.
+func sortedWords(lists ...[]string) []string {
+	words := slices.Concat(lists...)
+	slices.Sort(words)
+	return slices.Compact(words)
+}
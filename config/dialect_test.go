@@ -0,0 +1,207 @@
+package config
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/nao1215/sqluv/domain/model"
+)
+
+func TestDialect(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		dbmsType    DBMSType
+		qualified   string
+		literal     string
+		placeholder string
+		selectRows  string
+	}{
+		{
+			name:        "MySQL",
+			dbmsType:    MySQL,
+			qualified:   "`my``db`.`Order`",
+			literal:     `'it''s \\ ok'`,
+			placeholder: "?",
+			selectRows:  "SELECT * FROM `my``db`.`Order` LIMIT 100",
+		},
+		{
+			name:        "PostgreSQL",
+			dbmsType:    PostgreSQL,
+			qualified:   `"my""db"."Order"`,
+			literal:     `'it''s \ ok'`,
+			placeholder: "$2",
+			selectRows:  `SELECT * FROM "my""db"."Order" LIMIT 100`,
+		},
+		{
+			name:        "SQLite3",
+			dbmsType:    SQLite3,
+			qualified:   `"my""db"."Order"`,
+			literal:     `'it''s \ ok'`,
+			placeholder: "?",
+			selectRows:  `SELECT * FROM "my""db"."Order" LIMIT 100`,
+		},
+		{
+			name:        "SQL Server",
+			dbmsType:    SQLServer,
+			qualified:   "[my\"db].[Order]",
+			literal:     `N'it''s \ ok'`,
+			placeholder: "@p2",
+			selectRows:  "SELECT TOP 100 * FROM [my\"db].[Order]",
+		},
+		{
+			name:        "Oracle",
+			dbmsType:    Oracle,
+			qualified:   `"my""db"."Order"`,
+			literal:     `'it''s \ ok'`,
+			placeholder: ":2",
+			selectRows:  `SELECT * FROM "my""db"."Order" FETCH FIRST 100 ROWS ONLY`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := NewDialect(tt.dbmsType)
+			schema := `my"db`
+			if tt.dbmsType == MySQL {
+				schema = "my`db"
+			}
+			if got := d.QualifiedName(schema, "Order"); got != tt.qualified {
+				t.Errorf("QualifiedName() = %s, want %s", got, tt.qualified)
+			}
+			if got := d.QuoteLiteral(`it's \ ok`); got != tt.literal {
+				t.Errorf("QuoteLiteral() = %s, want %s", got, tt.literal)
+			}
+			if got := d.Placeholder(2); got != tt.placeholder {
+				t.Errorf("Placeholder() = %s, want %s", got, tt.placeholder)
+			}
+			if got := d.SelectRows(tt.qualified, 100); got != tt.selectRows {
+				t.Errorf("SelectRows() = %s, want %s", got, tt.selectRows)
+			}
+			if got, want := d.SelectRows(tt.qualified, 0), "SELECT * FROM "+tt.qualified; got != want {
+				t.Errorf("SelectRows() without limit = %s, want %s", got, want)
+			}
+		})
+	}
+}
+
+func TestDialectQualifiedNameWithoutSchema(t *testing.T) {
+	t.Parallel()
+
+	if got, want := NewDialect(SQLServer).QualifiedName("", "a]b"), "[a]]b]"; got != want {
+		t.Errorf("QualifiedName() = %s, want %s", got, want)
+	}
+}
+
+func TestDialectQuoteIdentifierIfNeeded(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		dbmsType DBMSType
+		ident    string
+		want     string
+	}{
+		{name: "plain name", dbmsType: PostgreSQL, ident: "user_id", want: "user_id"},
+		{name: "mixed case in PostgreSQL", dbmsType: PostgreSQL, ident: "UserId", want: `"UserId"`},
+		{name: "mixed case in MySQL", dbmsType: MySQL, ident: "UserId", want: "UserId"},
+		{name: "lower case in Oracle", dbmsType: Oracle, ident: "emp", want: `"emp"`},
+		{name: "upper case in Oracle", dbmsType: Oracle, ident: "EMP", want: "EMP"},
+		{name: "space", dbmsType: SQLServer, ident: "order id", want: "[order id]"},
+		{name: "leading digit", dbmsType: SQLite3, ident: "1st", want: `"1st"`},
+		{name: "reserved word", dbmsType: MySQL, ident: "order", want: "`order`"},
+		{name: "keyword that is not reserved in MySQL", dbmsType: MySQL, ident: "offset", want: "offset"},
+		{name: "reserved word in PostgreSQL", dbmsType: PostgreSQL, ident: "user", want: `"user"`},
+		{name: "reserved word that is not a completion keyword", dbmsType: PostgreSQL, ident: "grant", want: `"grant"`},
+		{name: "reserved word in SQL Server", dbmsType: SQLServer, ident: "user", want: "[user]"},
+		{name: "reserved word in Oracle", dbmsType: Oracle, ident: "COMMENT", want: `"COMMENT"`},
+		{name: "keyword in SQLite3", dbmsType: SQLite3, ident: "pragma", want: `"pragma"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := NewDialect(tt.dbmsType).QuoteIdentifierIfNeeded(tt.ident); got != tt.want {
+				t.Errorf("QuoteIdentifierIfNeeded() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDialectFoldIdentifier(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		dbmsType DBMSType
+		want     string
+	}{
+		{dbmsType: PostgreSQL, want: "user_data"},
+		{dbmsType: Oracle, want: "USER_DATA"},
+		{dbmsType: MySQL, want: "User_Data"},
+		{dbmsType: SQLServer, want: "User_Data"},
+		{dbmsType: SQLite3, want: "User_Data"},
+	}
+	for _, tt := range tests {
+		if got := NewDialect(tt.dbmsType).FoldIdentifier("User_Data"); got != tt.want {
+			t.Errorf("%s: FoldIdentifier() = %s, want %s", tt.dbmsType, got, tt.want)
+		}
+	}
+}
+
+func TestDialectSQLDialect(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		dbmsType DBMSType
+		want     model.SQLDialect
+	}{
+		{dbmsType: MySQL, want: model.SQLDialectMySQL},
+		{dbmsType: PostgreSQL, want: model.SQLDialectPostgreSQL},
+		{dbmsType: SQLite3, want: model.SQLDialectStandard},
+		{dbmsType: SQLServer, want: model.SQLDialectStandard},
+		{dbmsType: Oracle, want: model.SQLDialectStandard},
+	}
+	for _, tt := range tests {
+		if got := NewDialect(tt.dbmsType).SQLDialect(); got != tt.want {
+			t.Errorf("%s: SQLDialect() = %d, want %d", tt.dbmsType, got, tt.want)
+		}
+	}
+}
+
+func TestDialectKeywordsAndFunctions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		dbmsType    DBMSType
+		keyword     string
+		function    string
+		notKeyword  string
+		notFunction string
+	}{
+		{name: "MySQL", dbmsType: MySQL, keyword: "LIMIT", function: "IFNULL", notKeyword: "TOP", notFunction: "NVL"},
+		{name: "PostgreSQL", dbmsType: PostgreSQL, keyword: "RETURNING", function: "STRING_AGG", notKeyword: "TOP", notFunction: "IFNULL"},
+		{name: "SQLite3", dbmsType: SQLite3, keyword: "PRAGMA", function: "STRFTIME", notKeyword: "TOP", notFunction: "GETDATE"},
+		{name: "SQL Server", dbmsType: SQLServer, keyword: "TOP", function: "GETDATE", notKeyword: "LIMIT", notFunction: "NOW"},
+		{name: "Oracle", dbmsType: Oracle, keyword: "FETCH", function: "NVL", notKeyword: "LIMIT", notFunction: "GETDATE"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := NewDialect(tt.dbmsType)
+			keywords, functions := d.Keywords(), d.Functions()
+			if !slices.IsSorted(keywords) || !slices.IsSorted(functions) {
+				t.Error("keywords and functions must be sorted")
+			}
+			if !slices.Contains(keywords, "SELECT") || !slices.Contains(keywords, tt.keyword) || slices.Contains(keywords, tt.notKeyword) {
+				t.Errorf("unexpected keywords: %v", keywords)
+			}
+			if !slices.Contains(functions, "COUNT") || !slices.Contains(functions, tt.function) || slices.Contains(functions, tt.notFunction) {
+				t.Errorf("unexpected functions: %v", functions)
+			}
+		})
+	}
+}
@@ -11,14 +11,32 @@ import (
 	"os"
 )
 
-// EncryptPassword encrypts a password string using AES-GCM
+// EncryptPassword encrypts a password string using AES-GCM with the legacy key.
+//
+// Deprecated: The legacy key is derived from the home directory path, so anyone who can read dbms.yml
+// can decrypt the password. The passwords are encrypted with the master passphrase instead (see Vault).
 func EncryptPassword(password string) (string, error) {
 	// Generate a key based on a unique machine identifier
 	key, err := getEncryptionKey()
 	if err != nil {
 		return "", err
 	}
+	return sealPassword(key, password)
+}
+
+// DecryptPassword decrypts a password encrypted by EncryptPassword. It is used to migrate
+// the passwords to the master passphrase.
+func DecryptPassword(encryptedPassword string) (string, error) {
+	// Get the encryption key
+	key, err := getEncryptionKey()
+	if err != nil {
+		return "", err
+	}
+	return openPassword(key, encryptedPassword)
+}
 
+// sealPassword encrypts the password with the key using AES-GCM, and returns it base64 encoded.
+func sealPassword(key []byte, password string) (string, error) {
 	// Create cipher block
 	block, err := aes.NewCipher(key)
 	if err != nil {
@@ -44,14 +62,8 @@ func EncryptPassword(password string) (string, error) {
 	return base64.StdEncoding.EncodeToString(ciphertext), nil
 }
 
-// DecryptPassword decrypts an encrypted password string
-func DecryptPassword(encryptedPassword string) (string, error) {
-	// Get the encryption key
-	key, err := getEncryptionKey()
-	if err != nil {
-		return "", err
-	}
-
+// openPassword decrypts the password encrypted by sealPassword.
+func openPassword(key []byte, encryptedPassword string) (string, error) {
 	// Decode the base64 string
 	ciphertext, err := base64.StdEncoding.DecodeString(encryptedPassword)
 	if err != nil {
@@ -87,7 +99,7 @@ func DecryptPassword(encryptedPassword string) (string, error) {
 	return string(plaintext), nil
 }
 
-// getEncryptionKey generates a stable key based on machine-specific identifiers
+// getEncryptionKey generates the legacy key based on machine-specific identifiers
 func getEncryptionKey() ([]byte, error) {
 	// Use the user's home directory path as a unique identifier
 	homeDir, err := os.UserHomeDir()
@@ -100,7 +112,7 @@ func getEncryptionKey() ([]byte, error) {
 	return hash[:], nil
 }
 
-// IsEncrypted checks if a password string is likely encrypted
+// IsEncrypted checks if a password string is likely encrypted with the legacy key
 func IsEncrypted(password string) bool {
 	// Check if it looks like a base64 encoded string
 	_, err := base64.StdEncoding.DecodeString(password)
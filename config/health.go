@@ -0,0 +1,114 @@
+package config
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+)
+
+// healthCheckTimeout is the timeout of a ping of the health check.
+const healthCheckTimeout = 5 * time.Second
+
+// ConnectionStatus is the status of the connection to the database.
+type ConnectionStatus int
+
+const (
+	// ConnectionUnknown is the status before the first health check.
+	ConnectionUnknown ConnectionStatus = iota
+	// ConnectionConnected is the status when the database answers a ping.
+	ConnectionConnected
+	// ConnectionDisconnected is the status when the database does not answer a ping.
+	ConnectionDisconnected
+)
+
+// String returns the name of the status.
+func (s ConnectionStatus) String() string {
+	switch s {
+	case ConnectionConnected:
+		return "Connected"
+	case ConnectionDisconnected:
+		return "Disconnected"
+	default:
+		return "Unknown"
+	}
+}
+
+// HealthMonitor pings the database in the background, and reports the change of the connection status.
+// database/sql opens a new connection when the old one is broken, so a successful ping after
+// a disconnection means that the connection is restored.
+type HealthMonitor struct {
+	db       *sql.DB
+	interval time.Duration
+	onChange func(status ConnectionStatus, err error)
+
+	mu     sync.Mutex
+	status ConnectionStatus
+	stop   chan struct{}
+	once   sync.Once
+}
+
+// NewHealthMonitor returns the health monitor of the database. onChange is called from the goroutine of
+// the health check when the status is changed. err is the error of the ping if the status is ConnectionDisconnected.
+func NewHealthMonitor(db DBMS, interval time.Duration, onChange func(status ConnectionStatus, err error)) *HealthMonitor {
+	if interval <= 0 {
+		interval = defaultHealthCheck
+	}
+	return &HealthMonitor{
+		db:       db,
+		interval: interval,
+		onChange: onChange,
+		stop:     make(chan struct{}),
+	}
+}
+
+// Start checks the connection now and at every interval until Stop is called.
+func (m *HealthMonitor) Start() {
+	go func() {
+		ticker := time.NewTicker(m.interval)
+		defer ticker.Stop()
+		for {
+			m.Check(context.Background()) //nolint:errcheck // the error is reported to onChange
+			select {
+			case <-m.stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop stops the health check. It can be called more than once.
+func (m *HealthMonitor) Stop() {
+	m.once.Do(func() {
+		close(m.stop)
+	})
+}
+
+// Check pings the database and updates the status. It returns the error of the ping.
+func (m *HealthMonitor) Check(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+	err := m.db.PingContext(ctx)
+
+	status := ConnectionConnected
+	if err != nil {
+		status = ConnectionDisconnected
+	}
+	m.mu.Lock()
+	changed := m.status != status
+	m.status = status
+	m.mu.Unlock()
+
+	if changed && m.onChange != nil {
+		m.onChange(status, err)
+	}
+	return err
+}
+
+// Status returns the status of the last health check.
+func (m *HealthMonitor) Status() ConnectionStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.status
+}
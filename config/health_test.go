@@ -0,0 +1,59 @@
+package config
+
+import (
+	"database/sql"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestHealthMonitorCheck(t *testing.T) {
+	t.Parallel()
+
+	db, closeDB, err := NewDBMS(&DBConnection{Type: SQLite3, Database: filepath.Join(t.TempDir(), "app.db")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer closeDB()
+
+	var (
+		mu      sync.Mutex
+		changes []ConnectionStatus
+	)
+	monitor := NewHealthMonitor(db, time.Hour, func(status ConnectionStatus, _ error) {
+		mu.Lock()
+		defer mu.Unlock()
+		changes = append(changes, status)
+	})
+	defer monitor.Stop()
+
+	if got := monitor.Status(); got != ConnectionUnknown {
+		t.Errorf("Status() before the check = %v, want %v", got, ConnectionUnknown)
+	}
+	if err := monitor.Check(t.Context()); err != nil {
+		t.Fatal(err)
+	}
+	// The status is reported only when it is changed.
+	if err := monitor.Check(t.Context()); err != nil {
+		t.Fatal(err)
+	}
+	if err := (*sql.DB)(db).Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := monitor.Check(t.Context()); err == nil {
+		t.Error("Check() error = nil after the database is closed")
+	}
+	if got := monitor.Status(); got != ConnectionDisconnected {
+		t.Errorf("Status() = %v, want %v", got, ConnectionDisconnected)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := []ConnectionStatus{ConnectionConnected, ConnectionDisconnected}
+	if diff := cmp.Diff(want, changes); diff != "" {
+		t.Errorf("status changes mismatch (-want +got):\n%s", diff)
+	}
+}
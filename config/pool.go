@@ -0,0 +1,64 @@
+package config
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// defaultHealthCheck is the interval of the health check of the connection if it is not set.
+const defaultHealthCheck = 15 * time.Second
+
+// PoolConfig is the settings of the connection pool. The zero value of each setting is the default of database/sql.
+type PoolConfig struct {
+	// MaxOpen is the maximum number of open connections. It is unlimited if it is 0.
+	MaxOpen int `yaml:"max_open,omitempty"`
+	// MaxIdle is the maximum number of idle connections. It is 2 if it is 0.
+	MaxIdle int `yaml:"max_idle,omitempty"`
+	// MaxLifetime is the maximum time that a connection is reused, e.g. 30m. It is unlimited if it is 0.
+	MaxLifetime time.Duration `yaml:"max_lifetime,omitempty"`
+	// MaxIdleTime is the maximum time that a connection is idle, e.g. 5m. It is unlimited if it is 0.
+	MaxIdleTime time.Duration `yaml:"max_idle_time,omitempty"`
+	// HealthCheck is the interval of the health check of the connection, e.g. 15s. It is 15 seconds if it is 0.
+	HealthCheck time.Duration `yaml:"health_check,omitempty"`
+}
+
+// IsZero returns true if the pool settings are not set, so that they are not written to dbms.yml.
+func (c PoolConfig) IsZero() bool {
+	return c == PoolConfig{}
+}
+
+// Validate returns an error if a setting is negative.
+func (c PoolConfig) Validate() error {
+	if c.MaxOpen < 0 || c.MaxIdle < 0 {
+		return errors.New("the maximum number of connections in the pool must not be negative")
+	}
+	if c.MaxLifetime < 0 || c.MaxIdleTime < 0 || c.HealthCheck < 0 {
+		return errors.New("the lifetime of connections and the interval of the health check must not be negative")
+	}
+	return nil
+}
+
+// HealthCheckInterval returns the interval of the health check of the connection.
+func (c PoolConfig) HealthCheckInterval() time.Duration {
+	if c.HealthCheck == 0 {
+		return defaultHealthCheck
+	}
+	return c.HealthCheck
+}
+
+// apply sets the pool settings to db. The settings that are not set are left as they are.
+func (c PoolConfig) apply(db *sql.DB) {
+	if c.MaxOpen > 0 {
+		db.SetMaxOpenConns(c.MaxOpen)
+	}
+	if c.MaxIdle > 0 {
+		db.SetMaxIdleConns(c.MaxIdle)
+	}
+	if c.MaxLifetime > 0 {
+		db.SetConnMaxLifetime(c.MaxLifetime)
+	}
+	if c.MaxIdleTime > 0 {
+		db.SetConnMaxIdleTime(c.MaxIdleTime)
+	}
+}
@@ -0,0 +1,370 @@
+package config
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+const (
+	// defaultSSHPort is the port of the SSH server if it is not set.
+	defaultSSHPort = 22
+	// defaultSSHKeepAlive is the interval of keepalive requests if it is not set.
+	defaultSSHKeepAlive = 30 * time.Second
+	// sshDialTimeout is the timeout to connect to the SSH server.
+	sshDialTimeout = 15 * time.Second
+)
+
+// DialContextFunc connects to the address. It is used to connect to the database through an SSH tunnel.
+type DialContextFunc func(ctx context.Context, network, address string) (net.Conn, error)
+
+// SSHTunnelConfig is the settings of the SSH server (bastion host) that the connection to the database goes through.
+// The host and port of the connection are resolved and connected from the SSH server.
+type SSHTunnelConfig struct {
+	// Host is the SSH server. The connection does not use a tunnel if it is empty.
+	Host string `yaml:"host,omitempty"`
+	// Port is the port of the SSH server. It is 22 if it is 0.
+	Port int `yaml:"port,omitempty"`
+	// User is the user of the SSH server.
+	User string `yaml:"user,omitempty"`
+	// KeyFile is the private key file. A key protected by a passphrase must be used through ssh-agent.
+	KeyFile string `yaml:"key_file,omitempty"`
+	// UseAgent authenticates with the keys of ssh-agent (SSH_AUTH_SOCK).
+	UseAgent bool `yaml:"use_agent,omitempty"`
+	// KnownHostsFile is the known_hosts file that verifies the host key. It is ~/.ssh/known_hosts if it is empty.
+	KnownHostsFile string `yaml:"known_hosts_file,omitempty"`
+	// KeepAlive is the interval of keepalive requests, e.g. 30s. It is 30 seconds if it is 0.
+	KeepAlive time.Duration `yaml:"keepalive,omitempty"`
+}
+
+// IsZero returns true if the SSH settings are not set, so that they are not written to dbms.yml.
+func (c SSHTunnelConfig) IsZero() bool {
+	return c == SSHTunnelConfig{}
+}
+
+// Enabled returns true if the connection goes through the SSH server.
+func (c SSHTunnelConfig) Enabled() bool {
+	return c.Host != ""
+}
+
+// Validate returns an error if the SSH server is set without the user or the authentication method.
+func (c SSHTunnelConfig) Validate() error {
+	if !c.Enabled() {
+		return nil
+	}
+	if c.User == "" {
+		return errors.New("the user of the SSH server is not set")
+	}
+	if c.KeyFile == "" && !c.UseAgent {
+		return errors.New("set the private key file or use ssh-agent to authenticate with the SSH server")
+	}
+	if c.Port < 0 || c.Port > 65535 {
+		return fmt.Errorf("invalid port of the SSH server: %d", c.Port)
+	}
+	return nil
+}
+
+// address returns host:port of the SSH server.
+func (c SSHTunnelConfig) address() string {
+	port := c.Port
+	if port == 0 {
+		port = defaultSSHPort
+	}
+	return net.JoinHostPort(c.Host, strconv.Itoa(port))
+}
+
+// sshTunnel connects to the database through the SSH server. If the SSH connection is lost, it connects
+// again at the next dial, and keepalive requests detect the lost connection while the database is idle.
+type sshTunnel struct {
+	address      string
+	clientConfig *ssh.ClientConfig
+	keepAlive    time.Duration
+	closeAgent   func()
+
+	mu     sync.Mutex
+	client *ssh.Client
+	done   chan struct{}
+	once   sync.Once
+}
+
+// newSSHTunnel connects to the SSH server and returns the tunnel. The host key must be in known_hosts.
+func newSSHTunnel(cfg SSHTunnelConfig) (*sshTunnel, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	hostKeyCallback, err := knownHostsCallback(cfg.KnownHostsFile)
+	if err != nil {
+		return nil, err
+	}
+	auth, closeAgent, err := sshAuthMethods(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &sshTunnel{
+		address: cfg.address(),
+		clientConfig: &ssh.ClientConfig{
+			User:              cfg.User,
+			Auth:              auth,
+			HostKeyCallback:   hostKeyCallback,
+			HostKeyAlgorithms: knownHostKeyAlgorithms(hostKeyCallback, cfg.address()),
+			Timeout:           sshDialTimeout,
+		},
+		keepAlive:  cfg.KeepAlive,
+		closeAgent: closeAgent,
+		done:       make(chan struct{}),
+	}
+	if t.keepAlive <= 0 {
+		t.keepAlive = defaultSSHKeepAlive
+	}
+
+	// Connect now, so that an error of the SSH server is not reported as an error of the database.
+	if _, err := t.connect(); err != nil {
+		t.Close()
+		return nil, err
+	}
+	go t.keepAliveLoop()
+	return t, nil
+}
+
+// DialContext connects to the address from the SSH server. If the SSH connection is broken,
+// it connects to the SSH server again and retries once.
+func (t *sshTunnel) DialContext(ctx context.Context, _, address string) (net.Conn, error) {
+	var lastErr error
+	for range 2 {
+		client, err := t.connect()
+		if err != nil {
+			return nil, err
+		}
+		conn, err := client.DialContext(ctx, "tcp", address)
+		if err == nil {
+			return withDeadlines(conn), nil
+		}
+		var openErr *ssh.OpenChannelError
+		if errors.As(err, &openErr) || ctx.Err() != nil {
+			// The SSH server works, but cannot connect to the address.
+			return nil, fmt.Errorf("failed to connect to %s through the SSH server: %w", address, err)
+		}
+		lastErr = err
+		t.drop(client)
+	}
+	return nil, fmt.Errorf("failed to connect to %s through the SSH server: %w", address, lastErr)
+}
+
+// Close closes the SSH connection.
+func (t *sshTunnel) Close() {
+	t.once.Do(func() {
+		close(t.done)
+		t.mu.Lock()
+		client := t.client
+		t.client = nil
+		t.mu.Unlock()
+		if client != nil {
+			client.Close()
+		}
+		if t.closeAgent != nil {
+			t.closeAgent()
+		}
+	})
+}
+
+// connect returns the SSH connection, connecting to the SSH server if there is no connection.
+func (t *sshTunnel) connect() (*ssh.Client, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	select {
+	case <-t.done:
+		return nil, errors.New("the SSH tunnel is closed")
+	default:
+	}
+	if t.client != nil {
+		return t.client, nil
+	}
+	client, err := ssh.Dial("tcp", t.address, t.clientConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the SSH server %s: %w", t.address, err)
+	}
+	t.client = client
+	go func() {
+		// Wait returns when the connection is lost.
+		client.Wait() //nolint:errcheck // The connection is dropped whatever the error is.
+		t.drop(client)
+	}()
+	return client, nil
+}
+
+// drop closes the SSH connection, so that the next dial connects again.
+func (t *sshTunnel) drop(client *ssh.Client) {
+	t.mu.Lock()
+	if t.client == client {
+		t.client = nil
+	}
+	t.mu.Unlock()
+	client.Close()
+}
+
+// keepAliveLoop sends keepalive requests until the tunnel is closed. If the SSH server does not reply
+// in the interval, the connection is dropped.
+func (t *sshTunnel) keepAliveLoop() {
+	ticker := time.NewTicker(t.keepAlive)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-t.done:
+			return
+		case <-ticker.C:
+		}
+
+		t.mu.Lock()
+		client := t.client
+		t.mu.Unlock()
+		if client == nil {
+			continue
+		}
+		replied := make(chan error, 1)
+		go func() {
+			_, _, err := client.SendRequest("keepalive@openssh.com", true, nil)
+			replied <- err
+		}()
+		select {
+		case err := <-replied:
+			if err != nil {
+				t.drop(client)
+			}
+		case <-time.After(t.keepAlive):
+			t.drop(client)
+		case <-t.done:
+			return
+		}
+	}
+}
+
+// withDeadlines returns the connection of an SSH channel through an in-memory pipe. The drivers set
+// deadlines on their connections, but a channel does not support them.
+func withDeadlines(conn net.Conn) net.Conn {
+	local, remote := net.Pipe()
+	go func() {
+		io.Copy(conn, remote) //nolint:errcheck // The error is reported to the driver by the pipe.
+		conn.Close()
+	}()
+	go func() {
+		io.Copy(remote, conn) //nolint:errcheck // The error is reported to the driver by the pipe.
+		remote.Close()
+	}()
+	return local
+}
+
+// knownHostsCallback returns the callback that verifies the host key with the known_hosts file.
+func knownHostsCallback(file string) (ssh.HostKeyCallback, error) {
+	if file == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		file = filepath.Join(home, ".ssh", "known_hosts")
+	}
+	callback, err := knownhosts.New(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the known_hosts file: %w", err)
+	}
+	return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		err := callback(hostname, remote, key)
+		var keyErr *knownhosts.KeyError
+		if errors.As(err, &keyErr) && len(keyErr.Want) == 0 {
+			return fmt.Errorf("the host key of %s is not in %s; connect with ssh once to verify and add it: %w", hostname, file, err)
+		}
+		return err
+	}, nil
+}
+
+// knownHostKeyAlgorithms returns the algorithms of the keys of the host in known_hosts, so that the SSH server
+// sends a key that can be verified. It returns nil (the default algorithms) if the host is unknown.
+func knownHostKeyAlgorithms(callback ssh.HostKeyCallback, address string) []string {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil
+	}
+	signer, err := ssh.NewSignerFromKey(key)
+	if err != nil {
+		return nil
+	}
+	// The known keys of the host are returned in the error for the key that nobody knows.
+	var keyErr *knownhosts.KeyError
+	if err := callback(address, &net.TCPAddr{}, signer.PublicKey()); !errors.As(err, &keyErr) {
+		return nil
+	}
+	algorithms := []string{}
+	for _, known := range keyErr.Want {
+		switch known.Key.Type() {
+		case ssh.KeyAlgoRSA:
+			algorithms = append(algorithms, ssh.KeyAlgoRSASHA512, ssh.KeyAlgoRSASHA256, ssh.KeyAlgoRSA)
+		default:
+			algorithms = append(algorithms, known.Key.Type())
+		}
+	}
+	slices.Sort(algorithms)
+	return slices.Compact(algorithms)
+}
+
+// sshAuthMethods returns the authentication methods of the settings. The returned function closes
+// the connection to ssh-agent. The key file and the keys of ssh-agent are offered by one "publickey"
+// method, because x/crypto/ssh tries each method only once: the agent keys would not be offered after
+// the server rejects the key file.
+func sshAuthMethods(cfg SSHTunnelConfig) ([]ssh.AuthMethod, func(), error) {
+	signers := []ssh.Signer{}
+	if cfg.KeyFile != "" {
+		pem, err := os.ReadFile(cfg.KeyFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read the SSH private key: %w", err)
+		}
+		signer, err := ssh.ParsePrivateKey(pem)
+		if err != nil {
+			var passphraseErr *ssh.PassphraseMissingError
+			if errors.As(err, &passphraseErr) {
+				return nil, nil, fmt.Errorf("the SSH private key %s is protected by a passphrase; add it to ssh-agent and use the agent", cfg.KeyFile)
+			}
+			return nil, nil, fmt.Errorf("failed to parse the SSH private key: %w", err)
+		}
+		signers = append(signers, signer)
+	}
+	if !cfg.UseAgent {
+		methods := []ssh.AuthMethod{}
+		if len(signers) > 0 {
+			methods = append(methods, ssh.PublicKeys(signers...))
+		}
+		return methods, func() {}, nil
+	}
+
+	socket := os.Getenv("SSH_AUTH_SOCK")
+	if socket == "" {
+		return nil, nil, errors.New("ssh-agent is not running (SSH_AUTH_SOCK is not set)")
+	}
+	conn, err := net.Dial("unix", socket)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to ssh-agent: %w", err)
+	}
+	client := agent.NewClient(conn)
+	method := ssh.PublicKeysCallback(func() ([]ssh.Signer, error) {
+		agentSigners, err := client.Signers()
+		if err != nil && len(signers) == 0 {
+			return nil, err
+		}
+		return slices.Concat(signers, agentSigners), nil
+	})
+	return []ssh.AuthMethod{method}, func() { conn.Close() }, nil
+}
@@ -0,0 +1,436 @@
+package config
+
+import (
+	"bufio"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// testSSHServer is an SSH server that forwards direct-tcpip channels like "ssh -L".
+type testSSHServer struct {
+	listener net.Listener
+	config   *ssh.ServerConfig
+	hostKey  ssh.Signer
+
+	mu    sync.Mutex
+	conns []net.Conn
+}
+
+// newTestSSHServer starts the SSH server that accepts the client key.
+func newTestSSHServer(t *testing.T, clientKey ssh.PublicKey) *testSSHServer {
+	t.Helper()
+
+	s := &testSSHServer{hostKey: newTestSigner(t)}
+	s.config = &ssh.ServerConfig{
+		PublicKeyCallback: func(_ ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if string(key.Marshal()) != string(clientKey.Marshal()) {
+				return nil, io.EOF
+			}
+			return nil, nil
+		},
+	}
+	s.config.AddHostKey(s.hostKey)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.listener = listener
+	t.Cleanup(func() { listener.Close() })
+	go s.serve()
+	return s
+}
+
+// serve accepts the SSH connections.
+func (s *testSSHServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.conns = append(s.conns, conn)
+		s.mu.Unlock()
+		go s.handle(conn)
+	}
+}
+
+// handle forwards the direct-tcpip channels of the SSH connection.
+func (s *testSSHServer) handle(conn net.Conn) {
+	_, chans, reqs, err := ssh.NewServerConn(conn, s.config)
+	if err != nil {
+		conn.Close()
+		return
+	}
+	go ssh.DiscardRequests(reqs)
+	for newChannel := range chans {
+		if newChannel.ChannelType() != "direct-tcpip" {
+			newChannel.Reject(ssh.UnknownChannelType, "unsupported channel type") //nolint:errcheck
+			continue
+		}
+		var target struct {
+			Host     string
+			Port     uint32
+			OrigHost string
+			OrigPort uint32
+		}
+		if err := ssh.Unmarshal(newChannel.ExtraData(), &target); err != nil {
+			newChannel.Reject(ssh.ConnectionFailed, err.Error()) //nolint:errcheck
+			continue
+		}
+		backend, err := net.Dial("tcp", net.JoinHostPort(target.Host, strconv.Itoa(int(target.Port))))
+		if err != nil {
+			newChannel.Reject(ssh.ConnectionFailed, err.Error()) //nolint:errcheck
+			continue
+		}
+		channel, requests, err := newChannel.Accept()
+		if err != nil {
+			backend.Close()
+			continue
+		}
+		go ssh.DiscardRequests(requests)
+		go func() {
+			io.Copy(channel, backend) //nolint:errcheck
+			channel.Close()
+		}()
+		go func() {
+			io.Copy(backend, channel) //nolint:errcheck
+			backend.Close()
+		}()
+	}
+}
+
+// disconnectAll closes the SSH connections as if the network is lost.
+func (s *testSSHServer) disconnectAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, conn := range s.conns {
+		conn.Close()
+	}
+	s.conns = nil
+}
+
+// port returns the port of the SSH server.
+func (s *testSSHServer) port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+// writeKnownHosts writes the known_hosts file that has the host key, and returns its path.
+func (s *testSSHServer) writeKnownHosts(t *testing.T, key ssh.PublicKey) string {
+	t.Helper()
+
+	file := filepath.Join(t.TempDir(), "known_hosts")
+	line := knownhosts.Line([]string{knownhosts.Normalize(s.listener.Addr().String())}, key)
+	if err := os.WriteFile(file, []byte(line+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+// newTestSigner returns a new ed25519 key.
+func newTestSigner(t *testing.T) ssh.Signer {
+	t.Helper()
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := ssh.NewSignerFromKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signer
+}
+
+// writePrivateKey writes the private key in the OpenSSH format, and returns its path.
+func writePrivateKey(t *testing.T, key ed25519.PrivateKey) string {
+	t.Helper()
+
+	block, err := ssh.MarshalPrivateKey(key, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(t.TempDir(), "id_ed25519")
+	if err := os.WriteFile(file, pem.EncodeToMemory(block), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+// newEchoServer starts the TCP server that sends back each line, and returns its address.
+func newEchoServer(t *testing.T) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				io.Copy(conn, conn) //nolint:errcheck
+			}()
+		}
+	}()
+	return listener.Addr().String()
+}
+
+// echo sends the message through the connection and returns the reply.
+func echo(t *testing.T, conn net.Conn, message string) string {
+	t.Helper()
+
+	if err := conn.SetDeadline(time.Now().Add(5 * time.Second)); err != nil {
+		t.Fatalf("SetDeadline() = %v", err)
+	}
+	if _, err := conn.Write([]byte(message + "\n")); err != nil {
+		t.Fatal(err)
+	}
+	reply, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil {
+		t.Fatal(err)
+	}
+	return reply[:len(reply)-1]
+}
+
+func TestSSHTunnel(t *testing.T) {
+	t.Parallel()
+
+	_, clientKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientSigner, err := ssh.NewSignerFromKey(clientKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyFile := writePrivateKey(t, clientKey)
+	server := newTestSSHServer(t, clientSigner.PublicKey())
+	backend := newEchoServer(t)
+
+	t.Run("dial through the tunnel and reconnect", func(t *testing.T) {
+		t.Parallel()
+
+		tunnel, err := newSSHTunnel(SSHTunnelConfig{
+			Host:           "127.0.0.1",
+			Port:           server.port(),
+			User:           "sqluv",
+			KeyFile:        keyFile,
+			KnownHostsFile: server.writeKnownHosts(t, server.hostKey.PublicKey()),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer tunnel.Close()
+
+		conn, err := tunnel.DialContext(t.Context(), "tcp", backend)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := echo(t, conn, "select 1"); got != "select 1" {
+			t.Errorf("echo = %s, want select 1", got)
+		}
+		conn.Close()
+
+		// The next dial connects to the SSH server again.
+		server.disconnectAll()
+		conn, err = tunnel.DialContext(t.Context(), "tcp", backend)
+		if err != nil {
+			t.Fatalf("DialContext() after the connection is lost = %v", err)
+		}
+		defer conn.Close()
+		if got := echo(t, conn, "select 2"); got != "select 2" {
+			t.Errorf("echo = %s, want select 2", got)
+		}
+	})
+
+	t.Run("unknown host key", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := newSSHTunnel(SSHTunnelConfig{
+			Host:           "127.0.0.1",
+			Port:           server.port(),
+			User:           "sqluv",
+			KeyFile:        keyFile,
+			KnownHostsFile: server.writeKnownHosts(t, newTestSigner(t).PublicKey()),
+		})
+		if err == nil {
+			t.Error("newSSHTunnel() with a changed host key returns no error")
+		}
+	})
+
+	t.Run("rejected key", func(t *testing.T) {
+		t.Parallel()
+
+		_, otherKey, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = newSSHTunnel(SSHTunnelConfig{
+			Host:           "127.0.0.1",
+			Port:           server.port(),
+			User:           "sqluv",
+			KeyFile:        writePrivateKey(t, otherKey),
+			KnownHostsFile: server.writeKnownHosts(t, server.hostKey.PublicKey()),
+		})
+		if err == nil {
+			t.Error("newSSHTunnel() with a rejected key returns no error")
+		}
+	})
+
+	t.Run("unreachable database", func(t *testing.T) {
+		t.Parallel()
+
+		tunnel, err := newSSHTunnel(SSHTunnelConfig{
+			Host:           "127.0.0.1",
+			Port:           server.port(),
+			User:           "sqluv",
+			KeyFile:        keyFile,
+			KnownHostsFile: server.writeKnownHosts(t, server.hostKey.PublicKey()),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer tunnel.Close()
+
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		closed := listener.Addr().String()
+		listener.Close()
+		if _, err := tunnel.DialContext(t.Context(), "tcp", closed); err == nil {
+			t.Error("DialContext() to a closed port returns no error")
+		}
+	})
+}
+
+// serveTestAgent starts ssh-agent that has the key, and sets SSH_AUTH_SOCK to its socket.
+func serveTestAgent(t *testing.T, key ed25519.PrivateKey) {
+	t.Helper()
+
+	keyring := agent.NewKeyring()
+	if err := keyring.Add(agent.AddedKey{PrivateKey: key}); err != nil {
+		t.Fatal(err)
+	}
+	socket := filepath.Join(t.TempDir(), "agent.sock")
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go agent.ServeAgent(keyring, conn) //nolint:errcheck
+		}
+	}()
+	t.Setenv("SSH_AUTH_SOCK", socket)
+}
+
+func TestSSHTunnelAgent(t *testing.T) {
+	_, clientKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientSigner, err := ssh.NewSignerFromKey(clientKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, wrongKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := newTestSSHServer(t, clientSigner.PublicKey())
+	backend := newEchoServer(t)
+	serveTestAgent(t, clientKey)
+
+	tests := []struct {
+		name    string
+		keyFile string
+	}{
+		{name: "agent only"},
+		{name: "the agent key after a wrong key file", keyFile: writePrivateKey(t, wrongKey)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tunnel, err := newSSHTunnel(SSHTunnelConfig{
+				Host:           "127.0.0.1",
+				Port:           server.port(),
+				User:           "sqluv",
+				KeyFile:        tt.keyFile,
+				UseAgent:       true,
+				KnownHostsFile: server.writeKnownHosts(t, server.hostKey.PublicKey()),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer tunnel.Close()
+
+			conn, err := tunnel.DialContext(t.Context(), "tcp", backend)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer conn.Close()
+			if got := echo(t, conn, "ping"); got != "ping" {
+				t.Errorf("echo = %s, want ping", got)
+			}
+		})
+	}
+}
+
+func TestSSHTunnelConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		config  SSHTunnelConfig
+		wantErr bool
+	}{
+		{name: "no tunnel", config: SSHTunnelConfig{}},
+		{name: "key file", config: SSHTunnelConfig{Host: "bastion", User: "me", KeyFile: "id_ed25519"}},
+		{name: "agent", config: SSHTunnelConfig{Host: "bastion", User: "me", UseAgent: true}},
+		{name: "no user", config: SSHTunnelConfig{Host: "bastion", UseAgent: true}, wantErr: true},
+		{name: "no authentication", config: SSHTunnelConfig{Host: "bastion", User: "me"}, wantErr: true},
+		{name: "invalid port", config: SSHTunnelConfig{Host: "bastion", Port: 70000, User: "me", UseAgent: true}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if err := tt.config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewDBMSThroughSSHTunnelRejectsSQLite3(t *testing.T) {
+	t.Parallel()
+
+	conn := &DBConnection{Type: SQLite3, Database: "app.db", SSH: SSHTunnelConfig{Host: "bastion", User: "me", UseAgent: true}}
+	if _, _, err := NewDBMS(conn); err == nil {
+		t.Error("NewDBMS() returns no error")
+	}
+}
@@ -0,0 +1,167 @@
+package config
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// TLSMode is the TLS mode of a connection. The names follow sslmode of PostgreSQL.
+type TLSMode string
+
+const (
+	// TLSDisable connects without TLS.
+	TLSDisable TLSMode = "disable"
+	// TLSRequire encrypts the connection, but does not verify the server certificate.
+	TLSRequire TLSMode = "require"
+	// TLSVerifyCA encrypts the connection and verifies that the server certificate is signed by a trusted CA.
+	TLSVerifyCA TLSMode = "verify-ca"
+	// TLSVerifyFull verifies the server certificate like TLSVerifyCA, and also verifies that the certificate
+	// is issued for the server name.
+	TLSVerifyFull TLSMode = "verify-full"
+)
+
+// TLSModes is the TLS modes selectable in the connection form. The empty mode uses the defaults of sqluv.
+var TLSModes = []TLSMode{"", TLSDisable, TLSRequire, TLSVerifyCA, TLSVerifyFull}
+
+// TLSConfig is the TLS settings of a connection.
+type TLSConfig struct {
+	// Mode is the TLS mode. If it is empty, the other settings are ignored and the defaults of sqluv are used.
+	Mode TLSMode `yaml:"mode,omitempty"`
+	// CAFile is the PEM file of the CA certificates that sign the server certificate.
+	// The system certificates are used if it is empty.
+	CAFile string `yaml:"ca_file,omitempty"`
+	// CertFile and KeyFile are the PEM files of the client certificate and its private key.
+	CertFile string `yaml:"cert_file,omitempty"`
+	KeyFile  string `yaml:"key_file,omitempty"`
+	// ServerName is the name that the server certificate is verified for. It is the host if it is empty.
+	ServerName string `yaml:"server_name,omitempty"`
+}
+
+// IsZero returns true if the TLS settings are not set, so that they are not written to dbms.yml.
+func (c TLSConfig) IsZero() bool {
+	return c == TLSConfig{}
+}
+
+// Validate returns an error if the mode is unknown or only one of the client certificate and key is given.
+func (c TLSConfig) Validate() error {
+	switch c.Mode {
+	case "", TLSDisable, TLSRequire, TLSVerifyCA, TLSVerifyFull:
+	default:
+		return fmt.Errorf("unknown TLS mode %q: want disable, require, verify-ca or verify-full", c.Mode)
+	}
+	if (c.CertFile == "") != (c.KeyFile == "") {
+		return errors.New("both the client certificate and its key are needed for TLS client authentication")
+	}
+	return nil
+}
+
+// goTLSConfig returns *tls.Config of the settings for the drivers that accept it. host is the server name
+// if ServerName is empty. It returns nil if the mode is empty or TLSDisable.
+func (c TLSConfig) goTLSConfig(host string) (*tls.Config, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	if c.Mode == "" || c.Mode == TLSDisable {
+		return nil, nil
+	}
+
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	if err := c.setClientCertificate(cfg); err != nil {
+		return nil, err
+	}
+	roots, err := c.rootCAs()
+	if err != nil {
+		return nil, err
+	}
+	switch c.Mode {
+	case TLSRequire:
+		cfg.InsecureSkipVerify = true //nolint:gosec // The user asks for encryption without verification.
+	case TLSVerifyCA:
+		// The standard verification also checks the host name, so the chain is verified by itself.
+		cfg.InsecureSkipVerify = true //nolint:gosec // The chain is verified by VerifyPeerCertificate.
+		cfg.VerifyPeerCertificate = verifyCertificateChain(roots)
+	case TLSVerifyFull:
+		cfg.RootCAs = roots
+		cfg.ServerName = c.ServerName
+		if cfg.ServerName == "" {
+			cfg.ServerName = host
+		}
+	}
+	return cfg, nil
+}
+
+// completeTLSConfig adds the client certificate and, for TLSVerifyCA, the verification of the certificate
+// chain to the TLS configuration that a driver makes from its connection parameters.
+func (c TLSConfig) completeTLSConfig(cfg *tls.Config) error {
+	if err := c.setClientCertificate(cfg); err != nil {
+		return err
+	}
+	if c.Mode != TLSVerifyCA {
+		return nil
+	}
+	roots, err := c.rootCAs()
+	if err != nil {
+		return err
+	}
+	cfg.InsecureSkipVerify = true //nolint:gosec // The chain is verified by VerifyPeerCertificate.
+	cfg.VerifyPeerCertificate = verifyCertificateChain(roots)
+	return nil
+}
+
+// setClientCertificate adds the client certificate to the TLS configuration if it is given.
+func (c TLSConfig) setClientCertificate(cfg *tls.Config) error {
+	if c.CertFile == "" {
+		return nil
+	}
+	cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load the client certificate: %w", err)
+	}
+	cfg.Certificates = []tls.Certificate{cert}
+	return nil
+}
+
+// rootCAs returns the certificates of CAFile, or nil for the system certificates.
+func (c TLSConfig) rootCAs() (*x509.CertPool, error) {
+	if c.CAFile == "" {
+		return nil, nil
+	}
+	pem, err := os.ReadFile(c.CAFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the CA file: %w", err)
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates in the CA file %s", c.CAFile)
+	}
+	return roots, nil
+}
+
+// verifyCertificateChain returns the function that verifies that the server certificate is signed by
+// the CA certificates without checking the host name. nil roots are the system certificates.
+func verifyCertificateChain(roots *x509.CertPool) func([][]byte, [][]*x509.Certificate) error {
+	return func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+		if len(rawCerts) == 0 {
+			return errors.New("the server sent no certificate")
+		}
+		certs := make([]*x509.Certificate, 0, len(rawCerts))
+		for _, raw := range rawCerts {
+			cert, err := x509.ParseCertificate(raw)
+			if err != nil {
+				return fmt.Errorf("failed to parse the server certificate: %w", err)
+			}
+			certs = append(certs, cert)
+		}
+		opts := x509.VerifyOptions{Roots: roots, Intermediates: x509.NewCertPool()}
+		for _, cert := range certs[1:] {
+			opts.Intermediates.AddCert(cert)
+		}
+		if _, err := certs[0].Verify(opts); err != nil {
+			return fmt.Errorf("failed to verify the server certificate: %w", err)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,199 @@
+package config
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// testCertificate is a certificate and its key generated for a test.
+type testCertificate struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	der  []byte
+}
+
+// newTestCertificate returns a certificate signed by the parent. It is self-signed if the parent is nil.
+func newTestCertificate(t *testing.T, name string, isCA bool, parent *testCertificate) *testCertificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		DNSNames:              []string{name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  isCA,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	signer, signerKey := template, key
+	if parent != nil {
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testCertificate{cert: cert, key: key, der: der}
+}
+
+// writeFiles writes the certificate and its key as PEM files, and returns their paths.
+func (c *testCertificate) writeFiles(t *testing.T, dir string) (certFile, keyFile string) {
+	t.Helper()
+
+	keyDER, err := x509.MarshalECPrivateKey(c.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certFile = filepath.Join(dir, c.cert.Subject.CommonName+".pem")
+	keyFile = filepath.Join(dir, c.cert.Subject.CommonName+".key")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return certFile, keyFile
+}
+
+func TestTLSConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		config  TLSConfig
+		wantErr bool
+	}{
+		{name: "empty", config: TLSConfig{}},
+		{name: "verify-full with client certificate", config: TLSConfig{Mode: TLSVerifyFull, CertFile: "c.pem", KeyFile: "c.key"}},
+		{name: "unknown mode", config: TLSConfig{Mode: "prefer"}, wantErr: true},
+		{name: "certificate without key", config: TLSConfig{Mode: TLSRequire, CertFile: "c.pem"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if err := tt.config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTLSConfigGoTLSConfig(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	ca := newTestCertificate(t, "ca", true, nil)
+	caFile, _ := ca.writeFiles(t, dir)
+	certFile, keyFile := newTestCertificate(t, "client", false, ca).writeFiles(t, dir)
+
+	t.Run("disable", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := TLSConfig{Mode: TLSDisable}.goTLSConfig("db")
+		if err != nil || got != nil {
+			t.Errorf("goTLSConfig() = (%v, %v), want (nil, nil)", got, err)
+		}
+	})
+
+	t.Run("require with client certificate", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := TLSConfig{Mode: TLSRequire, CertFile: certFile, KeyFile: keyFile}.goTLSConfig("db")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !got.InsecureSkipVerify || len(got.Certificates) != 1 {
+			t.Errorf("goTLSConfig() InsecureSkipVerify = %v, certificates = %d, want true, 1", got.InsecureSkipVerify, len(got.Certificates))
+		}
+	})
+
+	t.Run("verify-full uses the host as the server name", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := TLSConfig{Mode: TLSVerifyFull, CAFile: caFile}.goTLSConfig("db")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.InsecureSkipVerify || got.ServerName != "db" || got.RootCAs == nil {
+			t.Errorf("goTLSConfig() = %+v, want verification of db with the CA", got)
+		}
+	})
+
+	t.Run("verify-ca verifies the chain", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := TLSConfig{Mode: TLSVerifyCA, CAFile: caFile}.goTLSConfig("db")
+		if err != nil {
+			t.Fatal(err)
+		}
+		server := newTestCertificate(t, "other-name", false, ca)
+		if err := got.VerifyPeerCertificate([][]byte{server.der}, nil); err != nil {
+			t.Errorf("VerifyPeerCertificate() of the certificate signed by the CA = %v, want nil", err)
+		}
+		stranger := newTestCertificate(t, "db", false, newTestCertificate(t, "other-ca", true, nil))
+		if err := got.VerifyPeerCertificate([][]byte{stranger.der}, nil); err == nil {
+			t.Error("VerifyPeerCertificate() of the certificate signed by another CA returns no error")
+		}
+	})
+
+	t.Run("CA file without certificates", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := (TLSConfig{Mode: TLSVerifyFull, CAFile: keyFile}).goTLSConfig("db"); err == nil {
+			t.Error("goTLSConfig() returns no error")
+		}
+	})
+
+	t.Run("missing client key", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := TLSConfig{Mode: TLSRequire, CertFile: certFile, KeyFile: filepath.Join(dir, "missing.key")}.goTLSConfig("db")
+		if err == nil {
+			t.Error("goTLSConfig() returns no error")
+		}
+	})
+}
+
+func TestMySQLConfigTLS(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := NewMySQLConfig("db", 3306, "root", "secret", "app").WithTLS(TLSConfig{Mode: TLSVerifyFull}).config()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.TLS == nil || cfg.TLS.ServerName != "db" {
+		t.Errorf("config().TLS = %+v, want verification of db", cfg.TLS)
+	}
+
+	// The tls parameter overrides the TLS settings.
+	cfg, err = NewMySQLConfig("db", 3306, "root", "secret", "app").
+		WithTLS(TLSConfig{Mode: TLSVerifyFull}).
+		WithParams(map[string]string{"tls": "skip-verify"}).
+		config()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.TLS == nil || !cfg.TLS.InsecureSkipVerify {
+		t.Errorf("config().TLS = %+v, want skip-verify", cfg.TLS)
+	}
+}
@@ -0,0 +1,167 @@
+package config
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+	// vaultPasswordPrefix marks a password encrypted with the key derived from the master passphrase.
+	vaultPasswordPrefix = "vault:"
+	// vaultCheckText is encrypted with the key to tell a wrong passphrase.
+	vaultCheckText = "sqluv"
+	// vaultKDF is the key derivation function of the vault.
+	vaultKDF = "argon2id"
+	// PassphraseEnv is the environment variable that has the master passphrase.
+	PassphraseEnv = "SQLUV_PASSPHRASE"
+	// vaultMaxTime, vaultMaxMemory (KiB) and vaultMaxThreads are the upper bounds of the Argon2id parameters
+	// in dbms.yml, so that a broken file does not make sqluv compute or allocate too much.
+	vaultMaxTime    = 16
+	vaultMaxMemory  = 1024 * 1024
+	vaultMaxThreads = 64
+)
+
+var (
+	// ErrLocked means that the saved passwords are encrypted with the master passphrase, and it is not entered.
+	ErrLocked = errors.New("the saved passwords are locked by the master passphrase")
+	// ErrNoPassphrase means that a password cannot be saved because the master passphrase is not set.
+	ErrNoPassphrase = errors.New("set a master passphrase to save passwords")
+	// ErrWrongPassphrase means that the entered master passphrase is wrong.
+	ErrWrongPassphrase = errors.New("wrong master passphrase")
+)
+
+// Vault is the settings of the key that encrypts the passwords in dbms.yml. The key is derived from
+// the master passphrase with Argon2id, and the passphrase itself is not saved.
+type Vault struct {
+	// KDF is the key derivation function. Only argon2id is supported.
+	KDF string `yaml:"kdf"`
+	// Salt is the base64 encoded salt of the KDF.
+	Salt string `yaml:"salt"`
+	// Time, Memory (KiB) and Threads are the parameters of Argon2id.
+	Time    uint32 `yaml:"time"`
+	Memory  uint32 `yaml:"memory"`
+	Threads uint8  `yaml:"threads"`
+	// Check is a known text encrypted with the key. It tells a wrong passphrase.
+	Check string `yaml:"check"`
+}
+
+// newVault returns the vault of the passphrase and its key.
+func newVault(passphrase string) (*Vault, []byte, error) {
+	if passphrase == "" {
+		return nil, nil, errors.New("the master passphrase is empty")
+	}
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, nil, err
+	}
+	v := &Vault{
+		KDF:     vaultKDF,
+		Salt:    base64.StdEncoding.EncodeToString(salt),
+		Time:    3,
+		Memory:  64 * 1024,
+		Threads: 4,
+	}
+	key := argon2.IDKey([]byte(passphrase), salt, v.Time, v.Memory, v.Threads, 32)
+	check, err := sealPassword(key, vaultCheckText)
+	if err != nil {
+		return nil, nil, err
+	}
+	v.Check = check
+	return v, key, nil
+}
+
+// validate returns an error if the settings of the vault are broken, e.g. by hand-editing dbms.yml.
+// Argon2id panics with zero parameters and allocates Memory KiB before the passphrase is checked.
+func (v *Vault) validate() error {
+	if v.KDF != vaultKDF {
+		return fmt.Errorf("unsupported key derivation function of the vault: %s", v.KDF)
+	}
+	salt, err := base64.StdEncoding.DecodeString(v.Salt)
+	if err != nil {
+		return fmt.Errorf("invalid salt of the vault: %w", err)
+	}
+	if len(salt) == 0 {
+		return errors.New("the salt of the vault is empty")
+	}
+	if v.Time == 0 || v.Time > vaultMaxTime {
+		return fmt.Errorf("the time of the vault must be between 1 and %d: %d", vaultMaxTime, v.Time)
+	}
+	if v.Memory == 0 || v.Memory > vaultMaxMemory {
+		return fmt.Errorf("the memory of the vault must be between 1 and %d KiB: %d", vaultMaxMemory, v.Memory)
+	}
+	if v.Threads == 0 || v.Threads > vaultMaxThreads {
+		return fmt.Errorf("the threads of the vault must be between 1 and %d: %d", vaultMaxThreads, v.Threads)
+	}
+	return nil
+}
+
+// key returns the key derived from the passphrase. It returns ErrWrongPassphrase if the passphrase is wrong.
+func (v *Vault) key(passphrase string) ([]byte, error) {
+	if err := v.validate(); err != nil {
+		return nil, err
+	}
+	salt, err := base64.StdEncoding.DecodeString(v.Salt)
+	if err != nil {
+		return nil, err
+	}
+	key := argon2.IDKey([]byte(passphrase), salt, v.Time, v.Memory, v.Threads, 32)
+	if check, err := openPassword(key, v.Check); err != nil || check != vaultCheckText {
+		return nil, ErrWrongPassphrase
+	}
+	return key, nil
+}
+
+// encryptVaultPassword encrypts the password with the key of the vault.
+func encryptVaultPassword(key []byte, password string) (string, error) {
+	sealed, err := sealPassword(key, password)
+	if err != nil {
+		return "", err
+	}
+	return vaultPasswordPrefix + sealed, nil
+}
+
+// isVaultPassword returns true if the password is encrypted with the key of the vault.
+func isVaultPassword(password string) bool {
+	return strings.HasPrefix(password, vaultPasswordPrefix)
+}
+
+// decryptVaultPassword decrypts the password encrypted with the key of the vault.
+func decryptVaultPassword(key []byte, password string) (string, error) {
+	return openPassword(key, strings.TrimPrefix(password, vaultPasswordPrefix))
+}
+
+// runPasswordCommand runs the command with the shell and returns the first line of its output,
+// e.g. "pass show db/prod".
+func runPasswordCommand(command string) (string, error) {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", command)
+	} else {
+		cmd = exec.Command("sh", "-c", command)
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("password command failed: %w: %s", err, msg)
+		}
+		return "", fmt.Errorf("password command failed: %w", err)
+	}
+	password, _, _ := strings.Cut(string(out), "\n")
+	return strings.TrimSuffix(password, "\r"), nil
+}
+
+// passphraseFromEnv returns the master passphrase in the environment variable.
+func passphraseFromEnv() string {
+	return os.Getenv(PassphraseEnv)
+}
@@ -0,0 +1,339 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"gopkg.in/yaml.v3"
+)
+
+// writeLegacyConfig writes dbms.yml that has a password encrypted with the legacy key.
+func writeLegacyConfig(t *testing.T, path string, conns ...DBConnection) {
+	t.Helper()
+	key, err := getEncryptionKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, conn := range conns {
+		if conns[i].Password, err = sealPassword(key, conn.Password); err != nil {
+			t.Fatal(err)
+		}
+	}
+	data, err := yaml.Marshal(DBConfigFile{Connections: conns})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// readConfigFile reads dbms.yml as it is saved.
+func readConfigFile(t *testing.T, path string) DBConfigFile {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var file DBConfigFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func TestDBConfigSetPassphrase(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "dbms.yml")
+	writeLegacyConfig(t, path,
+		DBConnection{Name: "prod", Type: PostgreSQL, Host: "db.example.com", Port: 5432, User: "admin", Password: "legacy-secret"})
+
+	cm := &DBConfig{configPath: path}
+	if cm.HasPassphrase() {
+		t.Fatal("HasPassphrase() = true before the passphrase is set")
+	}
+	if err := cm.SetPassphrase("correct horse"); err != nil {
+		t.Fatal(err)
+	}
+
+	file := readConfigFile(t, path)
+	if file.Vault == nil {
+		t.Fatal("the vault is not saved")
+	}
+	if got := file.Connections[0].Password; !isVaultPassword(got) || strings.Contains(got, "legacy-secret") {
+		t.Errorf("the legacy password is not migrated: %s", got)
+	}
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if perm := info.Mode().Perm(); perm != 0600 {
+			t.Errorf("permission of dbms.yml = %o, want 600", perm)
+		}
+	}
+
+	t.Run("locked until the passphrase is entered", func(t *testing.T) {
+		t.Parallel()
+
+		cm := &DBConfig{configPath: path}
+		if !cm.IsLocked() {
+			t.Error("IsLocked() = false before Unlock()")
+		}
+		if _, err := cm.LoadConnections(); !errors.Is(err, ErrLocked) {
+			t.Errorf("LoadConnections() error = %v, want ErrLocked", err)
+		}
+		if _, err := cm.ResolveConnection("prod"); !errors.Is(err, ErrLocked) {
+			t.Errorf("ResolveConnection() error = %v, want ErrLocked", err)
+		}
+		if err := cm.SaveConnection(DBConnection{Name: "dev", Type: MySQL, Password: "p"}); !errors.Is(err, ErrLocked) {
+			t.Errorf("SaveConnection() error = %v, want ErrLocked", err)
+		}
+	})
+
+	t.Run("wrong passphrase", func(t *testing.T) {
+		t.Parallel()
+
+		cm := &DBConfig{configPath: path}
+		if err := cm.Unlock("wrong"); !errors.Is(err, ErrWrongPassphrase) {
+			t.Errorf("Unlock() error = %v, want ErrWrongPassphrase", err)
+		}
+	})
+
+	t.Run("unlock", func(t *testing.T) {
+		t.Parallel()
+
+		cm := &DBConfig{configPath: path}
+		if err := cm.Unlock("correct horse"); err != nil {
+			t.Fatal(err)
+		}
+		if cm.IsLocked() {
+			t.Error("IsLocked() = true after Unlock()")
+		}
+		conns, err := cm.LoadConnections()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff("legacy-secret", conns[0].Password); diff != "" {
+			t.Errorf("password mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestDBConfigChangePassphrase(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "dbms.yml")
+	cm := &DBConfig{configPath: path}
+	if err := cm.SetPassphrase("old"); err != nil {
+		t.Fatal(err)
+	}
+	if err := cm.SaveConnection(DBConnection{Name: "prod", Type: MySQL, Host: "localhost", Port: 3306, Password: "secret"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The current passphrase must be entered to change it.
+	if err := (&DBConfig{configPath: path}).SetPassphrase("new"); !errors.Is(err, ErrLocked) {
+		t.Errorf("SetPassphrase() while locked error = %v, want ErrLocked", err)
+	}
+	if err := cm.SetPassphrase("new"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := (&DBConfig{configPath: path}).Unlock("old"); !errors.Is(err, ErrWrongPassphrase) {
+		t.Errorf("Unlock() with the old passphrase error = %v, want ErrWrongPassphrase", err)
+	}
+	reopened := &DBConfig{configPath: path}
+	if err := reopened.Unlock("new"); err != nil {
+		t.Fatal(err)
+	}
+	conn, err := reopened.GetConnectionByName("prod")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff("secret", conn.Password); diff != "" {
+		t.Errorf("password mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestDBConfigUnlockMigratesLegacyPasswords(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "dbms.yml")
+	cm := &DBConfig{configPath: path}
+	if err := cm.SetPassphrase("passphrase"); err != nil {
+		t.Fatal(err)
+	}
+	vault := readConfigFile(t, path).Vault
+
+	// An entry saved by an old version of sqluv after the passphrase is set.
+	writeLegacyConfig(t, path, DBConnection{Name: "old", Type: MySQL, Host: "localhost", Port: 3306, Password: "old-secret"})
+	file := readConfigFile(t, path)
+	file.Vault = vault
+	data, err := yaml.Marshal(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened := &DBConfig{configPath: path}
+	if err := reopened.Unlock("passphrase"); err != nil {
+		t.Fatal(err)
+	}
+	if got := readConfigFile(t, path).Connections[0].Password; !isVaultPassword(got) {
+		t.Errorf("the legacy password is not migrated: %s", got)
+	}
+	conn, err := reopened.GetConnectionByName("old")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff("old-secret", conn.Password); diff != "" {
+		t.Errorf("password mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestDBConfigSaveConnectionWithoutPassphrase(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "dbms.yml")
+	cm := &DBConfig{configPath: path}
+
+	err := cm.SaveConnection(DBConnection{Name: "prod", Type: MySQL, Host: "localhost", Port: 3306, Password: "secret"})
+	if !errors.Is(err, ErrNoPassphrase) {
+		t.Errorf("SaveConnection() error = %v, want ErrNoPassphrase", err)
+	}
+
+	// The password of a password command is not saved, so the passphrase is not needed.
+	withCommand := DBConnection{Name: "prod", Type: MySQL, Host: "localhost", Port: 3306, Password: "secret", PasswordCommand: "pass show db/prod"}
+	if err := cm.SaveConnection(withCommand); err != nil {
+		t.Fatal(err)
+	}
+	file := readConfigFile(t, path)
+	want := withCommand
+	want.Password = ""
+	if diff := cmp.Diff([]DBConnection{want}, file.Connections); diff != "" {
+		t.Errorf("saved connections mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestDBConfigUnlockFromEnv(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dbms.yml")
+	if err := (&DBConfig{configPath: path}).SetPassphrase("from env"); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv(PassphraseEnv, "from env")
+	cm := &DBConfig{configPath: path}
+	if cm.IsLocked() {
+		t.Errorf("IsLocked() = true with %s", PassphraseEnv)
+	}
+	if err := cm.SaveConnection(DBConnection{Name: "prod", Type: MySQL, Password: "secret"}); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv(PassphraseEnv, "wrong")
+	if _, err := (&DBConfig{configPath: path}).LoadConnections(); !errors.Is(err, ErrWrongPassphrase) {
+		t.Errorf("LoadConnections() error = %v, want ErrWrongPassphrase", err)
+	}
+}
+
+func TestDBConfigUnlockBrokenVault(t *testing.T) {
+	t.Parallel()
+
+	valid, _, err := newVault("passphrase")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name  string
+		vault func(v Vault) Vault
+	}{
+		{name: "zeroed vault", vault: func(Vault) Vault { return Vault{} }},
+		{name: "zero time", vault: func(v Vault) Vault { v.Time = 0; return v }},
+		{name: "zero memory", vault: func(v Vault) Vault { v.Memory = 0; return v }},
+		{name: "zero threads", vault: func(v Vault) Vault { v.Threads = 0; return v }},
+		{name: "too much memory", vault: func(v Vault) Vault { v.Memory = 1 << 31; return v }},
+		{name: "empty salt", vault: func(v Vault) Vault { v.Salt = ""; return v }},
+		{name: "unknown KDF", vault: func(v Vault) Vault { v.KDF = "scrypt"; return v }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			vault := tt.vault(*valid)
+			if _, err := vault.key("passphrase"); err == nil {
+				t.Error("Vault.key() error = nil, want an error")
+			}
+
+			path := filepath.Join(t.TempDir(), "dbms.yml")
+			data, err := yaml.Marshal(DBConfigFile{Vault: &vault})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(path, data, 0600); err != nil {
+				t.Fatal(err)
+			}
+			if err := (&DBConfig{configPath: path}).Unlock("passphrase"); err == nil || errors.Is(err, ErrWrongPassphrase) {
+				t.Errorf("Unlock() error = %v, want an error of the broken vault", err)
+			}
+		})
+	}
+}
+
+func TestRunPasswordCommand(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("the commands are for sh")
+	}
+
+	tests := []struct {
+		name    string
+		command string
+		want    string
+		wantErr string
+	}{
+		{
+			name:    "first line of the output",
+			command: `printf 'secret\nignored\n'`,
+			want:    "secret",
+		},
+		{
+			name:    "password with spaces",
+			command: `echo ' pass word '`,
+			want:    " pass word ",
+		},
+		{
+			name:    "failed command",
+			command: `echo 'no such entry' >&2; exit 1`,
+			wantErr: "no such entry",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := runPasswordCommand(tt.command)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("runPasswordCommand() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("runPasswordCommand() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
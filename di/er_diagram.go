@@ -0,0 +1,18 @@
+package di
+
+import (
+	"github.com/nao1215/sqluv/config"
+	"github.com/nao1215/sqluv/infrastructure/persistence"
+	"github.com/nao1215/sqluv/interactor"
+	"github.com/nao1215/sqluv/usecase"
+)
+
+// NewERDiagramGenerator connects to the database and creates an ERDiagramGenerator for the er subcommand.
+// The returned function closes the database.
+func NewERDiagramGenerator(conn *config.DBConnection) (usecase.ERDiagramGenerator, func(), error) {
+	db, closeDB, err := config.NewDBMS(conn)
+	if err != nil {
+		return nil, nil, err
+	}
+	return interactor.NewERDiagramGenerator(persistence.NewTableSchemasGetter(db, conn)), closeDB, nil
+}
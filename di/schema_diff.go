@@ -0,0 +1,33 @@
+package di
+
+import (
+	"github.com/nao1215/sqluv/config"
+	"github.com/nao1215/sqluv/infrastructure/persistence"
+	"github.com/nao1215/sqluv/interactor"
+	"github.com/nao1215/sqluv/usecase"
+)
+
+// NewSchemaComparer connects to the two databases and creates a SchemaComparer for the diff subcommand.
+// It is not generated by wire, because wire cannot tell the two connections apart.
+// The returned function closes both databases.
+func NewSchemaComparer(from, to *config.DBConnection) (usecase.SchemaComparer, func(), error) {
+	fromDB, closeFrom, err := config.NewDBMS(from)
+	if err != nil {
+		return nil, nil, err
+	}
+	toDB, closeTo, err := config.NewDBMS(to)
+	if err != nil {
+		closeFrom()
+		return nil, nil, err
+	}
+
+	comparer := interactor.NewSchemaComparer(
+		persistence.NewTableSchemasGetter(fromDB, from),
+		persistence.NewTableSchemasGetter(toDB, to),
+		persistence.NewAlterStatementsGenerator(from),
+	)
+	return comparer, func() {
+		closeFrom()
+		closeTo()
+	}, nil
+}
@@ -31,7 +31,8 @@ func NewSqluv(ctx context.Context, arg *config.Argument) (*tui.TUI, func(), erro
 	csvWriter := persistence.NewCSVWriter()
 	tsvWriter := persistence.NewTSVWriter()
 	ltsvWriter := persistence.NewLTSVWriter()
-	fileWriter := interactor.NewFileWriter(csvWriter, tsvWriter, ltsvWriter)
+	jsonWriter := persistence.NewJSONWriter()
+	fileWriter := interactor.NewFileWriter(csvWriter, tsvWriter, ltsvWriter, jsonWriter)
 	memoryDB, cleanup, err := config.NewMemoryDB()
 	if err != nil {
 		return nil, nil, err
@@ -42,6 +43,8 @@ func NewSqluv(ctx context.Context, arg *config.Argument) (*tui.TUI, func(), erro
 	usecaseTablesGetter := interactor.NewLocalTablesGetter(tablesGetter)
 	tableDDLGetter := memory.NewTableDDLGetter(memoryDB)
 	usecaseTableDDLGetter := interactor.NewTableDDLGetter(tableDDLGetter)
+	createTableStatementGetter := memory.NewCreateTableStatementGetter(memoryDB)
+	usecaseCreateTableStatementGetter := interactor.NewCreateTableStatementGetter(createTableStatementGetter)
 	queryExecutor := memory.NewQueryExecutor(memoryDB)
 	statementExecutor := memory.NewStatementExecutor(memoryDB)
 	sqlExecutor := interactor.NewSQLExecutor(queryExecutor, statementExecutor)
@@ -69,7 +72,7 @@ func NewSqluv(ctx context.Context, arg *config.Argument) (*tui.TUI, func(), erro
 		cleanup()
 		return nil, nil, err
 	}
-	tuiTUI := tui.NewTUI(arg, fileReader, fileWriter, usecaseTableCreator, usecaseTablesGetter, usecaseTableDDLGetter, sqlExecutor, usecaseRecordsInserter, usecaseHistoryTableCreator, usecaseHistoryCreator, usecaseHistoryLister, dbConfig, colorConfig)
+	tuiTUI := tui.NewTUI(arg, fileReader, fileWriter, usecaseTableCreator, usecaseTablesGetter, usecaseTableDDLGetter, usecaseCreateTableStatementGetter, sqlExecutor, usecaseRecordsInserter, usecaseHistoryTableCreator, usecaseHistoryCreator, usecaseHistoryLister, dbConfig, colorConfig)
 	return tuiTUI, func() {
 		cleanup2()
 		cleanup()
@@ -0,0 +1,330 @@
+package model
+
+import (
+	"strings"
+	"unicode"
+)
+
+// CompletionKind is the kind of a completion candidate.
+type CompletionKind int
+
+const (
+	// CompletionColumn is a column of a table.
+	CompletionColumn CompletionKind = iota
+	// CompletionTable is a table.
+	CompletionTable
+	// CompletionFunction is a function of the SQL dialect.
+	CompletionFunction
+	// CompletionKeyword is a keyword of the SQL dialect.
+	CompletionKeyword
+)
+
+// String returns the kind shown next to the candidate.
+func (k CompletionKind) String() string {
+	switch k {
+	case CompletionColumn:
+		return "column"
+	case CompletionTable:
+		return "table"
+	case CompletionFunction:
+		return "function"
+	default:
+		return "keyword"
+	}
+}
+
+// Completion is a completion candidate.
+type Completion struct {
+	// Text replaces the word before the cursor.
+	Text string
+	Kind CompletionKind
+	// Detail describes the candidate, e.g. the table of a column.
+	Detail string
+}
+
+// Completer completes keywords, functions, tables and columns in a SQL query.
+type Completer struct {
+	tables    []*Table
+	dialect   SQLDialect
+	keywords  []string
+	functions []string
+	reserved  map[string]bool
+	// identifier returns a table or column name as it is written in a query, e.g. quoted if it has a space.
+	identifier func(name string) string
+}
+
+// NewCompleter returns a Completer. dialect is the rules of the string literals, keywords and functions are
+// the upper-cased keywords and functions of the SQL dialect, and identifier returns a table or column name
+// as it is written in a query.
+func NewCompleter(
+	tables []*Table,
+	dialect SQLDialect,
+	keywords, functions []string,
+	identifier func(name string) string,
+) *Completer {
+	reserved := make(map[string]bool, len(keywords))
+	for _, keyword := range keywords {
+		reserved[keyword] = true
+	}
+	return &Completer{
+		tables:     tables,
+		dialect:    dialect,
+		keywords:   keywords,
+		functions:  functions,
+		reserved:   reserved,
+		identifier: identifier,
+	}
+}
+
+// tableClauses are the keywords that are followed by a table.
+var tableClauses = map[string]bool{"FROM": true, "JOIN": true, "UPDATE": true, "INTO": true, "TABLE": true}
+
+// columnClauses are the keywords that are followed by expressions of columns.
+var columnClauses = map[string]bool{
+	"SELECT": true, "WHERE": true, "ON": true, "BY": true, "HAVING": true, "SET": true, "USING": true, "RETURNING": true,
+}
+
+// otherClauses are the keywords that start a clause without tables or columns.
+var otherClauses = map[string]bool{"VALUES": true, "LIMIT": true, "OFFSET": true, "FETCH": true, "GROUP": true, "ORDER": true}
+
+// scopedTable is a table in the FROM, JOIN, UPDATE or INTO clause of the query, and its alias.
+type scopedTable struct {
+	table *Table
+	alias string
+}
+
+// Complete returns the candidates for the word that ends at the cursor (a byte offset in the query) and
+// the start of the word, which is replaced with the candidate. The candidates depend on the clause:
+// tables after FROM and JOIN, columns of the tables in the query (or their alias) after SELECT, WHERE and ON,
+// and columns of the table after "table." or "alias.". Keywords and functions are upper-cased unless
+// the word is lower-cased. It returns no candidates in a string literal or a comment.
+func (c *Completer) Complete(query string, cursor int) (int, []Completion) {
+	cursor = max(0, min(cursor, len(query)))
+	tokens := TokenizeSQL(query[:cursor], c.dialect)
+	prefix, start, quoted := "", cursor, false
+	if n := len(tokens); n > 0 {
+		last := tokens[n-1]
+		switch last.Kind {
+		case SQLTokenString, SQLTokenComment:
+			if last.Unterminated || strings.HasPrefix(last.Text, "--") {
+				return cursor, nil
+			}
+		case SQLTokenNumber, SQLTokenParameter:
+			return cursor, nil
+		case SQLTokenQuotedIdentifier:
+			if !last.Unterminated {
+				return cursor, nil
+			}
+			prefix, start, quoted = last.Identifier(), last.Start, true
+			tokens = tokens[:n-1]
+		case SQLTokenWord:
+			prefix, start = last.Text, last.Start
+			tokens = tokens[:n-1]
+		}
+	}
+	before := significantTokens(tokens)
+	scope := c.scope(significantTokens(TokenizeSQL(query, c.dialect)))
+
+	candidates := []Completion{}
+	if n := len(before); n >= 2 && before[n-1].Text == "." && isIdentifierToken(before[n-2]) {
+		qualifier := before[n-2].Identifier()
+		candidates = append(candidates, c.columns(c.qualified(qualifier, scope))...)
+		for _, table := range c.tables {
+			if strings.EqualFold(table.Schema(), qualifier) {
+				candidates = append(candidates, Completion{Text: c.identifier(table.Name()), Kind: CompletionTable, Detail: table.Schema()})
+			}
+		}
+		return start, filterCompletions(candidates, prefix, quoted)
+	}
+
+	clause, prev := clauseBefore(before), ""
+	if n := len(before); n > 0 {
+		prev = strings.ToUpper(before[n-1].Text)
+	}
+	switch {
+	case tableClauses[clause] && (prev == clause || prev == ","):
+		for _, table := range c.tables {
+			candidates = append(candidates, Completion{Text: c.identifier(table.Name()), Kind: CompletionTable, Detail: table.Schema()})
+		}
+	case columnClauses[clause]:
+		tables := make([]*Table, 0, len(scope))
+		for _, s := range scope {
+			tables = append(tables, s.table)
+		}
+		if len(tables) == 0 {
+			tables = c.tables
+		}
+		candidates = append(candidates, c.columns(tables)...)
+		candidates = append(candidates, c.words(c.functions, CompletionFunction, prefix)...)
+		candidates = append(candidates, c.words(c.keywords, CompletionKeyword, prefix)...)
+	case otherClauses[clause]:
+		candidates = append(candidates, c.words(c.functions, CompletionFunction, prefix)...)
+		candidates = append(candidates, c.words(c.keywords, CompletionKeyword, prefix)...)
+	default:
+		candidates = append(candidates, c.words(c.keywords, CompletionKeyword, prefix)...)
+	}
+	return start, filterCompletions(candidates, prefix, quoted)
+}
+
+// scope returns the tables in the FROM, JOIN, UPDATE and INTO clauses of the query, e.g. "FROM a x, b AS y".
+// The tables that are not known to the completer are ignored.
+func (c *Completer) scope(tokens []SQLToken) []scopedTable {
+	scope := []scopedTable{}
+	for _, ref := range tableReferences(tokens, c.reserved) {
+		if table := c.table(ref.Schema, ref.Name); table != nil {
+			scope = append(scope, scopedTable{table: table, alias: ref.Alias})
+		}
+	}
+	return scope
+}
+
+// qualified returns the tables whose alias or name is the qualifier of "qualifier.column".
+// A table that is not in the query is also returned if its name is the qualifier.
+func (c *Completer) qualified(qualifier string, scope []scopedTable) []*Table {
+	for _, s := range scope {
+		if strings.EqualFold(s.alias, qualifier) || (s.alias == "" && strings.EqualFold(s.table.Name(), qualifier)) {
+			return []*Table{s.table}
+		}
+	}
+	if table := c.table("", qualifier); table != nil {
+		return []*Table{table}
+	}
+	return nil
+}
+
+// table returns the table of the schema and the name, or nil if it is not known. If schema is empty,
+// the table of the name in any schema is returned.
+func (c *Completer) table(schema, name string) *Table {
+	for _, table := range c.tables {
+		if strings.EqualFold(table.Name(), name) && (schema == "" || strings.EqualFold(table.Schema(), schema)) {
+			return table
+		}
+	}
+	return nil
+}
+
+// columns returns the columns of the tables. The detail of a column is its table.
+func (c *Completer) columns(tables []*Table) []Completion {
+	columns := []Completion{}
+	for _, table := range tables {
+		for _, column := range table.Header() {
+			columns = append(columns, Completion{Text: c.identifier(column), Kind: CompletionColumn, Detail: table.Name()})
+		}
+	}
+	return columns
+}
+
+// words returns the keywords or the functions. They are lower-cased if the prefix is lower-cased.
+func (c *Completer) words(words []string, kind CompletionKind, prefix string) []Completion {
+	lower := prefix != "" && strings.ToLower(prefix) == prefix && strings.IndexFunc(prefix, unicode.IsLetter) >= 0
+	completions := make([]Completion, 0, len(words))
+	for _, word := range words {
+		if lower {
+			word = strings.ToLower(word)
+		}
+		completions = append(completions, Completion{Text: word, Kind: kind})
+	}
+	return completions
+}
+
+// filterCompletions returns the candidates that start with the prefix (case-insensitive) in their order.
+// Duplicates and the candidates that are the prefix itself are removed. If quoted is true, the prefix
+// is a quoted identifier that is being typed, so only tables and columns are returned.
+func filterCompletions(candidates []Completion, prefix string, quoted bool) []Completion {
+	upper := strings.ToUpper(prefix)
+	seen := map[Completion]bool{}
+	filtered := []Completion{}
+	for _, candidate := range candidates {
+		if quoted && candidate.Kind != CompletionTable && candidate.Kind != CompletionColumn {
+			continue
+		}
+		text := strings.ToUpper(strings.Trim(candidate.Text, "\"`[]"))
+		if !strings.HasPrefix(text, upper) || (text == upper && !quoted) || seen[candidate] {
+			continue
+		}
+		seen[candidate] = true
+		filtered = append(filtered, candidate)
+	}
+	return filtered
+}
+
+// clauseBefore returns the upper-cased keyword of the clause at the end of the tokens, e.g. "WHERE" for
+// "SELECT * FROM a WHERE x = 1 AND". Parenthesized expressions before the end are skipped, and an
+// unclosed parenthesis does not end the clause, e.g. "SELECT count(". It returns "" if there is no clause.
+func clauseBefore(tokens []SQLToken) string {
+	depth := 0
+	for i := len(tokens) - 1; i >= 0; i-- {
+		switch token := tokens[i]; {
+		case token.Text == ")":
+			depth++
+		case token.Text == "(":
+			depth = max(0, depth-1)
+		case depth > 0 || token.Kind != SQLTokenWord:
+		default:
+			word := strings.ToUpper(token.Text)
+			if tableClauses[word] || columnClauses[word] || otherClauses[word] {
+				return word
+			}
+		}
+	}
+	return ""
+}
+
+// TableReference is a table that a query refers to in its FROM, JOIN, UPDATE or INTO clause.
+type TableReference struct {
+	// Schema is the schema of "schema.table". It is empty if the table is not qualified.
+	Schema string
+	Name   string
+	Alias  string
+}
+
+// tableReferences returns the tables in the FROM, JOIN, UPDATE and INTO clauses of the tokens without
+// spaces and comments, e.g. "FROM a x, s.b AS y". The names are unquoted, and a word in reserved is not an alias.
+func tableReferences(tokens []SQLToken, reserved map[string]bool) []TableReference {
+	refs := []TableReference{}
+	for i := 0; i < len(tokens); i++ {
+		clause := strings.ToUpper(tokens[i].Text)
+		if tokens[i].Kind != SQLTokenWord || !tableClauses[clause] {
+			continue
+		}
+		j := i + 1
+		for j < len(tokens) && isIdentifierToken(tokens[j]) {
+			// The last two parts of "database.schema.table" are the schema and the table.
+			ref := TableReference{Name: tokens[j].Identifier()}
+			j++
+			for j+1 < len(tokens) && tokens[j].Text == "." && isIdentifierToken(tokens[j+1]) {
+				ref.Schema, ref.Name = ref.Name, tokens[j+1].Identifier()
+				j += 2
+			}
+			if j < len(tokens) && strings.EqualFold(tokens[j].Text, "AS") {
+				j++
+			}
+			if j < len(tokens) && isIdentifierToken(tokens[j]) && !reserved[strings.ToUpper(tokens[j].Text)] {
+				ref.Alias = tokens[j].Identifier()
+				j++
+			}
+			refs = append(refs, ref)
+			if clause != "FROM" || j >= len(tokens) || tokens[j].Text != "," {
+				break
+			}
+			j++
+		}
+	}
+	return refs
+}
+
+// significantTokens returns the tokens without spaces and comments.
+func significantTokens(tokens []SQLToken) []SQLToken {
+	significant := make([]SQLToken, 0, len(tokens))
+	for _, token := range tokens {
+		if token.Kind != SQLTokenSpace && token.Kind != SQLTokenComment {
+			significant = append(significant, token)
+		}
+	}
+	return significant
+}
+
+// isIdentifierToken returns true if the token can be a table, an alias or a column.
+func isIdentifierToken(token SQLToken) bool {
+	return token.Kind == SQLTokenWord || (token.Kind == SQLTokenQuotedIdentifier && !token.Unterminated)
+}
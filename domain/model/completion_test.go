@@ -0,0 +1,216 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCompleterComplete(t *testing.T) {
+	t.Parallel()
+
+	users := NewTable("users", NewHeader([]string{"id", "name", "email"}), []Record{})
+	orders := NewTable("orders", NewHeader([]string{"id", "user_id", "total"}), []Record{})
+	orders.SetSchema("shop")
+	spaced := NewTable("order items", NewHeader([]string{"order id"}), []Record{})
+	completer := NewCompleter(
+		[]*Table{users, orders, spaced},
+		SQLDialectStandard,
+		[]string{"AND", "AS", "FROM", "JOIN", "LIMIT", "ON", "ORDER", "SELECT", "WHERE"},
+		[]string{"COUNT", "LOWER"},
+		func(name string) string {
+			if strings.Contains(name, " ") {
+				return `"` + name + `"`
+			}
+			return name
+		},
+	)
+
+	tests := []struct {
+		name      string
+		query     string // "|" is the cursor, which is at the end of the query if it is omitted
+		wantStart int
+		want      []Completion
+	}{
+		{
+			name:      "keyword at the start",
+			query:     "SEL",
+			wantStart: 0,
+			want:      []Completion{{Text: "SELECT", Kind: CompletionKeyword}},
+		},
+		{
+			name:      "lower-cased keyword",
+			query:     "sel",
+			wantStart: 0,
+			want:      []Completion{{Text: "select", Kind: CompletionKeyword}},
+		},
+		{
+			name:      "tables after FROM",
+			query:     "SELECT * FROM ",
+			wantStart: 14,
+			want: []Completion{
+				{Text: "users", Kind: CompletionTable},
+				{Text: "orders", Kind: CompletionTable, Detail: "shop"},
+				{Text: `"order items"`, Kind: CompletionTable},
+			},
+		},
+		{
+			name:      "tables after JOIN with prefix",
+			query:     "SELECT * FROM users u JOIN or",
+			wantStart: 27,
+			want: []Completion{
+				{Text: "orders", Kind: CompletionTable, Detail: "shop"},
+				{Text: `"order items"`, Kind: CompletionTable},
+			},
+		},
+		{
+			name:      "keywords after the table of FROM",
+			query:     "SELECT * FROM users WH",
+			wantStart: 20,
+			want:      []Completion{{Text: "WHERE", Kind: CompletionKeyword}},
+		},
+		{
+			name:      "columns of the tables in the query after WHERE",
+			query:     "SELECT * FROM users WHERE na",
+			wantStart: 26,
+			want:      []Completion{{Text: "name", Kind: CompletionColumn, Detail: "users"}},
+		},
+		{
+			name:      "columns, functions and keywords after SELECT",
+			query:     "SELECT | FROM orders",
+			wantStart: 7,
+			want: []Completion{
+				{Text: "id", Kind: CompletionColumn, Detail: "orders"},
+				{Text: "user_id", Kind: CompletionColumn, Detail: "orders"},
+				{Text: "total", Kind: CompletionColumn, Detail: "orders"},
+				{Text: "COUNT", Kind: CompletionFunction},
+				{Text: "LOWER", Kind: CompletionFunction},
+				{Text: "AND", Kind: CompletionKeyword},
+				{Text: "AS", Kind: CompletionKeyword},
+				{Text: "FROM", Kind: CompletionKeyword},
+				{Text: "JOIN", Kind: CompletionKeyword},
+				{Text: "LIMIT", Kind: CompletionKeyword},
+				{Text: "ON", Kind: CompletionKeyword},
+				{Text: "ORDER", Kind: CompletionKeyword},
+				{Text: "SELECT", Kind: CompletionKeyword},
+				{Text: "WHERE", Kind: CompletionKeyword},
+			},
+		},
+		{
+			name:      "columns of the alias",
+			query:     "SELECT o.| FROM users u JOIN shop.orders AS o ON u.id = o.user_id",
+			wantStart: 9,
+			want: []Completion{
+				{Text: "id", Kind: CompletionColumn, Detail: "orders"},
+				{Text: "user_id", Kind: CompletionColumn, Detail: "orders"},
+				{Text: "total", Kind: CompletionColumn, Detail: "orders"},
+			},
+		},
+		{
+			name:      "no columns of the table of another schema",
+			query:     "SELECT o.| FROM users u JOIN archive.orders AS o ON u.id = o.user_id",
+			wantStart: 9,
+		},
+		{
+			name:      "columns of the alias with prefix after ON",
+			query:     "SELECT * FROM users u JOIN orders o ON u.id = o.us",
+			wantStart: 48,
+			want:      []Completion{{Text: "user_id", Kind: CompletionColumn, Detail: "orders"}},
+		},
+		{
+			name:      "columns of the table name",
+			query:     "SELECT users.e",
+			wantStart: 13,
+			want:      []Completion{{Text: "email", Kind: CompletionColumn, Detail: "users"}},
+		},
+		{
+			name:      "quoted column",
+			query:     `SELECT * FROM "order items" WHERE "ord`,
+			wantStart: 34,
+			want:      []Completion{{Text: `"order id"`, Kind: CompletionColumn, Detail: "order items"}},
+		},
+		{
+			name:      "tables of the schema",
+			query:     "SELECT * FROM shop.",
+			wantStart: 19,
+			want:      []Completion{{Text: "orders", Kind: CompletionTable, Detail: "shop"}},
+		},
+		{
+			name:      "columns in function arguments",
+			query:     "SELECT count(us| FROM orders",
+			wantStart: 13,
+			want:      []Completion{{Text: "user_id", Kind: CompletionColumn, Detail: "orders"}},
+		},
+		{
+			name:      "columns after a subquery",
+			query:     "SELECT * FROM users WHERE id IN (SELECT user_id FROM orders) AND em",
+			wantStart: 65,
+			want:      []Completion{{Text: "email", Kind: CompletionColumn, Detail: "users"}},
+		},
+		{
+			name:      "no candidates in a string literal",
+			query:     "SELECT * FROM users WHERE name = 'us",
+			wantStart: 36,
+		},
+		{
+			name:      "no candidates in a comment",
+			query:     "SELECT * FROM users -- us",
+			wantStart: 25,
+		},
+		{
+			name:      "the word itself is not a candidate",
+			query:     "SELECT",
+			wantStart: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			query, cursor := tt.query, len(tt.query)
+			if i := strings.Index(query, "|"); i >= 0 {
+				query, cursor = query[:i]+query[i+1:], i
+			}
+			start, got := completer.Complete(query, cursor)
+			if start != tt.wantStart {
+				t.Errorf("start = %d, want %d", start, tt.wantStart)
+			}
+			if len(got) == 0 {
+				got = nil
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCompleterCompleteAfterDialectString(t *testing.T) {
+	t.Parallel()
+
+	users := NewTable("users", NewHeader([]string{"id", "name"}), []Record{})
+	tests := []struct {
+		name    string
+		dialect SQLDialect
+		query   string
+	}{
+		{name: "MySQL backslash escape", dialect: SQLDialectMySQL, query: `SELECT * FROM users WHERE name = 'It\'s' AND na`},
+		{name: "PostgreSQL dollar quote", dialect: SQLDialectPostgreSQL, query: "SELECT * FROM users WHERE name = $$It's$$ AND na"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			completer := NewCompleter([]*Table{users}, tt.dialect, []string{"AND", "FROM", "SELECT", "WHERE"}, nil, func(name string) string { return name })
+			start, got := completer.Complete(tt.query, len(tt.query))
+			want := []Completion{{Text: "name", Kind: CompletionColumn, Detail: "users"}}
+			if start != len(tt.query)-len("na") {
+				t.Errorf("start = %d, want %d", start, len(tt.query)-len("na"))
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
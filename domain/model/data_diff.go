@@ -0,0 +1,232 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// RowDiffStatus is the kind of difference of a row.
+type RowDiffStatus string
+
+const (
+	// RowAdded means the row exists only in the "to" table.
+	RowAdded RowDiffStatus = "added"
+	// RowRemoved means the row exists only in the "from" table.
+	RowRemoved RowDiffStatus = "removed"
+	// RowChanged means the row exists in both tables with different values.
+	RowChanged RowDiffStatus = "changed"
+)
+
+// RowDiff is a row that differs between two tables.
+type RowDiff struct {
+	// Status is the kind of difference.
+	Status RowDiffStatus
+	// Key is the values of the key columns.
+	Key []string
+	// From is the row in the "from" table, in the order of DataDiff.Header. It is nil for an added row.
+	From Record
+	// To is the row in the "to" table, in the order of DataDiff.Header. It is nil for a removed row.
+	To Record
+	// ChangedColumns is the indexes of DataDiff.Header whose values differ in a changed row.
+	ChangedColumns []int
+}
+
+// IsChangedColumn returns true if the value of the column (index of DataDiff.Header) differs.
+func (r *RowDiff) IsChangedColumn(i int) bool {
+	for _, c := range r.ChangedColumns {
+		if c == i {
+			return true
+		}
+	}
+	return false
+}
+
+// DataDiff is the row-level difference between two tables.
+type DataDiff struct {
+	// Header is the columns that exist in both tables, in the order of the "from" table.
+	// Only these columns are compared.
+	Header Header
+	// KeyColumns is the columns that identify a row.
+	KeyColumns []string
+	// FromOnlyColumns and ToOnlyColumns are the columns that exist in only one table.
+	FromOnlyColumns []string
+	ToOnlyColumns   []string
+	// Rows is the removed and changed rows in the order of the "from" table,
+	// followed by the added rows in the order of the "to" table.
+	Rows []*RowDiff
+	// Unchanged is the number of rows that are the same in both tables.
+	Unchanged int
+}
+
+// DiffTableData compares the records of two tables by the key columns. Columns are matched by name
+// (case-insensitive), and values are compared as strings. If keyColumns is empty, all common columns
+// are used as the key, so that rows are reported only as added or removed.
+// It returns an error if a key column does not exist in both tables, or if a key is not unique.
+func DiffTableData(from, to *Table, keyColumns []string) (*DataDiff, error) {
+	diff := &DataDiff{}
+	fromIndexes := []int{}
+	toIndexes := []int{}
+	for i, name := range from.Header() {
+		j := columnIndex(to.Header(), name)
+		if j < 0 {
+			diff.FromOnlyColumns = append(diff.FromOnlyColumns, name)
+			continue
+		}
+		diff.Header = append(diff.Header, name)
+		fromIndexes = append(fromIndexes, i)
+		toIndexes = append(toIndexes, j)
+	}
+	for _, name := range to.Header() {
+		if columnIndex(from.Header(), name) < 0 {
+			diff.ToOnlyColumns = append(diff.ToOnlyColumns, name)
+		}
+	}
+	if len(diff.Header) == 0 {
+		return nil, errors.New("the tables have no common columns")
+	}
+
+	if len(keyColumns) == 0 {
+		keyColumns = diff.Header
+	}
+	keys := make([]int, 0, len(keyColumns))
+	for _, name := range keyColumns {
+		i := columnIndex(diff.Header, name)
+		if i < 0 {
+			return nil, fmt.Errorf("key column %s does not exist in both tables", name)
+		}
+		keys = append(keys, i)
+		diff.KeyColumns = append(diff.KeyColumns, diff.Header[i])
+	}
+
+	fromRows, err := indexRows(from, fromIndexes, keys)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", from.Name(), err)
+	}
+	toRows, err := indexRows(to, toIndexes, keys)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", to.Name(), err)
+	}
+
+	for _, f := range fromRows.rows {
+		key := rowKey(f, keys)
+		t, ok := toRows.byKey[key]
+		if !ok {
+			diff.Rows = append(diff.Rows, &RowDiff{Status: RowRemoved, Key: keyValues(f, keys), From: f})
+			continue
+		}
+		changed := []int{}
+		for i := range f {
+			if f[i] != t[i] {
+				changed = append(changed, i)
+			}
+		}
+		if len(changed) == 0 {
+			diff.Unchanged++
+			continue
+		}
+		diff.Rows = append(diff.Rows, &RowDiff{
+			Status: RowChanged, Key: keyValues(f, keys), From: f, To: t, ChangedColumns: changed,
+		})
+	}
+	for _, t := range toRows.rows {
+		if _, ok := fromRows.byKey[rowKey(t, keys)]; !ok {
+			diff.Rows = append(diff.Rows, &RowDiff{Status: RowAdded, Key: keyValues(t, keys), To: t})
+		}
+	}
+	return diff, nil
+}
+
+// indexedRows is the records of a table reordered to the common columns, and indexed by the key.
+type indexedRows struct {
+	rows  []Record
+	byKey map[string]Record
+}
+
+// indexRows reorders the records to the columns, and indexes them by the key columns.
+func indexRows(table *Table, columns, keys []int) (*indexedRows, error) {
+	ir := &indexedRows{
+		rows:  make([]Record, 0, len(table.Records())),
+		byKey: make(map[string]Record, len(table.Records())),
+	}
+	for _, record := range table.Records() {
+		r := make(Record, len(columns))
+		for i, c := range columns {
+			if c < len(record) {
+				r[i] = record[c]
+			}
+		}
+		key := rowKey(r, keys)
+		if _, ok := ir.byKey[key]; ok {
+			return nil, fmt.Errorf("duplicate key (%s)", strings.Join(keyValues(r, keys), ", "))
+		}
+		ir.byKey[key] = r
+		ir.rows = append(ir.rows, r)
+	}
+	return ir, nil
+}
+
+// rowKey returns the key of the record that is used as a map key.
+func rowKey(r Record, keys []int) string {
+	return strings.Join(keyValues(r, keys), "\x00")
+}
+
+// keyValues returns the values of the key columns.
+func keyValues(r Record, keys []int) []string {
+	values := make([]string, 0, len(keys))
+	for _, k := range keys {
+		values = append(values, r[k])
+	}
+	return values
+}
+
+// columnIndex returns the index of the column (case-insensitive), or -1.
+func columnIndex(header Header, name string) int {
+	for i, h := range header {
+		if strings.EqualFold(h, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Count returns the number of rows with the status.
+func (d *DataDiff) Count(status RowDiffStatus) int {
+	n := 0
+	for _, r := range d.Rows {
+		if r.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+// Summary returns the number of rows for each status, e.g. "1 added, 2 removed, 3 changed, 10 unchanged".
+func (d *DataDiff) Summary() string {
+	return fmt.Sprintf("%d added, %d removed, %d changed, %d unchanged",
+		d.Count(RowAdded), d.Count(RowRemoved), d.Count(RowChanged), d.Unchanged)
+}
+
+// DataDiffStatusColumn is the first column of DataDiff.Table.
+const DataDiffStatusColumn = "diff"
+
+// Table returns the differences as a table to be shown or exported. The first column is the status,
+// followed by the compared columns. A changed row is written as two rows, "changed from" with the
+// values of the "from" table and "changed to" with the values of the "to" table.
+func (d *DataDiff) Table() *Table {
+	header := append(Header{DataDiffStatusColumn}, d.Header...)
+	records := make([]Record, 0, len(d.Rows))
+	for _, r := range d.Rows {
+		switch r.Status {
+		case RowAdded:
+			records = append(records, append(Record{string(RowAdded)}, r.To...))
+		case RowRemoved:
+			records = append(records, append(Record{string(RowRemoved)}, r.From...))
+		case RowChanged:
+			records = append(records,
+				append(Record{string(RowChanged) + " from"}, r.From...),
+				append(Record{string(RowChanged) + " to"}, r.To...))
+		}
+	}
+	return NewTable("diff", header, records)
+}
@@ -0,0 +1,120 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDiffTableData(t *testing.T) {
+	t.Parallel()
+
+	from := NewTable("users.csv",
+		Header{"id", "name", "age", "note"},
+		[]Record{
+			{"1", "alice", "20", "a"},
+			{"2", "bob", "30", "b"},
+			{"3", "carol", "40", "c"},
+		})
+	to := NewTable("users",
+		Header{"ID", "age", "name", "email"},
+		[]Record{
+			{"1", "20", "alice", "alice@example.com"},
+			{"3", "41", "caroline", "carol@example.com"},
+			{"4", "50", "dave", "dave@example.com"},
+		})
+
+	got, err := DiffTableData(from, to, []string{"ID"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &DataDiff{
+		Header:          Header{"id", "name", "age"},
+		KeyColumns:      []string{"id"},
+		FromOnlyColumns: []string{"note"},
+		ToOnlyColumns:   []string{"email"},
+		Rows: []*RowDiff{
+			{Status: RowRemoved, Key: []string{"2"}, From: Record{"2", "bob", "30"}},
+			{
+				Status:         RowChanged,
+				Key:            []string{"3"},
+				From:           Record{"3", "carol", "40"},
+				To:             Record{"3", "caroline", "41"},
+				ChangedColumns: []int{1, 2},
+			},
+			{Status: RowAdded, Key: []string{"4"}, To: Record{"4", "dave", "50"}},
+		},
+		Unchanged: 1,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("DiffTableData() mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff("1 added, 1 removed, 1 changed, 1 unchanged", got.Summary()); diff != "" {
+		t.Errorf("DataDiff.Summary() mismatch (-want +got):\n%s", diff)
+	}
+
+	wantTable := NewTable("diff",
+		Header{"diff", "id", "name", "age"},
+		[]Record{
+			{"removed", "2", "bob", "30"},
+			{"changed from", "3", "carol", "40"},
+			{"changed to", "3", "caroline", "41"},
+			{"added", "4", "dave", "50"},
+		})
+	if !wantTable.Equal(got.Table()) {
+		t.Errorf("DataDiff.Table() = %v, want %v", got.Table(), wantTable)
+	}
+}
+
+func TestDiffTableDataWithoutKey(t *testing.T) {
+	t.Parallel()
+
+	from := NewTable("a", Header{"id", "name"}, []Record{{"1", "alice"}, {"2", "bob"}})
+	to := NewTable("b", Header{"id", "name"}, []Record{{"1", "alice"}, {"2", "bobby"}})
+
+	got, err := DiffTableData(from, to, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Count(RowRemoved) != 1 || got.Count(RowAdded) != 1 || got.Count(RowChanged) != 0 || got.Unchanged != 1 {
+		t.Errorf("DiffTableData() without key = %s, want 1 added, 1 removed, 0 changed, 1 unchanged", got.Summary())
+	}
+}
+
+func TestDiffTableDataError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		from *Table
+		to   *Table
+		keys []string
+	}{
+		{
+			name: "no common columns",
+			from: NewTable("a", Header{"id"}, []Record{{"1"}}),
+			to:   NewTable("b", Header{"code"}, []Record{{"1"}}),
+		},
+		{
+			name: "key column does not exist in both tables",
+			from: NewTable("a", Header{"id", "code"}, []Record{{"1", "x"}}),
+			to:   NewTable("b", Header{"id"}, []Record{{"1"}}),
+			keys: []string{"code"},
+		},
+		{
+			name: "duplicate key",
+			from: NewTable("a", Header{"id", "name"}, []Record{{"1", "alice"}, {"1", "bob"}}),
+			to:   NewTable("b", Header{"id", "name"}, []Record{{"1", "alice"}}),
+			keys: []string{"id"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if _, err := DiffTableData(tt.from, tt.to, tt.keys); err == nil {
+				t.Error("DiffTableData() returns no error")
+			}
+		})
+	}
+}
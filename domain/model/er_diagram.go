@@ -0,0 +1,234 @@
+package model
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// ERDiagramFormat is the output format of an ER diagram.
+type ERDiagramFormat string
+
+const (
+	// ERDiagramMermaid is Mermaid erDiagram.
+	ERDiagramMermaid ERDiagramFormat = "mermaid"
+	// ERDiagramDOT is Graphviz DOT.
+	ERDiagramDOT ERDiagramFormat = "dot"
+)
+
+// NewERDiagramFormat returns the format of the name ("mermaid" or "dot", case-insensitive).
+func NewERDiagramFormat(name string) (ERDiagramFormat, error) {
+	switch f := ERDiagramFormat(strings.ToLower(name)); f {
+	case ERDiagramMermaid, ERDiagramDOT:
+		return f, nil
+	default:
+		return "", fmt.Errorf("unsupported ER diagram format: %s (mermaid or dot)", name)
+	}
+}
+
+// Ext returns the file extension of the format.
+func (f ERDiagramFormat) Ext() string {
+	if f == ERDiagramDOT {
+		return ".dot"
+	}
+	return ".mmd"
+}
+
+// ERDiagramOptions is the options of an ER diagram.
+type ERDiagramOptions struct {
+	// Format is the output format.
+	Format ERDiagramFormat
+	// Tables is the tables drawn in the diagram (case-insensitive). All tables are drawn if it is empty.
+	Tables []string
+	// ShowTypes writes the data type of each column.
+	ShowTypes bool
+	// ShowKeys marks the primary key and foreign key columns with PK and FK.
+	ShowKeys bool
+}
+
+// GenerateERDiagram writes the tables and the relationships of their foreign keys as an ER diagram.
+// A foreign key that references a table that is not drawn is not written as a relationship.
+// Mermaid needs a data type for each column, so the columns are not written in Mermaid
+// if ShowTypes is false.
+func GenerateERDiagram(tables []*TableSchema, opts ERDiagramOptions) (string, error) {
+	selected := tables
+	if len(opts.Tables) > 0 {
+		selected = make([]*TableSchema, 0, len(opts.Tables))
+		for _, name := range opts.Tables {
+			t := findTableSchema(tables, name)
+			if t == nil {
+				return "", fmt.Errorf("table %s does not exist", name)
+			}
+			selected = append(selected, t)
+		}
+	}
+
+	if opts.Format == ERDiagramDOT {
+		return dotERDiagram(selected, opts), nil
+	}
+	return mermaidERDiagram(selected, opts), nil
+}
+
+// isForeignKeyColumn returns true if the column is a referencing column of a foreign key.
+func (t *TableSchema) isForeignKeyColumn(name string) bool {
+	for _, fk := range t.ForeignKeys {
+		if slices.ContainsFunc(fk.Columns, func(c string) bool { return strings.EqualFold(c, name) }) {
+			return true
+		}
+	}
+	return false
+}
+
+// keyMarkers returns the markers of the column, e.g. []string{"PK", "FK"}.
+func (t *TableSchema) keyMarkers(name string) []string {
+	markers := []string{}
+	if slices.ContainsFunc(t.PrimaryKey, func(c string) bool { return strings.EqualFold(c, name) }) {
+		markers = append(markers, "PK")
+	}
+	if t.isForeignKeyColumn(name) {
+		markers = append(markers, "FK")
+	}
+	return markers
+}
+
+// mermaidNameRegexp matches the names that can be written in Mermaid without quotes.
+var mermaidNameRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// mermaidInvalidRegexp matches the characters that cannot be used in Mermaid attribute types and names.
+var mermaidInvalidRegexp = regexp.MustCompile(`[^A-Za-z0-9_()\[\]-]`)
+
+// mermaidEntity returns the entity name, quoted if it has characters such as "." and spaces.
+func mermaidEntity(name string) string {
+	if mermaidNameRegexp.MatchString(name) {
+		return name
+	}
+	return `"` + strings.ReplaceAll(name, `"`, `'`) + `"`
+}
+
+// mermaidWord replaces the characters that cannot be used in Mermaid attributes with "_".
+func mermaidWord(s string) string {
+	s = mermaidInvalidRegexp.ReplaceAllString(s, "_")
+	if s == "" || (s[0] >= '0' && s[0] <= '9') || s[0] == '-' {
+		s = "_" + s
+	}
+	return s
+}
+
+// mermaidERDiagram writes the tables in Mermaid erDiagram.
+func mermaidERDiagram(tables []*TableSchema, opts ERDiagramOptions) string {
+	var b strings.Builder
+	b.WriteString("erDiagram\n")
+	for _, t := range tables {
+		if !opts.ShowTypes || len(t.Columns) == 0 {
+			fmt.Fprintf(&b, "    %s\n", mermaidEntity(t.Name))
+			continue
+		}
+		fmt.Fprintf(&b, "    %s {\n", mermaidEntity(t.Name))
+		for _, c := range t.Columns {
+			line := mermaidWord(c.Type) + " " + mermaidWord(c.Name)
+			if opts.ShowKeys {
+				if markers := t.keyMarkers(c.Name); len(markers) > 0 {
+					line += " " + strings.Join(markers, ", ")
+				}
+			}
+			fmt.Fprintf(&b, "        %s\n", line)
+		}
+		b.WriteString("    }\n")
+	}
+
+	for _, t := range tables {
+		for _, fk := range t.ForeignKeys {
+			ref := findTableSchema(tables, fk.RefTable)
+			if ref == nil {
+				continue
+			}
+			// The referencing rows may have no parent if a referencing column is nullable.
+			parent := "||"
+			for _, name := range fk.Columns {
+				if c := t.column(name); c != nil && c.Nullable {
+					parent = "|o"
+				}
+			}
+			fmt.Fprintf(&b, "    %s %s--o{ %s : %q\n",
+				mermaidEntity(ref.Name), parent, mermaidEntity(t.Name), relationshipLabel(fk))
+		}
+	}
+	return b.String()
+}
+
+// relationshipLabel returns the constraint name, or the referencing columns if the foreign key has no name.
+func relationshipLabel(fk *ForeignKey) string {
+	if fk.Name != "" {
+		return strings.ReplaceAll(fk.Name, `"`, `'`)
+	}
+	return strings.ReplaceAll(strings.Join(fk.Columns, ", "), `"`, `'`)
+}
+
+// dotID quotes the identifier for DOT.
+func dotID(s string) string {
+	return `"` + strings.ReplaceAll(strings.ReplaceAll(s, `\`, `\\`), `"`, `\"`) + `"`
+}
+
+// dotERDiagram writes the tables in Graphviz DOT. Each table is a node with an HTML-like label,
+// and each column has a port ("c" and the column position) so that edges point to the columns.
+func dotERDiagram(tables []*TableSchema, opts ERDiagramOptions) string {
+	var b strings.Builder
+	b.WriteString("digraph er {\n")
+	b.WriteString("    graph [rankdir=LR];\n")
+	b.WriteString("    node [shape=plaintext];\n")
+	b.WriteString("    edge [arrowhead=crow, arrowtail=tee, dir=both];\n")
+	for _, t := range tables {
+		fmt.Fprintf(&b, "    %s [label=<<table border=\"0\" cellborder=\"1\" cellspacing=\"0\">", dotID(t.Name))
+		fmt.Fprintf(&b, "<tr><td bgcolor=\"lightgray\"><b>%s</b></td></tr>", html.EscapeString(t.Name))
+		for i, c := range t.Columns {
+			text := html.EscapeString(c.Name)
+			if opts.ShowTypes {
+				text += " " + html.EscapeString(c.Type)
+			}
+			if opts.ShowKeys {
+				if markers := t.keyMarkers(c.Name); len(markers) > 0 {
+					text += " " + strings.Join(markers, ", ")
+				}
+			}
+			fmt.Fprintf(&b, "<tr><td align=\"left\" port=\"c%d\">%s</td></tr>", i, text)
+		}
+		b.WriteString("</table>>];\n")
+	}
+
+	for _, t := range tables {
+		for _, fk := range t.ForeignKeys {
+			ref := findTableSchema(tables, fk.RefTable)
+			if ref == nil {
+				continue
+			}
+			from := dotID(t.Name)
+			if len(fk.Columns) > 0 {
+				if i := columnPosition(t, fk.Columns[0]); i >= 0 {
+					from += fmt.Sprintf(":c%d", i)
+				}
+			}
+			to := dotID(ref.Name)
+			if len(fk.RefColumns) > 0 {
+				if i := columnPosition(ref, fk.RefColumns[0]); i >= 0 {
+					to += fmt.Sprintf(":c%d", i)
+				}
+			}
+			// The edge points from the referenced table to the referencing table, like Mermaid.
+			fmt.Fprintf(&b, "    %s -> %s [label=%s];\n", to, from, dotID(relationshipLabel(fk)))
+		}
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// columnPosition returns the position of the column (case-insensitive), or -1.
+func columnPosition(t *TableSchema, name string) int {
+	for i, c := range t.Columns {
+		if strings.EqualFold(c.Name, name) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,114 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGenerateERDiagram(t *testing.T) {
+	t.Parallel()
+
+	tables := []*TableSchema{
+		{
+			Name: "team",
+			Columns: []*ColumnSchema{
+				{Name: "id", Type: "integer"},
+				{Name: "name", Type: "character varying(20)", Nullable: true},
+			},
+			PrimaryKey: []string{"id"},
+		},
+		{
+			Name: "user",
+			Columns: []*ColumnSchema{
+				{Name: "id", Type: "integer"},
+				{Name: "team_id", Type: "integer", Nullable: true},
+				{Name: "role_id", Type: "integer"},
+			},
+			PrimaryKey: []string{"id"},
+			ForeignKeys: []*ForeignKey{
+				{Name: "fk_user_team", Columns: []string{"team_id"}, RefTable: "team", RefColumns: []string{"id"}},
+				{Columns: []string{"role_id"}, RefTable: "role", RefColumns: []string{"id"}},
+			},
+		},
+		{
+			Name:    "role",
+			Columns: []*ColumnSchema{{Name: "id", Type: "integer"}},
+		},
+	}
+
+	tests := []struct {
+		name string
+		opts ERDiagramOptions
+		want string
+	}{
+		{
+			name: "mermaid with types and keys",
+			opts: ERDiagramOptions{Format: ERDiagramMermaid, Tables: []string{"TEAM", "user"}, ShowTypes: true, ShowKeys: true},
+			want: `erDiagram
+    team {
+        integer id PK
+        character_varying(20) name
+    }
+    user {
+        integer id PK
+        integer team_id FK
+        integer role_id FK
+    }
+    team |o--o{ user : "fk_user_team"
+`,
+		},
+		{
+			name: "mermaid without types",
+			opts: ERDiagramOptions{Format: ERDiagramMermaid, ShowKeys: true},
+			want: `erDiagram
+    team
+    user
+    role
+    team |o--o{ user : "fk_user_team"
+    role ||--o{ user : "role_id"
+`,
+		},
+		{
+			name: "dot without keys",
+			opts: ERDiagramOptions{Format: ERDiagramDOT, Tables: []string{"user", "role"}, ShowTypes: true},
+			want: `digraph er {
+    graph [rankdir=LR];
+    node [shape=plaintext];
+    edge [arrowhead=crow, arrowtail=tee, dir=both];
+    "user" [label=<<table border="0" cellborder="1" cellspacing="0"><tr><td bgcolor="lightgray"><b>user</b></td></tr><tr><td align="left" port="c0">id integer</td></tr><tr><td align="left" port="c1">team_id integer</td></tr><tr><td align="left" port="c2">role_id integer</td></tr></table>>];
+    "role" [label=<<table border="0" cellborder="1" cellspacing="0"><tr><td bgcolor="lightgray"><b>role</b></td></tr><tr><td align="left" port="c0">id integer</td></tr></table>>];
+    "role":c0 -> "user":c2 [label="role_id"];
+}
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := GenerateERDiagram(tables, tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("GenerateERDiagram() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	if _, err := GenerateERDiagram(tables, ERDiagramOptions{Tables: []string{"nothing"}}); err == nil {
+		t.Error("GenerateERDiagram() returns no error for an unknown table")
+	}
+}
+
+func TestNewERDiagramFormat(t *testing.T) {
+	t.Parallel()
+
+	if f, err := NewERDiagramFormat("DOT"); err != nil || f != ERDiagramDOT {
+		t.Errorf("NewERDiagramFormat(DOT) = %v, %v, want dot", f, err)
+	}
+	if _, err := NewERDiagramFormat("png"); err == nil {
+		t.Error("NewERDiagramFormat(png) returns no error")
+	}
+}
@@ -105,6 +105,11 @@ func (f *File) IsLTSV() bool {
 	return strings.HasSuffix(f.path, ".ltsv")
 }
 
+// IsJSON returns true if the file is a JSON. JSON is supported only for writing.
+func (f *File) IsJSON() bool {
+	return strings.HasSuffix(f.path, ".json")
+}
+
 // Open open file.
 func (f *File) Open() (*os.File, error) {
 	return os.Open(f.path)
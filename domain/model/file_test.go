@@ -247,6 +247,25 @@ func TestFileIsLTSV(t *testing.T) {
 	}
 }
 
+func TestFileIsJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		want bool
+	}{
+		{name: "file is json", path: "test.json", want: true},
+		{name: "file is not json", path: "test.csv", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &File{path: tt.path}
+			if got := f.IsJSON(); got != tt.want {
+				t.Errorf("File.IsJSON() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestFileOpen(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,188 @@
+package model
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ColumnType is the type of a file column inferred from its values.
+// It is mapped to a data type of each DBMS when a table is created for the file.
+type ColumnType string
+
+const (
+	// ColumnTypeInteger is a column of integers.
+	ColumnTypeInteger ColumnType = "INTEGER"
+	// ColumnTypeFloat is a column of decimal numbers.
+	ColumnTypeFloat ColumnType = "FLOAT"
+	// ColumnTypeDecimal is a column of exact decimal numbers. It is not inferred from file values,
+	// but mapped from DECIMAL and NUMERIC columns of a query result.
+	ColumnTypeDecimal ColumnType = "DECIMAL"
+	// ColumnTypeDate is a column of dates in "2006-01-02" format.
+	ColumnTypeDate ColumnType = "DATE"
+	// ColumnTypeTimestamp is a column of timestamps in "2006-01-02 15:04:05" format.
+	ColumnTypeTimestamp ColumnType = "TIMESTAMP"
+	// ColumnTypeText is a column of any other values.
+	ColumnTypeText ColumnType = "TEXT"
+)
+
+var (
+	integerPattern = regexp.MustCompile(`^[+-]?(0|[1-9][0-9]*)$`)
+	floatPattern   = regexp.MustCompile(`^[+-]?((0|[1-9][0-9]*)(\.[0-9]*)?|\.[0-9]+)([eE][+-]?[0-9]+)?$`)
+)
+
+// InferColumnTypes infers the type of each column from the records.
+// Empty values are ignored, and a column that has only empty values is TEXT.
+// Numbers with a leading zero, such as zip codes, are TEXT so that the zero is not lost.
+func InferColumnTypes(t *Table) []ColumnType {
+	types := make([]ColumnType, 0, len(t.Header()))
+	for i := range t.Header() {
+		types = append(types, inferColumnType(t.Records(), i))
+	}
+	return types
+}
+
+// inferColumnType returns the narrowest type that all non-empty values of the column match.
+func inferColumnType(records []Record, column int) ColumnType {
+	candidates := []ColumnType{ColumnTypeInteger, ColumnTypeFloat, ColumnTypeDate, ColumnTypeTimestamp}
+	hasValue := false
+	for _, record := range records {
+		if column >= len(record) || record[column] == "" {
+			continue
+		}
+		hasValue = true
+		remains := candidates[:0]
+		for _, c := range candidates {
+			if matchColumnType(c, record[column]) {
+				remains = append(remains, c)
+			}
+		}
+		candidates = remains
+		if len(candidates) == 0 {
+			return ColumnTypeText
+		}
+	}
+	if !hasValue {
+		return ColumnTypeText
+	}
+	return candidates[0]
+}
+
+// matchColumnType returns true if the value can be stored in a column of the type.
+func matchColumnType(c ColumnType, value string) bool {
+	switch c {
+	case ColumnTypeInteger:
+		return integerPattern.MatchString(value) && len(strings.TrimLeft(value, "+-")) <= 18
+	case ColumnTypeFloat:
+		return floatPattern.MatchString(value)
+	case ColumnTypeDate:
+		_, err := time.Parse(time.DateOnly, value)
+		return err == nil
+	case ColumnTypeTimestamp:
+		_, err := time.Parse(time.DateTime, value)
+		return err == nil
+	default:
+		return true
+	}
+}
+
+// ColumnTypeOf maps the data type name of a DBMS column, such as "VARCHAR" or "int8",
+// to a ColumnType. Unknown types, including booleans and binaries, are TEXT.
+func ColumnTypeOf(databaseTypeName string) ColumnType {
+	name := strings.TrimPrefix(strings.ToUpper(databaseTypeName), "UNSIGNED ")
+	if i := strings.IndexAny(name, "( "); i > 0 {
+		name = name[:i]
+	}
+	switch name {
+	case "INT", "INTEGER", "TINYINT", "SMALLINT", "MEDIUMINT", "BIGINT",
+		"INT2", "INT4", "INT8", "SERIAL", "BIGSERIAL", "SMALLSERIAL", "YEAR":
+		return ColumnTypeInteger
+	case "REAL", "FLOAT", "FLOAT4", "FLOAT8", "DOUBLE", "MONEY", "SMALLMONEY":
+		return ColumnTypeFloat
+	case "DECIMAL", "NUMERIC":
+		return ColumnTypeDecimal
+	case "DATE":
+		return ColumnTypeDate
+	case "DATETIME", "DATETIME2", "SMALLDATETIME", "TIMESTAMP":
+		return ColumnTypeTimestamp
+	default:
+		return ColumnTypeText
+	}
+}
+
+// ImportColumn maps a file column to a column of the table that the file is imported into.
+type ImportColumn struct {
+	// Source is the column name in the file.
+	Source string
+	// Target is the column name in the table.
+	Target string
+	// DataType is the data type of the target column in the DBMS.
+	DataType string
+	// EmptyAsNull is true if an empty value is stored as NULL. It is false for text columns.
+	EmptyAsNull bool
+}
+
+// ImportPlan describes how the records of a file are imported into a table in database.
+// It is shown to the user as a dry-run before the import.
+type ImportPlan struct {
+	// Source is the table read from the file.
+	Source *Table
+	// Target is the name of the table that the records are imported into.
+	Target string
+	// CreateStatement is the CREATE TABLE statement for the target. It is empty if the table exists.
+	CreateStatement string
+	// Columns is the column mapping in the order of the file columns.
+	Columns []ImportColumn
+}
+
+// CreatesTable returns true if the target table is created by the import.
+func (p *ImportPlan) CreatesTable() bool {
+	return p.CreateStatement != ""
+}
+
+// TargetColumns returns the target column names in the order of the file columns.
+func (p *ImportPlan) TargetColumns() []string {
+	columns := make([]string, 0, len(p.Columns))
+	for _, c := range p.Columns {
+		columns = append(columns, c.Target)
+	}
+	return columns
+}
+
+// Values returns the values of the row-th record of the source to be imported. A value is nil if it is
+// NULL in the source table, or if it is empty and the column stores empty values as NULL.
+func (p *ImportPlan) Values(row int) []any {
+	record := p.Source.Records()[row]
+	values := make([]any, 0, len(p.Columns))
+	for i, c := range p.Columns {
+		var v string
+		if i < len(record) {
+			v = record[i]
+		}
+		if p.Source.IsNull(row, i) || (v == "" && c.EmptyAsNull) {
+			values = append(values, nil)
+			continue
+		}
+		values = append(values, v)
+	}
+	return values
+}
+
+// NextBatch returns the plan for the next batch of records. It has the same column mapping,
+// but does not create the table again.
+func (p *ImportPlan) NextBatch(source *Table) *ImportPlan {
+	return &ImportPlan{
+		Source:  source,
+		Target:  p.Target,
+		Columns: p.Columns,
+	}
+}
+
+// Preview returns the first n records of the file with the target column names.
+func (p *ImportPlan) Preview(n int) *Table {
+	records := p.Source.Records()
+	if len(records) > n {
+		records = records[:n]
+	}
+	return NewTable(p.Target, NewHeader(p.TargetColumns()), records)
+}
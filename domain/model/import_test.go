@@ -0,0 +1,116 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestInferColumnTypes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		records []Record
+		want    []ColumnType
+	}{
+		{
+			name: "each type",
+			records: []Record{
+				{"1", "1.5", "2024-01-02", "2024-01-02 03:04:05", "abc"},
+				{"-20", "3", "2024-12-31", "2024-12-31 23:59:59", "1"},
+			},
+			want: []ColumnType{ColumnTypeInteger, ColumnTypeFloat, ColumnTypeDate, ColumnTypeTimestamp, ColumnTypeText},
+		},
+		{
+			name: "empty values are ignored",
+			records: []Record{
+				{"", "", "1e3", ""},
+				{"10", "", "", ""},
+			},
+			want: []ColumnType{ColumnTypeInteger, ColumnTypeText, ColumnTypeFloat, ColumnTypeText},
+		},
+		{
+			name: "leading zero, mixed values and invalid dates are text",
+			records: []Record{
+				{"00123", "1", "2024-02-30", "NaN"},
+				{"00456", "2024-01-01", "2024-01-01", "Inf"},
+			},
+			want: []ColumnType{ColumnTypeText, ColumnTypeText, ColumnTypeText, ColumnTypeText},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			header := make(Header, len(tt.want))
+			for i := range header {
+				header[i] = "col" + string(rune('a'+i))
+			}
+			got := InferColumnTypes(NewTable("test", header, tt.records))
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("InferColumnTypes() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestImportPlanValues(t *testing.T) {
+	t.Parallel()
+
+	plan := &ImportPlan{
+		Source: NewTable("user", Header{"id", "name", "age"}, []Record{{"1", "", ""}, {"2", "", "3"}}),
+		Target: "user",
+		Columns: []ImportColumn{
+			{Source: "id", Target: "id", DataType: "BIGINT", EmptyAsNull: true},
+			{Source: "name", Target: "name", DataType: "TEXT"},
+			{Source: "age", Target: "age", DataType: "BIGINT", EmptyAsNull: true},
+		},
+	}
+	plan.Source.SetNull(1, 1)
+	want := []any{"1", "", nil}
+	if diff := cmp.Diff(want, plan.Values(0)); diff != "" {
+		t.Errorf("ImportPlan.Values() mismatch (-want +got):\n%s", diff)
+	}
+	want = []any{"2", nil, "3"}
+	if diff := cmp.Diff(want, plan.Values(1)); diff != "" {
+		t.Errorf("ImportPlan.Values() of NULL mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"id", "name", "age"}, plan.TargetColumns()); diff != "" {
+		t.Errorf("ImportPlan.TargetColumns() mismatch (-want +got):\n%s", diff)
+	}
+	if plan.CreatesTable() {
+		t.Error("ImportPlan.CreatesTable() = true, want false")
+	}
+}
+
+func TestColumnTypeOf(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		databaseTypeName string
+		want             ColumnType
+	}{
+		{databaseTypeName: "INT8", want: ColumnTypeInteger},
+		{databaseTypeName: "bigint", want: ColumnTypeInteger},
+		{databaseTypeName: "UNSIGNED INT", want: ColumnTypeInteger},
+		{databaseTypeName: "FLOAT8", want: ColumnTypeFloat},
+		{databaseTypeName: "NUMERIC", want: ColumnTypeDecimal},
+		{databaseTypeName: "DECIMAL(10,2)", want: ColumnTypeDecimal},
+		{databaseTypeName: "DATE", want: ColumnTypeDate},
+		{databaseTypeName: "DATETIME2", want: ColumnTypeTimestamp},
+		{databaseTypeName: "TIMESTAMP", want: ColumnTypeTimestamp},
+		{databaseTypeName: "BOOL", want: ColumnTypeText},
+		{databaseTypeName: "VARCHAR", want: ColumnTypeText},
+		{databaseTypeName: "", want: ColumnTypeText},
+	}
+	for _, tt := range tests {
+		t.Run(tt.databaseTypeName, func(t *testing.T) {
+			t.Parallel()
+
+			if got := ColumnTypeOf(tt.databaseTypeName); got != tt.want {
+				t.Errorf("ColumnTypeOf(%q) = %v, want %v", tt.databaseTypeName, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,380 @@
+package model
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// expensiveCostRatio is the ratio of the total cost of a plan from which a node is expensive.
+const expensiveCostRatio = 0.2
+
+// PlanNode is a step of a query plan.
+type PlanNode struct {
+	// Operation is the kind of the step, e.g. "Seq Scan", "Hash Join" or "SCAN user".
+	Operation string
+	// Object is the table or index that the step reads. It may be empty.
+	Object string
+	// Detail is additional information such as the filter condition. It may be empty.
+	Detail string
+	// Cost is the estimated cost of the step including its children. It is valid if HasCost is true.
+	Cost    float64
+	HasCost bool
+	// Rows is the number of rows that the step returns (actual rows if the plan was analyzed,
+	// otherwise the estimate). It is valid if HasRows is true.
+	Rows    float64
+	HasRows bool
+	// Time is the actual time of the step in milliseconds. It is valid if HasTime is true.
+	Time    float64
+	HasTime bool
+	// FullScan is true if the step reads a whole table, such as a sequential scan.
+	FullScan bool
+	// Expensive is true if the cost of the step itself is a large part of the total cost.
+	Expensive bool
+	// Children is the steps whose rows this step reads.
+	Children []*PlanNode
+}
+
+// SelfCost returns the cost of the step excluding its children.
+func (n *PlanNode) SelfCost() float64 {
+	cost := n.Cost
+	for _, c := range n.Children {
+		cost -= c.Cost
+	}
+	return max(cost, 0)
+}
+
+// String returns the step in "Operation on object (cost=1.00 rows=10 time=0.123ms) detail" format.
+func (n *PlanNode) String() string {
+	s := n.Operation
+	if n.Object != "" {
+		s += " on " + n.Object
+	}
+	metrics := []string{}
+	if n.HasCost {
+		metrics = append(metrics, "cost="+strconv.FormatFloat(n.Cost, 'f', 2, 64))
+	}
+	if n.HasRows {
+		metrics = append(metrics, "rows="+strconv.FormatFloat(n.Rows, 'f', -1, 64))
+	}
+	if n.HasTime {
+		metrics = append(metrics, "time="+strconv.FormatFloat(n.Time, 'f', 3, 64)+"ms")
+	}
+	if len(metrics) > 0 {
+		s += " (" + strings.Join(metrics, " ") + ")"
+	}
+	if n.Detail != "" {
+		s += " " + n.Detail
+	}
+	return s
+}
+
+// QueryPlan is the plan of a query returned by EXPLAIN.
+type QueryPlan struct {
+	// Root is the last step of the plan.
+	Root *PlanNode
+	// Raw is the plan as returned by the DBMS (JSON, XML or text).
+	Raw string
+}
+
+// NewQueryPlan creates a QueryPlan. A node without a cost gets the total cost of its children,
+// and the nodes whose own cost is at least 20% of the total cost are marked as expensive.
+func NewQueryPlan(root *PlanNode, raw string) *QueryPlan {
+	fillCost(root)
+	if root.Cost > 0 {
+		markExpensive(root, root.Cost*expensiveCostRatio)
+	}
+	return &QueryPlan{Root: root, Raw: raw}
+}
+
+// fillCost sets the total cost of the children to the nodes that have no cost.
+func fillCost(n *PlanNode) {
+	sum := 0.0
+	for _, c := range n.Children {
+		fillCost(c)
+		sum += c.Cost
+	}
+	if !n.HasCost {
+		n.Cost = sum
+	}
+}
+
+// markExpensive marks the nodes whose own cost is at least the threshold.
+func markExpensive(n *PlanNode, threshold float64) {
+	n.Expensive = n.HasCost && n.SelfCost() >= threshold
+	for _, c := range n.Children {
+		markExpensive(c, threshold)
+	}
+}
+
+// ParsePostgreSQLPlan parses the output of EXPLAIN (FORMAT JSON).
+func ParsePostgreSQLPlan(raw string) (*QueryPlan, error) {
+	var plans []map[string]any
+	if err := json.Unmarshal([]byte(raw), &plans); err != nil {
+		return nil, fmt.Errorf("failed to parse the plan: %w", err)
+	}
+	if len(plans) == 0 {
+		return nil, errors.New("the plan is empty")
+	}
+	plan, ok := plans[0]["Plan"].(map[string]any)
+	if !ok {
+		return nil, errors.New("the plan has no Plan node")
+	}
+	return NewQueryPlan(postgreSQLPlanNode(plan), raw), nil
+}
+
+// postgreSQLPlanNode converts a node of the JSON plan and its children.
+func postgreSQLPlanNode(plan map[string]any) *PlanNode {
+	n := &PlanNode{Operation: jsonString(plan, "Node Type")}
+	if join := jsonString(plan, "Join Type"); join != "" && join != "Inner" {
+		n.Operation += " (" + join + ")"
+	}
+	n.Object = jsonString(plan, "Relation Name")
+	if index := jsonString(plan, "Index Name"); index != "" {
+		if n.Object != "" {
+			n.Object += " using " + index
+		} else {
+			n.Object = index
+		}
+	}
+	n.Cost, n.HasCost = jsonNumber(plan, "Total Cost")
+	if n.Rows, n.HasRows = jsonNumber(plan, "Actual Rows"); !n.HasRows {
+		n.Rows, n.HasRows = jsonNumber(plan, "Plan Rows")
+	}
+	if n.Time, n.HasTime = jsonNumber(plan, "Actual Total Time"); n.HasTime {
+		if loops, ok := jsonNumber(plan, "Actual Loops"); ok && loops > 1 {
+			n.Time *= loops
+		}
+	}
+	n.FullScan = n.Operation == "Seq Scan"
+
+	details := []string{}
+	for _, key := range []string{"Index Cond", "Hash Cond", "Merge Cond", "Join Filter", "Filter"} {
+		if v := jsonString(plan, key); v != "" {
+			details = append(details, key+": "+v)
+		}
+	}
+	n.Detail = strings.Join(details, ", ")
+
+	if children, ok := plan["Plans"].([]any); ok {
+		for _, child := range children {
+			if c, ok := child.(map[string]any); ok {
+				n.Children = append(n.Children, postgreSQLPlanNode(c))
+			}
+		}
+	}
+	return n
+}
+
+// mysqlPlanKeys is the keys of the MySQL JSON plan that have child steps, in the order they are shown.
+var mysqlPlanKeys = []string{
+	"query_block", "union_result", "query_specifications", "ordering_operation", "grouping_operation",
+	"duplicates_removal", "windowing", "buffer_result", "nested_loop", "table",
+	"materialized_from_subquery", "attached_subqueries", "optimized_away_subqueries",
+}
+
+// ParseMySQLPlan parses the output of EXPLAIN FORMAT=JSON.
+func ParseMySQLPlan(raw string) (*QueryPlan, error) {
+	var plan map[string]any
+	if err := json.Unmarshal([]byte(raw), &plan); err != nil {
+		return nil, fmt.Errorf("failed to parse the plan: %w", err)
+	}
+	block, ok := plan["query_block"].(map[string]any)
+	if !ok {
+		return nil, errors.New("the plan has no query_block")
+	}
+	return NewQueryPlan(mysqlPlanNode("query_block", block), raw), nil
+}
+
+// mysqlPlanNode converts a step of the JSON plan and its children. key is the name of the step.
+func mysqlPlanNode(key string, step map[string]any) *PlanNode {
+	n := &PlanNode{Operation: strings.ReplaceAll(key, "_", " ")}
+	costInfo, _ := step["cost_info"].(map[string]any)
+	switch key {
+	case "query_block":
+		n.Operation = "query block"
+		if id, ok := jsonNumber(step, "select_id"); ok {
+			n.Operation += " #" + strconv.FormatFloat(id, 'f', -1, 64)
+		}
+		n.Cost, n.HasCost = jsonNumber(costInfo, "query_cost")
+	case "table":
+		access := jsonString(step, "access_type")
+		n.Operation = "table access (" + access + ")"
+		n.Object = jsonString(step, "table_name")
+		if index := jsonString(step, "key"); index != "" {
+			n.Object += " using " + index
+		}
+		read, hasRead := jsonNumber(costInfo, "read_cost")
+		eval, hasEval := jsonNumber(costInfo, "eval_cost")
+		n.Cost, n.HasCost = read+eval, hasRead || hasEval
+		n.Rows, n.HasRows = jsonNumber(step, "rows_examined_per_scan")
+		n.FullScan = access == "ALL"
+		n.Detail = jsonString(step, "attached_condition")
+	}
+
+	for _, k := range mysqlPlanKeys {
+		switch v := step[k].(type) {
+		case map[string]any:
+			n.Children = append(n.Children, mysqlPlanNode(k, v))
+		case []any:
+			// An array has the steps of a join or the subqueries.
+			group := &PlanNode{Operation: strings.ReplaceAll(k, "_", " ")}
+			for _, item := range v {
+				child, ok := item.(map[string]any)
+				if !ok {
+					continue
+				}
+				for _, ck := range mysqlPlanKeys {
+					if c, ok := child[ck].(map[string]any); ok {
+						group.Children = append(group.Children, mysqlPlanNode(ck, c))
+					}
+				}
+			}
+			n.Children = append(n.Children, group)
+		}
+	}
+	return n
+}
+
+// ParseSQLite3Plan parses the records of EXPLAIN QUERY PLAN. Each record is id, parent, notused and detail.
+func ParseSQLite3Plan(records []Record) (*QueryPlan, error) {
+	root := &PlanNode{Operation: "QUERY PLAN"}
+	nodes := map[string]*PlanNode{"0": root}
+	lines := make([]string, 0, len(records))
+	for _, r := range records {
+		if len(r) < 4 {
+			return nil, errors.New("the plan record must have id, parent, notused and detail")
+		}
+		n := &PlanNode{Operation: r[3]}
+		detail := strings.ToUpper(r[3])
+		n.FullScan = strings.HasPrefix(detail, "SCAN ") && !strings.Contains(detail, " INDEX") &&
+			!strings.Contains(detail, "INTEGER PRIMARY KEY")
+		parent, ok := nodes[r[1]]
+		if !ok {
+			parent = root
+		}
+		parent.Children = append(parent.Children, n)
+		nodes[r[0]] = n
+		lines = append(lines, strings.Join(r, "|"))
+	}
+	return NewQueryPlan(root, strings.Join(lines, "\n")), nil
+}
+
+// xmlNode is an element of an XML document.
+type xmlNode struct {
+	XMLName  xml.Name
+	Attrs    []xml.Attr `xml:",any,attr"`
+	Children []*xmlNode `xml:",any"`
+}
+
+// attr returns the value of the attribute, or an empty string.
+func (x *xmlNode) attr(name string) string {
+	for _, a := range x.Attrs {
+		if a.Name.Local == name {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// number returns the attribute as a number.
+func (x *xmlNode) number(name string) (float64, bool) {
+	f, err := strconv.ParseFloat(x.attr(name), 64)
+	return f, err == nil
+}
+
+// find returns the descendant elements with the name. The descendants of a found element
+// and of the elements named stop are not searched.
+func (x *xmlNode) find(name, stop string) []*xmlNode {
+	found := []*xmlNode{}
+	for _, c := range x.Children {
+		switch c.XMLName.Local {
+		case name:
+			found = append(found, c)
+		case stop:
+		default:
+			found = append(found, c.find(name, stop)...)
+		}
+	}
+	return found
+}
+
+// ParseSQLServerPlan parses the XML showplan returned while SHOWPLAN_XML is ON.
+func ParseSQLServerPlan(raw string) (*QueryPlan, error) {
+	var doc xmlNode
+	decoder := xml.NewDecoder(strings.NewReader(raw))
+	// The plan declares utf-16, but it is already decoded into a Go string by the driver.
+	decoder.CharsetReader = func(_ string, r io.Reader) (io.Reader, error) { return r, nil }
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to parse the plan: %w", err)
+	}
+	statements := doc.find("StmtSimple", "")
+	if len(statements) == 0 {
+		return nil, errors.New("the plan has no statement")
+	}
+
+	nodes := make([]*PlanNode, 0, len(statements))
+	for _, stmt := range statements {
+		n := &PlanNode{Operation: "Statement", Detail: strings.TrimSpace(stmt.attr("StatementText"))}
+		n.Cost, n.HasCost = stmt.number("StatementSubTreeCost")
+		n.Rows, n.HasRows = stmt.number("StatementEstRows")
+		for _, op := range stmt.find("RelOp", "") {
+			n.Children = append(n.Children, sqlServerPlanNode(op))
+		}
+		nodes = append(nodes, n)
+	}
+	if len(nodes) == 1 {
+		return NewQueryPlan(nodes[0], raw), nil
+	}
+	return NewQueryPlan(&PlanNode{Operation: "Batch", Children: nodes}, raw), nil
+}
+
+// sqlServerPlanNode converts a RelOp element and the RelOp elements under it.
+func sqlServerPlanNode(op *xmlNode) *PlanNode {
+	n := &PlanNode{Operation: op.attr("PhysicalOp")}
+	if logical := op.attr("LogicalOp"); logical != "" && logical != n.Operation {
+		n.Operation += " (" + logical + ")"
+	}
+	n.Cost, n.HasCost = op.number("EstimatedTotalSubtreeCost")
+	n.Rows, n.HasRows = op.number("EstimateRows")
+	n.FullScan = op.attr("PhysicalOp") == "Table Scan" || op.attr("PhysicalOp") == "Clustered Index Scan"
+	if objects := op.find("Object", "RelOp"); len(objects) > 0 {
+		n.Object = strings.Trim(objects[0].attr("Table"), "[]")
+		if index := objects[0].attr("Index"); index != "" {
+			n.Object += " using " + strings.Trim(index, "[]")
+		}
+	}
+	for _, child := range op.find("RelOp", "") {
+		n.Children = append(n.Children, sqlServerPlanNode(child))
+	}
+	return n
+}
+
+// jsonString returns the value of the key as a string, or an empty string.
+func jsonString(m map[string]any, key string) string {
+	switch v := m[key].(type) {
+	case string:
+		return v
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// jsonNumber returns the value of the key as a number. MySQL writes costs as strings.
+func jsonNumber(m map[string]any, key string) (float64, bool) {
+	switch v := m[key].(type) {
+	case float64:
+		return v, true
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
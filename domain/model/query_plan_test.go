@@ -0,0 +1,154 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// planLines returns the nodes in depth-first order with the markers, e.g. "  Seq Scan on user [full scan]".
+func planLines(n *PlanNode, depth int) []string {
+	line := ""
+	for range depth {
+		line += "  "
+	}
+	line += n.String()
+	if n.FullScan {
+		line += " [full scan]"
+	}
+	if n.Expensive {
+		line += " [expensive]"
+	}
+	lines := []string{line}
+	for _, c := range n.Children {
+		lines = append(lines, planLines(c, depth+1)...)
+	}
+	return lines
+}
+
+func TestParseQueryPlan(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		parse func() (*QueryPlan, error)
+		want  []string
+	}{
+		{
+			name: "PostgreSQL",
+			parse: func() (*QueryPlan, error) {
+				return ParsePostgreSQLPlan(`[{"Plan": {
+					"Node Type": "Hash Join", "Join Type": "Inner", "Total Cost": 100.0, "Plan Rows": 10,
+					"Actual Rows": 8, "Actual Total Time": 1.5, "Actual Loops": 1, "Hash Cond": "(u.team_id = t.id)",
+					"Plans": [
+						{"Node Type": "Seq Scan", "Relation Name": "user", "Total Cost": 70.0, "Plan Rows": 1000,
+						 "Actual Rows": 990, "Actual Total Time": 0.25, "Actual Loops": 2, "Filter": "(age > 20)"},
+						{"Node Type": "Index Scan", "Relation Name": "team", "Index Name": "team_pkey", "Total Cost": 5.0, "Plan Rows": 3}
+					]}, "Planning Time": 0.1}]`)
+			},
+			want: []string{
+				"Hash Join (cost=100.00 rows=8 time=1.500ms) Hash Cond: (u.team_id = t.id) [expensive]",
+				"  Seq Scan on user (cost=70.00 rows=990 time=0.500ms) Filter: (age > 20) [full scan] [expensive]",
+				"  Index Scan on team using team_pkey (cost=5.00 rows=3)",
+			},
+		},
+		{
+			name: "MySQL",
+			parse: func() (*QueryPlan, error) {
+				return ParseMySQLPlan(`{"query_block": {"select_id": 1, "cost_info": {"query_cost": "12.50"},
+					"ordering_operation": {"using_filesort": true,
+						"nested_loop": [
+							{"table": {"table_name": "u", "access_type": "ALL", "rows_examined_per_scan": 100,
+							 "cost_info": {"read_cost": "9.00", "eval_cost": "1.00"}, "attached_condition": "(u.age > 20)"}},
+							{"table": {"table_name": "t", "access_type": "eq_ref", "key": "PRIMARY", "rows_examined_per_scan": 1,
+							 "cost_info": {"read_cost": "0.25", "eval_cost": "0.25"}}}
+						]}}}`)
+			},
+			want: []string{
+				"query block #1 (cost=12.50)",
+				"  ordering operation",
+				"    nested loop",
+				"      table access (ALL) on u (cost=10.00 rows=100) (u.age > 20) [full scan] [expensive]",
+				"      table access (eq_ref) on t using PRIMARY (cost=0.50 rows=1)",
+			},
+		},
+		{
+			name: "SQLite3",
+			parse: func() (*QueryPlan, error) {
+				return ParseSQLite3Plan([]Record{
+					{"3", "0", "0", "SCAN user"},
+					{"7", "0", "0", "SEARCH team USING INTEGER PRIMARY KEY (rowid=?)"},
+					{"12", "0", "0", "USE TEMP B-TREE FOR ORDER BY"},
+					{"20", "12", "0", "SCAN t USING COVERING INDEX idx_t"},
+				})
+			},
+			want: []string{
+				"QUERY PLAN",
+				"  SCAN user [full scan]",
+				"  SEARCH team USING INTEGER PRIMARY KEY (rowid=?)",
+				"  USE TEMP B-TREE FOR ORDER BY",
+				"    SCAN t USING COVERING INDEX idx_t",
+			},
+		},
+		{
+			name: "SQL Server",
+			parse: func() (*QueryPlan, error) {
+				return ParseSQLServerPlan(`<?xml version="1.0" encoding="utf-16"?>
+<ShowPlanXML xmlns="http://schemas.microsoft.com/sqlserver/2004/07/showplan" Version="1.6">
+ <BatchSequence><Batch><Statements>
+  <StmtSimple StatementText="SELECT * FROM [user] u JOIN team t ON u.team_id = t.id" StatementSubTreeCost="0.5" StatementEstRows="10">
+   <QueryPlan>
+    <RelOp PhysicalOp="Nested Loops" LogicalOp="Inner Join" EstimateRows="10" EstimatedTotalSubtreeCost="0.5">
+     <NestedLoops>
+      <RelOp PhysicalOp="Table Scan" LogicalOp="Table Scan" EstimateRows="100" EstimatedTotalSubtreeCost="0.4">
+       <TableScan><Object Database="[db]" Schema="[dbo]" Table="[user]" Alias="[u]"/></TableScan>
+      </RelOp>
+      <RelOp PhysicalOp="Clustered Index Seek" LogicalOp="Clustered Index Seek" EstimateRows="1" EstimatedTotalSubtreeCost="0.05">
+       <IndexScan><Object Database="[db]" Schema="[dbo]" Table="[team]" Index="[PK_team]"/></IndexScan>
+      </RelOp>
+     </NestedLoops>
+    </RelOp>
+   </QueryPlan>
+  </StmtSimple>
+ </Statements></Batch></BatchSequence>
+</ShowPlanXML>`)
+			},
+			want: []string{
+				"Statement (cost=0.50 rows=10) SELECT * FROM [user] u JOIN team t ON u.team_id = t.id",
+				"  Nested Loops (Inner Join) (cost=0.50 rows=10)",
+				"    Table Scan on user (cost=0.40 rows=100) [full scan] [expensive]",
+				"    Clustered Index Seek on team using PK_team (cost=0.05 rows=1)",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			plan, err := tt.parse()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, planLines(plan.Root, 0)); diff != "" {
+				t.Errorf("plan mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseQueryPlanError(t *testing.T) {
+	t.Parallel()
+
+	if _, err := ParsePostgreSQLPlan(`[]`); err == nil {
+		t.Error("ParsePostgreSQLPlan() returns no error for an empty plan")
+	}
+	if _, err := ParseMySQLPlan(`{"message": "no query block"}`); err == nil {
+		t.Error("ParseMySQLPlan() returns no error without query_block")
+	}
+	if _, err := ParseSQLServerPlan(`<ShowPlanXML></ShowPlanXML>`); err == nil {
+		t.Error("ParseSQLServerPlan() returns no error without statements")
+	}
+	if _, err := ParseSQLite3Plan([]Record{{"1", "0"}}); err == nil {
+		t.Error("ParseSQLite3Plan() returns no error for a short record")
+	}
+}
@@ -0,0 +1,98 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ObjectType is the type of a database object other than a table.
+type ObjectType string
+
+const (
+	// ObjectTypeView is a view.
+	ObjectTypeView ObjectType = "Views"
+	// ObjectTypeMaterializedView is a materialized view.
+	ObjectTypeMaterializedView ObjectType = "Materialized Views"
+	// ObjectTypeSequence is a sequence.
+	ObjectTypeSequence ObjectType = "Sequences"
+	// ObjectTypeFunction is a function or a stored procedure.
+	ObjectTypeFunction ObjectType = "Functions"
+)
+
+// ObjectTypes returns all object types in the order they are shown to the user.
+func ObjectTypes() []ObjectType {
+	return []ObjectType{
+		ObjectTypeView,
+		ObjectTypeMaterializedView,
+		ObjectTypeSequence,
+		ObjectTypeFunction,
+	}
+}
+
+// Index represents a table index.
+type Index struct {
+	// Name is index name.
+	Name string
+	// Columns is the indexed columns in index order.
+	Columns []string
+	// Unique is true if the index is unique.
+	Unique bool
+	// Primary is true if the index is the primary key.
+	Primary bool
+}
+
+// String returns the index in "name (col1, col2) UNIQUE" format.
+func (i *Index) String() string {
+	s := fmt.Sprintf("%s (%s)", i.Name, strings.Join(i.Columns, ", "))
+	switch {
+	case i.Primary:
+		s += " PRIMARY"
+	case i.Unique:
+		s += " UNIQUE"
+	}
+	return s
+}
+
+// ForeignKey represents a foreign key constraint.
+type ForeignKey struct {
+	// Name is constraint name. SQLite3 foreign keys have no name.
+	Name string
+	// Columns is the referencing columns.
+	Columns []string
+	// RefTable is the referenced table name.
+	RefTable string
+	// RefSchema is the schema of the referenced table. It is set only in SQL Server, where the tables of
+	// all schemas are listed.
+	RefSchema string
+	// RefColumns is the referenced columns in the same order as Columns.
+	RefColumns []string
+}
+
+// String returns the foreign key in "name: (col) -> table(col)" format.
+func (f *ForeignKey) String() string {
+	refTable := f.RefTable
+	if f.RefSchema != "" {
+		refTable = f.RefSchema + "." + refTable
+	}
+	s := fmt.Sprintf("(%s) -> %s(%s)",
+		strings.Join(f.Columns, ", "), refTable, strings.Join(f.RefColumns, ", "))
+	if f.Name == "" {
+		return s
+	}
+	return f.Name + ": " + s
+}
+
+// Trigger represents a table trigger.
+type Trigger struct {
+	// Name is trigger name.
+	Name string
+	// Timing is BEFORE, AFTER or INSTEAD OF.
+	Timing string
+	// Events is the statements that fire the trigger (INSERT, UPDATE, DELETE).
+	Events []string
+}
+
+// String returns the trigger in "name BEFORE INSERT OR UPDATE" format.
+func (t *Trigger) String() string {
+	return strings.TrimSpace(fmt.Sprintf("%s %s %s", t.Name, t.Timing, strings.Join(t.Events, " OR ")))
+}
@@ -0,0 +1,328 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ColumnSchema is the definition of a column used to compare schemas.
+type ColumnSchema struct {
+	// Name is column name.
+	Name string
+	// Type is the data type including the length, e.g. "varchar(255)".
+	Type string
+	// Nullable is true if the column accepts NULL.
+	Nullable bool
+	// Default is the default value expression. It is empty if the column has no default.
+	Default string
+	// TypeIncomplete is true if Type may lack the precision, the scale or other parts of the type,
+	// because only the data type and the length are known. Such a type is not written in ALTER statements.
+	TypeIncomplete bool
+	// Extra is the MySQL attributes of the column, e.g. "auto_increment" or "on update CURRENT_TIMESTAMP".
+	Extra string
+	// Collation is the MySQL collation of a character column. It is empty if it is unknown.
+	Collation string
+	// Comment is the MySQL comment of the column.
+	Comment string
+}
+
+// String returns the column in "name type NOT NULL DEFAULT x" format.
+func (c *ColumnSchema) String() string {
+	s := c.Name + " " + c.Type
+	if !c.Nullable {
+		s += " NOT NULL"
+	}
+	if c.Default != "" {
+		s += " DEFAULT " + c.Default
+	}
+	return s
+}
+
+// TableSchema is the definition of a table used to compare schemas and to draw ER diagrams.
+type TableSchema struct {
+	// Name is table name.
+	Name string
+	// Columns is the columns in ordinal order.
+	Columns []*ColumnSchema
+	// PrimaryKey is the primary key columns. It is empty if the table has no primary key.
+	PrimaryKey []string
+	// PrimaryKeyName is the name of the primary key constraint. It may be empty.
+	PrimaryKeyName string
+	// Indexes is the indexes other than the primary key.
+	Indexes []*Index
+	// ForeignKeys is the foreign keys. They are drawn in ER diagrams, and not compared by DiffSchemas.
+	ForeignKeys []*ForeignKey
+}
+
+// column returns the column that has the name (case-insensitive), or nil.
+func (t *TableSchema) column(name string) *ColumnSchema {
+	for _, c := range t.Columns {
+		if strings.EqualFold(c.Name, name) {
+			return c
+		}
+	}
+	return nil
+}
+
+// index returns the index that has the name (case-insensitive), or nil.
+func (t *TableSchema) index(name string) *Index {
+	for _, i := range t.Indexes {
+		if strings.EqualFold(i.Name, name) {
+			return i
+		}
+	}
+	return nil
+}
+
+// attributes returns the MySQL attributes of the column, or "none" if the column has no attributes.
+func (c *ColumnSchema) attributes() string {
+	attributes := []string{}
+	if c.Extra != "" {
+		attributes = append(attributes, c.Extra)
+	}
+	if c.Collation != "" {
+		attributes = append(attributes, "COLLATE "+c.Collation)
+	}
+	if c.Comment != "" {
+		attributes = append(attributes, "COMMENT '"+strings.ReplaceAll(c.Comment, "'", "''")+"'")
+	}
+	if len(attributes) == 0 {
+		return "none"
+	}
+	return strings.Join(attributes, " ")
+}
+
+// ColumnChange is a column that exists on both sides with a different definition.
+type ColumnChange struct {
+	From *ColumnSchema
+	To   *ColumnSchema
+}
+
+// TypeChanged returns true if the data type is changed.
+func (c *ColumnChange) TypeChanged() bool {
+	return !strings.EqualFold(c.From.Type, c.To.Type)
+}
+
+// NullableChanged returns true if the nullability is changed.
+func (c *ColumnChange) NullableChanged() bool {
+	return c.From.Nullable != c.To.Nullable
+}
+
+// DefaultChanged returns true if the default value is changed.
+func (c *ColumnChange) DefaultChanged() bool {
+	return c.From.Default != c.To.Default
+}
+
+// AttributesChanged returns true if the MySQL attributes, the collation or the comment is changed.
+// The collation is compared only if it is known on both sides.
+func (c *ColumnChange) AttributesChanged() bool {
+	if c.From.Collation != "" && c.To.Collation != "" && !strings.EqualFold(c.From.Collation, c.To.Collation) {
+		return true
+	}
+	return !strings.EqualFold(c.From.Extra, c.To.Extra) || c.From.Comment != c.To.Comment
+}
+
+// TableDiff is the difference of a table that exists on both sides.
+type TableDiff struct {
+	// Name is table name.
+	Name string
+	// AddedColumns is the columns that exist only in the "to" side.
+	AddedColumns []*ColumnSchema
+	// DroppedColumns is the columns that exist only in the "from" side.
+	DroppedColumns []*ColumnSchema
+	// ChangedColumns is the columns whose type, nullability, default or attributes differ.
+	ChangedColumns []*ColumnChange
+	// FromPrimaryKey and ToPrimaryKey are the primary key columns if the primary key differs.
+	FromPrimaryKey []string
+	ToPrimaryKey   []string
+	// FromPrimaryKeyName is the name of the primary key constraint to be dropped. It may be empty.
+	FromPrimaryKeyName string
+	// AddedIndexes is the indexes that exist only in the "to" side, or whose definition differs.
+	AddedIndexes []*Index
+	// DroppedIndexes is the indexes that exist only in the "from" side, or whose definition differs.
+	DroppedIndexes []*Index
+}
+
+// PrimaryKeyChanged returns true if the primary key differs.
+func (d *TableDiff) PrimaryKeyChanged() bool {
+	return d.FromPrimaryKey != nil || d.ToPrimaryKey != nil
+}
+
+// isEmpty returns true if the table is the same on both sides.
+func (d *TableDiff) isEmpty() bool {
+	return len(d.AddedColumns) == 0 && len(d.DroppedColumns) == 0 && len(d.ChangedColumns) == 0 &&
+		!d.PrimaryKeyChanged() && len(d.AddedIndexes) == 0 && len(d.DroppedIndexes) == 0
+}
+
+// SchemaDiff is the difference between two schemas. The changes are described as the changes
+// that turn the "from" schema into the "to" schema.
+type SchemaDiff struct {
+	// AddedTables is the tables that exist only in the "to" schema.
+	AddedTables []*TableSchema
+	// DroppedTables is the tables that exist only in the "from" schema.
+	DroppedTables []*TableSchema
+	// ChangedTables is the tables that exist in both schemas with differences.
+	ChangedTables []*TableDiff
+}
+
+// IsEmpty returns true if the schemas are the same.
+func (d *SchemaDiff) IsEmpty() bool {
+	return len(d.AddedTables) == 0 && len(d.DroppedTables) == 0 && len(d.ChangedTables) == 0
+}
+
+// DiffSchemas compares the tables of two schemas. Tables, columns and indexes are matched by name
+// (case-insensitive), and data types are compared case-insensitively.
+func DiffSchemas(from, to []*TableSchema) *SchemaDiff {
+	diff := &SchemaDiff{}
+	for _, f := range from {
+		t := findTableSchema(to, f.Name)
+		if t == nil {
+			diff.DroppedTables = append(diff.DroppedTables, f)
+			continue
+		}
+		if td := diffTables(f, t); !td.isEmpty() {
+			diff.ChangedTables = append(diff.ChangedTables, td)
+		}
+	}
+	for _, t := range to {
+		if findTableSchema(from, t.Name) == nil {
+			diff.AddedTables = append(diff.AddedTables, t)
+		}
+	}
+	return diff
+}
+
+// findTableSchema returns the table that has the name (case-insensitive), or nil.
+func findTableSchema(tables []*TableSchema, name string) *TableSchema {
+	for _, t := range tables {
+		if strings.EqualFold(t.Name, name) {
+			return t
+		}
+	}
+	return nil
+}
+
+// diffTables compares two definitions of the same table.
+func diffTables(from, to *TableSchema) *TableDiff {
+	diff := &TableDiff{Name: to.Name}
+	for _, f := range from.Columns {
+		t := to.column(f.Name)
+		if t == nil {
+			diff.DroppedColumns = append(diff.DroppedColumns, f)
+			continue
+		}
+		change := &ColumnChange{From: f, To: t}
+		if change.TypeChanged() || change.NullableChanged() || change.DefaultChanged() || change.AttributesChanged() {
+			diff.ChangedColumns = append(diff.ChangedColumns, change)
+		}
+	}
+	for _, t := range to.Columns {
+		if from.column(t.Name) == nil {
+			diff.AddedColumns = append(diff.AddedColumns, t)
+		}
+	}
+
+	if !equalFoldNames(from.PrimaryKey, to.PrimaryKey) {
+		diff.FromPrimaryKey = append([]string{}, from.PrimaryKey...)
+		diff.ToPrimaryKey = append([]string{}, to.PrimaryKey...)
+		diff.FromPrimaryKeyName = from.PrimaryKeyName
+	}
+
+	for _, f := range from.Indexes {
+		t := to.index(f.Name)
+		if t == nil || t.Unique != f.Unique || !equalFoldNames(t.Columns, f.Columns) {
+			diff.DroppedIndexes = append(diff.DroppedIndexes, f)
+		}
+	}
+	for _, t := range to.Indexes {
+		f := from.index(t.Name)
+		if f == nil || t.Unique != f.Unique || !equalFoldNames(t.Columns, f.Columns) {
+			diff.AddedIndexes = append(diff.AddedIndexes, t)
+		}
+	}
+	return diff
+}
+
+// equalFoldNames returns true if the names are the same in the same order (case-insensitive).
+func equalFoldNames(a, b []string) bool {
+	return slices.EqualFunc(a, b, strings.EqualFold)
+}
+
+// String returns the differences, one per line. Added, dropped and changed tables start with
+// "+ table", "- table" and "~ table", and the changes of a table are indented below it,
+// e.g. "    ~ column name: varchar(10) -> varchar(20), NULL -> NOT NULL, default none -> 'none'".
+func (d *SchemaDiff) String() string {
+	if d.IsEmpty() {
+		return "No differences\n"
+	}
+
+	var b strings.Builder
+	for _, t := range d.AddedTables {
+		fmt.Fprintf(&b, "+ table %s\n", t.Name)
+	}
+	for _, t := range d.DroppedTables {
+		fmt.Fprintf(&b, "- table %s\n", t.Name)
+	}
+	for _, t := range d.ChangedTables {
+		fmt.Fprintf(&b, "~ table %s\n", t.Name)
+		for _, c := range t.AddedColumns {
+			fmt.Fprintf(&b, "    + column %s\n", c.String())
+		}
+		for _, c := range t.DroppedColumns {
+			fmt.Fprintf(&b, "    - column %s\n", c.Name)
+		}
+		for _, c := range t.ChangedColumns {
+			changes := []string{}
+			if c.TypeChanged() {
+				changes = append(changes, c.From.Type+" -> "+c.To.Type)
+			}
+			if c.NullableChanged() {
+				changes = append(changes, nullability(c.From.Nullable)+" -> "+nullability(c.To.Nullable))
+			}
+			if c.DefaultChanged() {
+				changes = append(changes, "default "+defaultValue(c.From.Default)+" -> "+defaultValue(c.To.Default))
+			}
+			if c.AttributesChanged() {
+				changes = append(changes, "attributes "+c.From.attributes()+" -> "+c.To.attributes())
+			}
+			fmt.Fprintf(&b, "    ~ column %s: %s\n", c.To.Name, strings.Join(changes, ", "))
+		}
+		if t.PrimaryKeyChanged() {
+			fmt.Fprintf(&b, "    ~ primary key (%s) -> (%s)\n",
+				strings.Join(t.FromPrimaryKey, ", "), strings.Join(t.ToPrimaryKey, ", "))
+		}
+		for _, i := range t.DroppedIndexes {
+			fmt.Fprintf(&b, "    - index %s\n", i.String())
+		}
+		for _, i := range t.AddedIndexes {
+			fmt.Fprintf(&b, "    + index %s\n", i.String())
+		}
+	}
+	return b.String()
+}
+
+// Comment returns String() as SQL comments, so that it can be written before the ALTER statements.
+func (d *SchemaDiff) Comment() string {
+	var b strings.Builder
+	for _, line := range strings.Split(strings.TrimSuffix(d.String(), "\n"), "\n") {
+		fmt.Fprintf(&b, "-- %s\n", line)
+	}
+	return b.String()
+}
+
+// nullability returns "NULL" or "NOT NULL".
+func nullability(nullable bool) string {
+	if nullable {
+		return "NULL"
+	}
+	return "NOT NULL"
+}
+
+// defaultValue returns the default value, or "none" if the column has no default.
+func defaultValue(v string) string {
+	if v == "" {
+		return "none"
+	}
+	return v
+}
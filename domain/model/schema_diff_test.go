@@ -0,0 +1,93 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDiffSchemas(t *testing.T) {
+	t.Parallel()
+
+	from := []*TableSchema{
+		{
+			Name: "user",
+			Columns: []*ColumnSchema{
+				{Name: "id", Type: "integer"},
+				{Name: "name", Type: "varchar(10)", Nullable: true},
+				{Name: "age", Type: "integer", Nullable: true},
+			},
+			PrimaryKey: []string{"id"},
+			Indexes: []*Index{
+				{Name: "idx_user_name", Columns: []string{"name"}},
+				{Name: "idx_user_age", Columns: []string{"age"}},
+			},
+		},
+		{Name: "old", Columns: []*ColumnSchema{{Name: "id", Type: "integer"}}},
+		{Name: "same", Columns: []*ColumnSchema{{Name: "id", Type: "INTEGER"}}},
+	}
+	to := []*TableSchema{
+		{
+			Name: "user",
+			Columns: []*ColumnSchema{
+				{Name: "ID", Type: "INTEGER", Extra: "auto_increment", Comment: "user's id"},
+				{Name: "name", Type: "varchar(20)", Default: "'none'"},
+				{Name: "email", Type: "text", Nullable: true},
+			},
+			PrimaryKey: []string{"id", "name"},
+			Indexes: []*Index{
+				{Name: "idx_user_name", Columns: []string{"name"}, Unique: true},
+			},
+		},
+		{Name: "same", Columns: []*ColumnSchema{{Name: "id", Type: "integer"}}},
+		{Name: "new", Columns: []*ColumnSchema{{Name: "id", Type: "integer"}}},
+	}
+
+	got := DiffSchemas(from, to)
+	want := &SchemaDiff{
+		AddedTables:   []*TableSchema{to[2]},
+		DroppedTables: []*TableSchema{from[1]},
+		ChangedTables: []*TableDiff{
+			{
+				Name:           "user",
+				AddedColumns:   []*ColumnSchema{to[0].Columns[2]},
+				DroppedColumns: []*ColumnSchema{from[0].Columns[2]},
+				ChangedColumns: []*ColumnChange{
+					{From: from[0].Columns[0], To: to[0].Columns[0]},
+					{From: from[0].Columns[1], To: to[0].Columns[1]},
+				},
+				FromPrimaryKey: []string{"id"},
+				ToPrimaryKey:   []string{"id", "name"},
+				AddedIndexes:   []*Index{to[0].Indexes[0]},
+				DroppedIndexes: []*Index{from[0].Indexes[0], from[0].Indexes[1]},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("DiffSchemas() mismatch (-want +got):\n%s", diff)
+	}
+
+	wantString := `+ table new
+- table old
+~ table user
+    + column email text
+    - column age
+    ~ column ID: attributes none -> auto_increment COMMENT 'user''s id'
+    ~ column name: varchar(10) -> varchar(20), NULL -> NOT NULL, default none -> 'none'
+    ~ primary key (id) -> (id, name)
+    - index idx_user_name (name)
+    - index idx_user_age (age)
+    + index idx_user_name (name) UNIQUE
+`
+	if diff := cmp.Diff(wantString, got.String()); diff != "" {
+		t.Errorf("SchemaDiff.String() mismatch (-want +got):\n%s", diff)
+	}
+
+	empty := DiffSchemas(from, from)
+	if !empty.IsEmpty() {
+		t.Error("DiffSchemas() of the same schema is not empty")
+	}
+	if diff := cmp.Diff("-- No differences\n", empty.Comment()); diff != "" {
+		t.Errorf("SchemaDiff.Comment() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,100 @@
+package model
+
+import "testing"
+
+func TestIndexString(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		index *Index
+		want  string
+	}{
+		{
+			name:  "primary key",
+			index: &Index{Name: "PRIMARY", Columns: []string{"id"}, Unique: true, Primary: true},
+			want:  "PRIMARY (id) PRIMARY",
+		},
+		{
+			name:  "unique index",
+			index: &Index{Name: "idx_user_email", Columns: []string{"email"}, Unique: true},
+			want:  "idx_user_email (email) UNIQUE",
+		},
+		{
+			name:  "composite index",
+			index: &Index{Name: "idx_user_name", Columns: []string{"last_name", "first_name"}},
+			want:  "idx_user_name (last_name, first_name)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.index.String(); got != tt.want {
+				t.Errorf("Index.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestForeignKeyString(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		fk   *ForeignKey
+		want string
+	}{
+		{
+			name: "named foreign key",
+			fk:   &ForeignKey{Name: "fk_order_user", Columns: []string{"user_id"}, RefTable: "user", RefColumns: []string{"id"}},
+			want: "fk_order_user: (user_id) -> user(id)",
+		},
+		{
+			name: "unnamed composite foreign key",
+			fk:   &ForeignKey{Columns: []string{"a", "b"}, RefTable: "parent", RefColumns: []string{"x", "y"}},
+			want: "(a, b) -> parent(x, y)",
+		},
+		{
+			name: "referenced table in another schema",
+			fk:   &ForeignKey{Name: "fk_order_user", Columns: []string{"user_id"}, RefTable: "user", RefSchema: "sales", RefColumns: []string{"id"}},
+			want: "fk_order_user: (user_id) -> sales.user(id)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.fk.String(); got != tt.want {
+				t.Errorf("ForeignKey.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTriggerString(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		trigger *Trigger
+		want    string
+	}{
+		{
+			name:    "trigger with multiple events",
+			trigger: &Trigger{Name: "trg_audit", Timing: "AFTER", Events: []string{"INSERT", "UPDATE"}},
+			want:    "trg_audit AFTER INSERT OR UPDATE",
+		},
+		{
+			name:    "trigger without timing",
+			trigger: &Trigger{Name: "trg_audit"},
+			want:    "trg_audit",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.trigger.String(); got != tt.want {
+				t.Errorf("Trigger.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package model
 import (
 	"errors"
 	"strings"
+	"unicode"
 )
 
 // ddl is Data Definition Language List
@@ -34,7 +35,7 @@ func NewSQL(q string) (*SQL, error) {
 
 	return &SQL{
 		query: q,
-		ddl:   []string{"CREATE", "DROP", "ALTER", "REINDEX"},
+		ddl:   []string{"CREATE", "DROP", "ALTER", "REINDEX", "TRUNCATE"},
 		dml:   []string{"SELECT", "INSERT", "UPDATE", "DELETE", "EXPLAIN", "WITH"},
 		tcl:   []string{"BEGIN", "COMMIT", "ROLLBACK", "SAVEPOINT", "RELEASE"},
 		dcl:   []string{"GRANT", "REVOKE"},
@@ -91,6 +92,347 @@ func (sql *SQL) IsWith() bool {
 	return strings.ToUpper(sql.firstWord()) == "WITH"
 }
 
+// IsDrop returns true if the given string represents a DROP statement.
+func (sql *SQL) IsDrop() bool {
+	return strings.ToUpper(sql.firstWord()) == "DROP"
+}
+
+// IsTruncate returns true if the given string represents a TRUNCATE statement.
+func (sql *SQL) IsTruncate() bool {
+	return strings.ToUpper(sql.firstWord()) == "TRUNCATE"
+}
+
+// IsReadOnly returns true if the given string only reads data.
+// SELECT, EXPLAIN, WITH and the schema inspection statements (SHOW, DESCRIBE) are read-only unless
+// they write data inside, e.g. "WITH d AS (DELETE ...) SELECT", "SELECT ... INTO t" and "EXPLAIN ANALYZE
+// DELETE". Other statements, including unknown ones, multiple statements and a statement keyword after
+// the first statement are treated as modifications, because SQL Server and Oracle Database have no
+// read-only transaction to catch them.
+// The string literals are quoted by the rules of the dialect of the DBMS that executes the query.
+func (sql *SQL) IsReadOnly(dialect SQLDialect) bool {
+	switch strings.ToUpper(sql.firstWord()) {
+	case "SELECT", "EXPLAIN", "WITH", "SHOW", "DESCRIBE", "DESC":
+	default:
+		return false
+	}
+	if sql.hasMultipleStatements(dialect) {
+		return false
+	}
+	tokens := significantTokens(TokenizeSQL(sql.query, dialect))
+	for i, token := range tokens {
+		if token.Kind != SQLTokenWord || !modifyingKeywords[strings.ToUpper(token.Text)] {
+			continue
+		}
+		// REPLACE(s, 'a', 'b') is a function and t.set is a column.
+		if (i+1 < len(tokens) && tokens[i+1].Text == "(") || (i > 0 && tokens[i-1].Text == ".") {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+// modifyingKeywords are the keywords of the statements that modify the database or the session, and INTO
+// of "SELECT ... INTO t". They are rejected anywhere in a read-only query, because SQL Server runs the
+// statements of a batch without semicolons, e.g. "SELECT 1 DROP TABLE t".
+var modifyingKeywords = map[string]bool{
+	"ALTER": true, "BACKUP": true, "BEGIN": true, "CALL": true, "COMMIT": true, "COPY": true, "CREATE": true,
+	"DBCC": true, "DECLARE": true, "DELETE": true, "DENY": true, "DROP": true, "EXEC": true, "EXECUTE": true,
+	"GRANT": true, "INSERT": true, "INTO": true, "KILL": true, "LOAD": true, "LOCK": true, "MERGE": true,
+	"RENAME": true, "REPLACE": true, "RESTORE": true, "REVOKE": true, "ROLLBACK": true, "SAVEPOINT": true,
+	"SET": true, "SHUTDOWN": true, "TRUNCATE": true, "UNLOCK": true, "UPDATE": true, "USE": true,
+}
+
+// IsRetryable returns true if the query can be executed again after the connection is lost while it runs.
+// Only read-only queries without side effects are retryable: EXPLAIN ANALYZE is not, because it executes
+// the statement.
+func (sql *SQL) IsRetryable(dialect SQLDialect) bool {
+	return sql.IsReadOnly(dialect) && !contains(sql.tokenWords(dialect), "ANALYZE")
+}
+
+// hasMultipleStatements returns true if the query has a statement after a semicolon.
+// A semicolon at the end of the query, and comments after it, do not make another statement.
+func (sql *SQL) hasMultipleStatements(dialect SQLDialect) bool {
+	terminated := false
+	for _, token := range TokenizeSQL(sql.query, dialect) {
+		switch {
+		case token.Kind == SQLTokenSpace || token.Kind == SQLTokenComment:
+		case token.Text == ";":
+			terminated = true
+		case terminated:
+			return true
+		}
+	}
+	return false
+}
+
+// IsDestructive returns true if the given string may remove a large amount of data at once.
+// UPDATE/DELETE without a WHERE clause, DROP and TRUNCATE are destructive. DROP and TRUNCATE are
+// destructive anywhere outside parentheses, e.g. "ALTER TABLE t DROP COLUMN c" and the SQL Server batch
+// "SELECT 1 DROP TABLE t".
+func (sql *SQL) IsDestructive() bool {
+	top := sql.topLevelWords()
+	if contains(top, "DROP") || contains(top, "TRUNCATE") {
+		return true
+	}
+	if sql.IsUpdate() || sql.IsDelete() {
+		return !contains(top, "WHERE")
+	}
+	return false
+}
+
+// IsImport returns true if the given string is the sqluv IMPORT command
+// that copies a remote table into the local workspace.
+func (sql *SQL) IsImport() bool {
+	return strings.ToUpper(sql.firstWord()) == "IMPORT"
+}
+
+// ImportTable returns the remote table of the IMPORT command.
+// For "IMPORT remote.public.users", it returns "public.users".
+func (sql *SQL) ImportTable() (string, error) {
+	fields := strings.Fields(strings.TrimSuffix(strings.TrimSpace(sql.query), ";"))
+	if len(fields) != 2 || !strings.EqualFold(fields[0], "IMPORT") {
+		return "", errors.New("usage: IMPORT remote.<table>")
+	}
+	prefix, table, found := strings.Cut(fields[1], ".")
+	if !found || !strings.EqualFold(prefix, "remote") || table == "" {
+		return "", errors.New("usage: IMPORT remote.<table>")
+	}
+	return table, nil
+}
+
+// nonAliasKeywords are the keywords that may follow a table in the FROM and JOIN clauses, so they are not its alias.
+var nonAliasKeywords = map[string]bool{
+	"APPLY": true, "CROSS": true, "EXCEPT": true, "FETCH": true, "FOR": true, "FULL": true, "GROUP": true,
+	"HAVING": true, "INNER": true, "INTERSECT": true, "JOIN": true, "LEFT": true, "LIMIT": true, "MINUS": true,
+	"NATURAL": true, "OFFSET": true, "ON": true, "ORDER": true, "OUTER": true, "OUTPUT": true, "RETURNING": true,
+	"RIGHT": true, "SELECT": true, "SET": true, "UNION": true, "USING": true, "VALUES": true, "WHERE": true,
+	"WINDOW": true,
+}
+
+// TableReferences returns the tables in the FROM, JOIN, UPDATE and INTO clauses of the query.
+// Words in other places, e.g. columns, aliases and string literals, are not tables.
+// The string literals are quoted by the rules of the dialect.
+func (sql *SQL) TableReferences(dialect SQLDialect) []TableReference {
+	return tableReferences(significantTokens(TokenizeSQL(sql.query, dialect)), nonAliasKeywords)
+}
+
+// Words returns the upper-cased words of the query that are not inside
+// string literals, quoted identifiers or comments.
+func (sql *SQL) Words() []string {
+	return sql.words(false)
+}
+
+// tokenWords returns the upper-cased words of the query that are not inside string literals,
+// quoted identifiers or comments, which are quoted by the rules of the dialect.
+func (sql *SQL) tokenWords(dialect SQLDialect) []string {
+	words := []string{}
+	for _, token := range TokenizeSQL(sql.query, dialect) {
+		if token.Kind == SQLTokenWord {
+			words = append(words, strings.ToUpper(token.Text))
+		}
+	}
+	return words
+}
+
+// topLevelWords returns the upper-cased words of the query that are not inside
+// parentheses, string literals, quoted identifiers or comments.
+func (sql *SQL) topLevelWords() []string {
+	return sql.words(true)
+}
+
+// sqlWord is an upper-cased word of the query and its position [start, end) in the query runes.
+type sqlWord struct {
+	text  string
+	start int
+	end   int
+}
+
+// words returns the upper-cased words of the query that are not inside string literals,
+// quoted identifiers or comments. If topLevel is true, words inside parentheses are skipped.
+func (sql *SQL) words(topLevel bool) []string {
+	words := []string{}
+	for _, w := range sql.wordPositions(topLevel) {
+		words = append(words, w.text)
+	}
+	return words
+}
+
+// wordPositions returns the words of the query with their positions. If topLevel is true,
+// words inside parentheses are skipped.
+func (sql *SQL) wordPositions(topLevel bool) []sqlWord {
+	words := []sqlWord{}
+	q := []rune(sql.query)
+	depth := 0
+	for i := 0; i < len(q); i++ {
+		switch c := q[i]; {
+		case c == '\'' || c == '"' || c == '`':
+			// Skip quoted text. A doubled quote is an escaped quote.
+			for i++; i < len(q); i++ {
+				if q[i] == c {
+					if i+1 < len(q) && q[i+1] == c {
+						i++
+						continue
+					}
+					break
+				}
+			}
+		case c == '-' && i+1 < len(q) && q[i+1] == '-':
+			for i < len(q) && q[i] != '\n' {
+				i++
+			}
+		case c == '/' && i+1 < len(q) && q[i+1] == '*':
+			i += 2
+			for i+1 < len(q) && (q[i] != '*' || q[i+1] != '/') {
+				i++
+			}
+			i++
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case isWordRune(c):
+			start := i
+			for i+1 < len(q) && isWordRune(q[i+1]) {
+				i++
+			}
+			if !topLevel || depth == 0 {
+				words = append(words, sqlWord{text: strings.ToUpper(string(q[start : i+1])), start: start, end: i + 1})
+			}
+		}
+	}
+	return words
+}
+
+// SourceTable returns the table that the rows of the SELECT statement are read from, if the statement
+// reads one table: "SELECT ... FROM table [[AS] alias] [WHERE ...] [ORDER BY ...] [LIMIT ...]".
+// ref is the table as written in the query (e.g. `public."User"`), and name is the table name without
+// the schema and the quotes (e.g. `User`). ok is false for joins, subqueries in FROM, set operations,
+// aggregations and DISTINCT, because their rows are not the rows of a table.
+func (sql *SQL) SourceTable() (ref, name string, ok bool) {
+	if !sql.IsSelect() {
+		return "", "", false
+	}
+	top := sql.wordPositions(true)
+	from := -1
+	for i, w := range top {
+		switch w.text {
+		case "JOIN", "UNION", "INTERSECT", "EXCEPT", "GROUP", "HAVING", "DISTINCT", "INTO", "APPLY":
+			return "", "", false
+		case "FROM":
+			if from >= 0 {
+				return "", "", false
+			}
+			from = i
+		}
+	}
+	if from < 0 {
+		return "", "", false
+	}
+
+	q := []rune(sql.query)
+	pos := top[from].end
+	for pos < len(q) && unicode.IsSpace(q[pos]) {
+		pos++
+	}
+	refStart := pos
+	for {
+		part, next, ok := identifierAt(q, pos)
+		if !ok {
+			return "", "", false
+		}
+		name, pos = part, next
+		if pos >= len(q) || q[pos] != '.' {
+			break
+		}
+		pos++
+	}
+	ref = string(q[refStart:pos])
+
+	// The table may be followed by an alias, and then by the clauses that only filter and sort the rows.
+	rest := []sqlWord{}
+	for _, w := range top[from+1:] {
+		if w.start >= pos {
+			rest = append(rest, w)
+		}
+	}
+	if len(rest) > 0 && rest[0].text == "AS" {
+		rest = rest[1:]
+	}
+	if len(rest) > 0 && !isRowFilterClause(rest[0].text) {
+		rest = rest[1:]
+	}
+	clauseStart := len(q)
+	if len(rest) > 0 {
+		if !isRowFilterClause(rest[0].text) {
+			return "", "", false
+		}
+		clauseStart = rest[0].start
+	}
+	if strings.ContainsAny(string(q[pos:clauseStart]), ",(") {
+		return "", "", false
+	}
+	return ref, name, true
+}
+
+// isRowFilterClause returns true if the word starts a clause that follows FROM and does not
+// change the rows into other values.
+func isRowFilterClause(word string) bool {
+	switch word {
+	case "WHERE", "ORDER", "LIMIT", "OFFSET", "FETCH", "FOR":
+		return true
+	default:
+		return false
+	}
+}
+
+// identifierAt reads an identifier at pos, which may be quoted with double quotes, backquotes or brackets.
+// It returns the identifier without the quotes and the position after it.
+func identifierAt(q []rune, pos int) (string, int, bool) {
+	if pos >= len(q) {
+		return "", pos, false
+	}
+	switch open := q[pos]; open {
+	case '"', '`', '[':
+		closing := open
+		if open == '[' {
+			closing = ']'
+		}
+		var b strings.Builder
+		for i := pos + 1; i < len(q); i++ {
+			if q[i] != closing {
+				b.WriteRune(q[i])
+				continue
+			}
+			// A doubled closing quote is an escaped quote.
+			if i+1 < len(q) && q[i+1] == closing {
+				b.WriteRune(closing)
+				i++
+				continue
+			}
+			if b.Len() == 0 {
+				return "", pos, false
+			}
+			return b.String(), i + 1, true
+		}
+		return "", pos, false
+	default:
+		end := pos
+		for end < len(q) && isWordRune(q[end]) {
+			end++
+		}
+		if end == pos {
+			return "", pos, false
+		}
+		return string(q[pos:end]), end, true
+	}
+}
+
+// isWordRune returns true if r can be a part of a SQL keyword or identifier.
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
 // firstWord returns the first word of the given string.
 func (sql *SQL) firstWord() string {
 	return strings.Split(trimWordGaps(sql.query), " ")[0]
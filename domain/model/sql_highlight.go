@@ -0,0 +1,113 @@
+package model
+
+import "strings"
+
+// SQLHighlightRole is the role of a part of a SQL query in the syntax highlighting.
+type SQLHighlightRole int
+
+const (
+	// SQLHighlightNone is white space and punctuation, which are not highlighted.
+	SQLHighlightNone SQLHighlightRole = iota
+	// SQLHighlightKeyword is a keyword of the SQL dialect.
+	SQLHighlightKeyword
+	// SQLHighlightIdentifier is a table, a column, a function or an alias, quoted or not.
+	SQLHighlightIdentifier
+	// SQLHighlightString is a string literal.
+	SQLHighlightString
+	// SQLHighlightNumber is a numeric literal.
+	SQLHighlightNumber
+	// SQLHighlightComment is a comment.
+	SQLHighlightComment
+	// SQLHighlightParameter is a bind parameter.
+	SQLHighlightParameter
+	// SQLHighlightError marks an unclosed quote or comment and an unbalanced parenthesis.
+	SQLHighlightError
+)
+
+// SQLHighlight is a part of a SQL query with the same role. Start and End are the byte offsets
+// [Start, End) in the query.
+type SQLHighlight struct {
+	Role  SQLHighlightRole
+	Start int
+	End   int
+}
+
+// HighlightSQL returns the parts of the query to highlight in order. keywords are the upper-cased keywords
+// of the SQL dialect; the other words are identifiers. The opening quote of an unclosed string literal
+// or quoted identifier, the "/*" of an unclosed block comment, and a parenthesis without its pair are
+// errors, so that the typo is marked where it is. The string literals are quoted by the rules of the dialect.
+func HighlightSQL(query string, dialect SQLDialect, keywords []string) []SQLHighlight {
+	reserved := make(map[string]bool, len(keywords))
+	for _, keyword := range keywords {
+		reserved[keyword] = true
+	}
+
+	highlights := []SQLHighlight{}
+	parens := []int{} // The indexes of the highlights of the unclosed "(".
+	for _, token := range TokenizeSQL(query, dialect) {
+		role := SQLHighlightNone
+		switch token.Kind {
+		case SQLTokenWord:
+			role = SQLHighlightIdentifier
+			if reserved[strings.ToUpper(token.Text)] {
+				role = SQLHighlightKeyword
+			}
+		case SQLTokenQuotedIdentifier:
+			role = SQLHighlightIdentifier
+		case SQLTokenString:
+			role = SQLHighlightString
+		case SQLTokenNumber:
+			role = SQLHighlightNumber
+		case SQLTokenComment:
+			role = SQLHighlightComment
+		case SQLTokenParameter:
+			role = SQLHighlightParameter
+		case SQLTokenPunctuation:
+			switch token.Text {
+			case "(":
+				parens = append(parens, len(highlights))
+			case ")":
+				if len(parens) == 0 {
+					role = SQLHighlightError
+				} else {
+					parens = parens[:len(parens)-1]
+				}
+			}
+		}
+
+		if token.Unterminated {
+			opening := len(`'`)
+			switch {
+			case token.Kind == SQLTokenComment:
+				opening = len("/*")
+			case token.Kind == SQLTokenString && token.Text[0] == '$':
+				opening = len(dollarTag(token.Text))
+			case token.Kind == SQLTokenString && token.Text[0] != '\'':
+				opening = len(`E'`)
+			}
+			highlights = append(highlights, SQLHighlight{Role: SQLHighlightError, Start: token.Start, End: token.Start + opening})
+			if token.Start+opening < token.End {
+				highlights = append(highlights, SQLHighlight{Role: role, Start: token.Start + opening, End: token.End})
+			}
+			continue
+		}
+		highlights = append(highlights, SQLHighlight{Role: role, Start: token.Start, End: token.End})
+	}
+	for _, i := range parens {
+		highlights[i].Role = SQLHighlightError
+	}
+	return mergeHighlights(highlights)
+}
+
+// mergeHighlights joins the adjacent highlights of the same role, e.g. a space and a comma.
+func mergeHighlights(highlights []SQLHighlight) []SQLHighlight {
+	merged := make([]SQLHighlight, 0, len(highlights))
+	for _, h := range highlights {
+		if n := len(merged); n > 0 && merged[n-1].Role == h.Role && merged[n-1].End == h.Start && h.Role != SQLHighlightError {
+			merged[n-1].End = h.End
+			continue
+		}
+		merged = append(merged, h)
+	}
+	return merged
+}
@@ -0,0 +1,145 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestHighlightSQL(t *testing.T) {
+	t.Parallel()
+
+	keywords := []string{"FROM", "SELECT", "WHERE"}
+	tests := []struct {
+		name    string
+		query   string
+		dialect SQLDialect
+		want    []SQLHighlight
+	}{
+		{
+			name:  "roles of the tokens",
+			query: `select "Name", 'x' FROM t WHERE id = 1 -- c` + "\n" + "AND v = $1",
+			want: []SQLHighlight{
+				{Role: SQLHighlightKeyword, Start: 0, End: 6},
+				{Role: SQLHighlightNone, Start: 6, End: 7},
+				{Role: SQLHighlightIdentifier, Start: 7, End: 13},
+				{Role: SQLHighlightNone, Start: 13, End: 15},
+				{Role: SQLHighlightString, Start: 15, End: 18},
+				{Role: SQLHighlightNone, Start: 18, End: 19},
+				{Role: SQLHighlightKeyword, Start: 19, End: 23},
+				{Role: SQLHighlightNone, Start: 23, End: 24},
+				{Role: SQLHighlightIdentifier, Start: 24, End: 25},
+				{Role: SQLHighlightNone, Start: 25, End: 26},
+				{Role: SQLHighlightKeyword, Start: 26, End: 31},
+				{Role: SQLHighlightNone, Start: 31, End: 32},
+				{Role: SQLHighlightIdentifier, Start: 32, End: 34},
+				{Role: SQLHighlightNone, Start: 34, End: 37},
+				{Role: SQLHighlightNumber, Start: 37, End: 38},
+				{Role: SQLHighlightNone, Start: 38, End: 39},
+				{Role: SQLHighlightComment, Start: 39, End: 43},
+				{Role: SQLHighlightNone, Start: 43, End: 44},
+				{Role: SQLHighlightIdentifier, Start: 44, End: 47},
+				{Role: SQLHighlightNone, Start: 47, End: 48},
+				{Role: SQLHighlightIdentifier, Start: 48, End: 49},
+				{Role: SQLHighlightNone, Start: 49, End: 52},
+				{Role: SQLHighlightParameter, Start: 52, End: 54},
+			},
+		},
+		{
+			name:  "unclosed string literal",
+			query: "WHERE a = 'x",
+			want: []SQLHighlight{
+				{Role: SQLHighlightKeyword, Start: 0, End: 5},
+				{Role: SQLHighlightNone, Start: 5, End: 6},
+				{Role: SQLHighlightIdentifier, Start: 6, End: 7},
+				{Role: SQLHighlightNone, Start: 7, End: 10},
+				{Role: SQLHighlightError, Start: 10, End: 11},
+				{Role: SQLHighlightString, Start: 11, End: 12},
+			},
+		},
+		{
+			name:  "unclosed quoted identifier and block comment",
+			query: `/* c` + "\n" + `[a`,
+			want: []SQLHighlight{
+				{Role: SQLHighlightError, Start: 0, End: 2},
+				{Role: SQLHighlightComment, Start: 2, End: 7},
+			},
+		},
+		{
+			name:  "quote alone",
+			query: `"`,
+			want: []SQLHighlight{
+				{Role: SQLHighlightError, Start: 0, End: 1},
+			},
+		},
+		{
+			name:  "unbalanced parentheses",
+			query: "(a)) + ((b)",
+			want: []SQLHighlight{
+				{Role: SQLHighlightNone, Start: 0, End: 1},
+				{Role: SQLHighlightIdentifier, Start: 1, End: 2},
+				{Role: SQLHighlightNone, Start: 2, End: 3},
+				{Role: SQLHighlightError, Start: 3, End: 4},
+				{Role: SQLHighlightNone, Start: 4, End: 7},
+				{Role: SQLHighlightError, Start: 7, End: 8},
+				{Role: SQLHighlightNone, Start: 8, End: 9},
+				{Role: SQLHighlightIdentifier, Start: 9, End: 10},
+				{Role: SQLHighlightNone, Start: 10, End: 11},
+			},
+		},
+		{
+			name:  "parentheses in a string and a comment are ignored",
+			query: "'(' -- )",
+			want: []SQLHighlight{
+				{Role: SQLHighlightString, Start: 0, End: 3},
+				{Role: SQLHighlightNone, Start: 3, End: 4},
+				{Role: SQLHighlightComment, Start: 4, End: 8},
+			},
+		},
+		{
+			name:  "empty query",
+			query: "",
+			want:  []SQLHighlight{},
+		},
+		{
+			name:    "MySQL backslash escape is not an error",
+			query:   `'It\'s'`,
+			dialect: SQLDialectMySQL,
+			want:    []SQLHighlight{{Role: SQLHighlightString, Start: 0, End: 7}},
+		},
+		{
+			name:    "PostgreSQL dollar quote is not an error",
+			query:   "$$It's$$",
+			dialect: SQLDialectPostgreSQL,
+			want:    []SQLHighlight{{Role: SQLHighlightString, Start: 0, End: 8}},
+		},
+		{
+			name:    "opening tag of an unclosed dollar quote",
+			query:   "$fn$ x",
+			dialect: SQLDialectPostgreSQL,
+			want: []SQLHighlight{
+				{Role: SQLHighlightError, Start: 0, End: 4},
+				{Role: SQLHighlightString, Start: 4, End: 6},
+			},
+		},
+		{
+			name:    "opening quote of an unclosed escape string",
+			query:   `E'x\'`,
+			dialect: SQLDialectPostgreSQL,
+			want: []SQLHighlight{
+				{Role: SQLHighlightError, Start: 0, End: 2},
+				{Role: SQLHighlightString, Start: 2, End: 5},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := HighlightSQL(tt.query, tt.dialect, keywords)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("HighlightSQL() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -2,6 +2,8 @@ package model
 
 import (
 	"testing"
+
+	"github.com/google/go-cmp/cmp"
 )
 
 func TestContains(t *testing.T) {
@@ -552,3 +554,297 @@ func TestSQLIsWith(t *testing.T) {
 		})
 	}
 }
+
+func TestSQLIsReadOnly(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		query   string
+		dialect SQLDialect
+		want    bool
+	}{
+		{name: "SELECT is read-only", query: "SELECT * FROM test", want: true},
+		{name: "EXPLAIN is read-only", query: "EXPLAIN SELECT * FROM test", want: true},
+		{name: "WITH is read-only", query: "with cte AS (SELECT 1) SELECT * FROM cte", want: true},
+		{name: "SHOW is read-only", query: "SHOW TABLES", want: true},
+		{name: "DESCRIBE is read-only", query: "DESCRIBE test", want: true},
+		{name: "INSERT is not read-only", query: "INSERT INTO test VALUES (1)", want: false},
+		{name: "UPDATE is not read-only", query: "UPDATE test SET id = 1", want: false},
+		{name: "CREATE is not read-only", query: "CREATE TABLE test (id INT)", want: false},
+		{name: "GRANT is not read-only", query: "GRANT SELECT ON test TO user", want: false},
+		{name: "unknown statement is not read-only", query: "CALL procedure()", want: false},
+		{name: "SELECT with a trailing semicolon is read-only", query: "SELECT 1; -- done", want: true},
+		{name: "keyword in a string is read-only", query: "SELECT * FROM test WHERE name = 'delete'", want: true},
+		{name: "WITH that deletes is not read-only", query: "WITH c AS (SELECT 1) DELETE FROM test", want: false},
+		{name: "WITH that modifies data is not read-only", query: "WITH d AS (DELETE FROM test RETURNING *) SELECT * FROM d", want: false},
+		{name: "SELECT INTO is not read-only", query: "SELECT * INTO backup FROM test", want: false},
+		{name: "EXPLAIN ANALYZE DELETE is not read-only", query: "EXPLAIN ANALYZE DELETE FROM test", want: false},
+		{name: "multiple statements are not read-only", query: "SELECT 1; DROP TABLE test", want: false},
+		{name: "EXEC is not read-only", query: "SELECT 1 EXEC sp_drop", want: false},
+		{name: "DROP in a batch is not read-only", query: "SELECT 1 DROP TABLE t", want: false},
+		{name: "TRUNCATE in a batch is not read-only", query: "SELECT 1 TRUNCATE TABLE t", want: false},
+		{name: "ALTER in a batch is not read-only", query: "SELECT 1 ALTER TABLE t ADD c INT", want: false},
+		{name: "CREATE in a batch is not read-only", query: "SELECT 1 CREATE TABLE x (id int)", want: false},
+		{name: "GRANT in a batch is not read-only", query: "SELECT 1 GRANT SELECT ON t TO u", want: false},
+		{name: "DENY in a batch is not read-only", query: "SELECT 1 DENY SELECT ON t TO u", want: false},
+		{name: "SET in a batch is not read-only", query: "SELECT 1 SET IMPLICIT_TRANSACTIONS ON", want: false},
+		{name: "USE in a batch is not read-only", query: "SELECT 1 USE master", want: false},
+		{name: "DECLARE in a batch is not read-only", query: "SELECT 1 DECLARE @x INT", want: false},
+		{name: "COMMIT in a batch is not read-only", query: "SELECT 1 COMMIT", want: false},
+		{name: "DROP after WITH in a batch is not read-only", query: "WITH x AS (SELECT 1 a) SELECT * FROM x DROP TABLE t", want: false},
+		{name: "REPLACE function is read-only", query: "SELECT REPLACE(name, 'a', 'b') FROM test", want: true},
+		{name: "column named like a keyword is read-only", query: "SELECT t.set FROM test t", want: true},
+		{
+			name:    "MySQL statement after a backslash-escaped quote is not read-only",
+			query:   `SELECT 'a\''; DELETE FROM test; -- '`,
+			dialect: SQLDialectMySQL,
+			want:    false,
+		},
+		{
+			name:    "keyword in a PostgreSQL dollar quote is read-only",
+			query:   "SELECT $$'; DELETE FROM test$$",
+			dialect: SQLDialectPostgreSQL,
+			want:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			sql, err := NewSQL(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := sql.IsReadOnly(tt.dialect); got != tt.want {
+				t.Errorf("SQL.IsReadOnly() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSQLIsRetryable(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		query string
+		want  bool
+	}{
+		{name: "SELECT is retryable", query: "SELECT * FROM test WHERE name = 'update'", want: true},
+		{name: "SHOW is retryable", query: "SHOW TABLES", want: true},
+		{name: "EXPLAIN is retryable", query: "EXPLAIN SELECT * FROM test", want: true},
+		{name: "EXPLAIN ANALYZE is not retryable", query: "EXPLAIN ANALYZE DELETE FROM test", want: false},
+		{name: "WITH that modifies data is not retryable", query: "WITH d AS (DELETE FROM test RETURNING *) SELECT * FROM d", want: false},
+		{name: "SELECT INTO is not retryable", query: "SELECT * INTO backup FROM test", want: false},
+		{name: "SELECT FOR UPDATE is not retryable", query: "SELECT * FROM test FOR UPDATE", want: false},
+		{name: "INSERT is not retryable", query: "INSERT INTO test VALUES (1)", want: false},
+		{name: "DROP in a batch is not retryable", query: "SELECT 1 DROP TABLE test", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			sql, err := NewSQL(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := sql.IsRetryable(SQLDialectStandard); got != tt.want {
+				t.Errorf("SQL.IsRetryable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSQLIsDestructive(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		query string
+		want  bool
+	}{
+		{name: "DELETE without WHERE", query: "DELETE FROM test", want: true},
+		{name: "DELETE with WHERE", query: "DELETE FROM test WHERE id = 1", want: false},
+		{name: "UPDATE without WHERE", query: "UPDATE test SET name = 'a'", want: true},
+		{name: "UPDATE with lower case where", query: "update test set name = 'a' where id = 1", want: false},
+		{name: "WHERE in string literal", query: "UPDATE test SET name = 'WHERE'", want: true},
+		{name: "WHERE in escaped string literal", query: "UPDATE test SET name = 'it''s WHERE'", want: true},
+		{name: "WHERE in quoted identifier", query: "DELETE FROM \"where\"", want: true},
+		{name: "WHERE in line comment", query: "DELETE FROM test -- WHERE id = 1", want: true},
+		{name: "WHERE in block comment", query: "DELETE FROM test /* WHERE id = 1 */", want: true},
+		{name: "WHERE only in subquery", query: "UPDATE test SET id = (SELECT max(id) FROM t2 WHERE id > 1)", want: true},
+		{name: "WHERE after subquery", query: "UPDATE test SET id = (SELECT 1) WHERE id = 2", want: false},
+		{name: "DROP", query: "DROP TABLE test", want: true},
+		{name: "TRUNCATE", query: "TRUNCATE TABLE test", want: true},
+		{name: "SELECT", query: "SELECT * FROM test", want: false},
+		{name: "INSERT", query: "INSERT INTO test VALUES (1)", want: false},
+		{name: "DROP after SELECT in a batch", query: "SELECT 1 DROP TABLE test", want: true},
+		{name: "TRUNCATE after SELECT in a batch", query: "SELECT 1 TRUNCATE TABLE test", want: true},
+		{name: "ALTER TABLE DROP COLUMN", query: "ALTER TABLE test DROP COLUMN name", want: true},
+		{name: "ALTER TABLE ADD COLUMN", query: "ALTER TABLE test ADD name TEXT", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			sql, err := NewSQL(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := sql.IsDestructive(); got != tt.want {
+				t.Errorf("SQL.IsDestructive() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSQLImportTable(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		query   string
+		want    string
+		wantErr bool
+	}{
+		{name: "table", query: "IMPORT remote.users", want: "users"},
+		{name: "schema qualified table with semicolon", query: "import REMOTE.public.users;", want: "public.users"},
+		{name: "no remote prefix", query: "IMPORT users", wantErr: true},
+		{name: "other prefix", query: "IMPORT local.users", wantErr: true},
+		{name: "no table", query: "IMPORT remote.", wantErr: true},
+		{name: "extra words", query: "IMPORT remote.users AS u", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			sql, err := NewSQL(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !sql.IsImport() {
+				t.Fatal("SQL.IsImport() = false, want true")
+			}
+			got, err := sql.ImportTable()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SQL.ImportTable() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("SQL.ImportTable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSQLWords(t *testing.T) {
+	t.Parallel()
+
+	sql, err := NewSQL("SELECT c.id FROM customers c JOIN (SELECT id FROM orders) o ON o.id = c.id WHERE c.name = 'users' -- accounts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"SELECT", "C", "ID", "FROM", "CUSTOMERS", "C", "JOIN", "SELECT", "ID", "FROM", "ORDERS", "O", "ON", "O", "ID", "C", "ID", "WHERE", "C", "NAME"}
+	if diff := cmp.Diff(want, sql.Words()); diff != "" {
+		t.Errorf("SQL.Words() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestSQLSourceTable(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		query    string
+		wantRef  string
+		wantName string
+		wantOK   bool
+	}{
+		{name: "select all", query: "SELECT * FROM users", wantRef: "users", wantName: "users", wantOK: true},
+		{name: "with where, order and limit", query: "select id, name from users where id > 1 order by id, name limit 10;", wantRef: "users", wantName: "users", wantOK: true},
+		{name: "schema and quoted name", query: `SELECT * FROM public."User Account" WHERE id = 1`, wantRef: `public."User Account"`, wantName: "User Account", wantOK: true},
+		{name: "brackets", query: "SELECT * FROM [dbo].[users]", wantRef: "[dbo].[users]", wantName: "users", wantOK: true},
+		{name: "backquotes", query: "SELECT * FROM `my``table`", wantRef: "`my``table`", wantName: "my`table", wantOK: true},
+		{name: "alias", query: "SELECT u.id FROM users u WHERE u.id = 1", wantRef: "users", wantName: "users", wantOK: true},
+		{name: "alias with AS", query: "SELECT u.id FROM users AS u", wantRef: "users", wantName: "users", wantOK: true},
+		{name: "subquery in WHERE", query: "SELECT * FROM users WHERE id IN (SELECT user_id FROM orders)", wantRef: "users", wantName: "users", wantOK: true},
+		{name: "join", query: "SELECT * FROM users u JOIN orders o ON o.user_id = u.id", wantOK: false},
+		{name: "comma join", query: "SELECT * FROM users, orders", wantOK: false},
+		{name: "comma join with alias", query: "SELECT * FROM users u, orders o", wantOK: false},
+		{name: "subquery in FROM", query: "SELECT * FROM (SELECT * FROM users) u", wantOK: false},
+		{name: "group by", query: "SELECT name, count(*) FROM users GROUP BY name", wantOK: false},
+		{name: "distinct", query: "SELECT DISTINCT name FROM users", wantOK: false},
+		{name: "union", query: "SELECT id FROM users UNION SELECT id FROM admins", wantOK: false},
+		{name: "without FROM", query: "SELECT 1", wantOK: false},
+		{name: "not SELECT", query: "DELETE FROM users", wantOK: false},
+		{name: "table function", query: "SELECT * FROM generate_series(1, 10)", wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			sql, err := NewSQL(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ref, name, ok := sql.SourceTable()
+			if ok != tt.wantOK {
+				t.Fatalf("SQL.SourceTable() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ref != tt.wantRef || name != tt.wantName {
+				t.Errorf("SQL.SourceTable() = (%q, %q), want (%q, %q)", ref, name, tt.wantRef, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestSQLTableReferences(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		query string
+		want  []TableReference
+	}{
+		{
+			name:  "tables in FROM and JOIN with aliases",
+			query: `SELECT o.orders, u.name FROM users u JOIN sales."Orders" AS o ON o.user_id = u.id WHERE o.id IN (SELECT id FROM items)`,
+			want: []TableReference{
+				{Name: "users", Alias: "u"},
+				{Schema: "sales", Name: "Orders", Alias: "o"},
+				{Name: "items"},
+			},
+		},
+		{
+			name:  "comma separated tables",
+			query: "SELECT * FROM a, b x, c WHERE a.id = b.id",
+			want: []TableReference{
+				{Name: "a"},
+				{Name: "b", Alias: "x"},
+				{Name: "c"},
+			},
+		},
+		{
+			name:  "columns, aliases and strings are not tables",
+			query: "SELECT count(*) AS orders FROM users WHERE name = 'FROM orders'",
+			want: []TableReference{
+				{Name: "users"},
+			},
+		},
+		{
+			name:  "INSERT INTO and a table qualified with the database",
+			query: "INSERT INTO logs SELECT * FROM db.public.events",
+			want: []TableReference{
+				{Name: "logs"},
+				{Schema: "public", Name: "events"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			sql, err := NewSQL(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, sql.TableReferences(SQLDialectStandard)); diff != "" {
+				t.Errorf("SQL.TableReferences() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,218 @@
+package model
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// SQLTokenKind is the kind of a token of a SQL query.
+type SQLTokenKind int
+
+const (
+	// SQLTokenSpace is white space.
+	SQLTokenSpace SQLTokenKind = iota
+	// SQLTokenWord is a keyword or an unquoted identifier.
+	SQLTokenWord
+	// SQLTokenQuotedIdentifier is an identifier quoted with double quotes, backquotes or brackets.
+	SQLTokenQuotedIdentifier
+	// SQLTokenString is a string literal.
+	SQLTokenString
+	// SQLTokenNumber is a numeric literal.
+	SQLTokenNumber
+	// SQLTokenComment is a line comment (--) or a block comment (/* */).
+	SQLTokenComment
+	// SQLTokenParameter is a bind parameter, e.g. ?, $1, :name and @p1.
+	SQLTokenParameter
+	// SQLTokenPunctuation is an operator or a punctuation, e.g. "(", "," and "=".
+	SQLTokenPunctuation
+)
+
+// SQLDialect is the lexical rules of string literals that differ between the DBMS.
+type SQLDialect int
+
+const (
+	// SQLDialectStandard quotes a string with single quotes and escapes a quote by doubling it.
+	SQLDialectStandard SQLDialect = iota
+	// SQLDialectMySQL also escapes a character in a quoted string with a backslash, e.g. 'It\'s'.
+	SQLDialectMySQL
+	// SQLDialectPostgreSQL also quotes a string with dollar quotes, e.g. $$It's$$ and $body$...$body$,
+	// and escapes a character with a backslash in an escape string, e.g. E'It\'s'.
+	SQLDialectPostgreSQL
+)
+
+// SQLToken is a token of a SQL query. Start and End are the byte offsets [Start, End) in the query.
+type SQLToken struct {
+	Kind  SQLTokenKind
+	Text  string
+	Start int
+	End   int
+	// Unterminated is true for a string literal, a quoted identifier or a block comment that is not closed.
+	Unterminated bool
+}
+
+// Identifier returns the identifier of a word or a quoted identifier without the quotes.
+func (t SQLToken) Identifier() string {
+	if t.Kind != SQLTokenQuotedIdentifier {
+		return t.Text
+	}
+	closing := t.Text[:1]
+	if closing == "[" {
+		closing = "]"
+	}
+	inner := t.Text[1:]
+	if !t.Unterminated {
+		inner = inner[:len(inner)-1]
+	}
+	return strings.ReplaceAll(inner, closing+closing, closing)
+}
+
+// TokenizeSQL splits the query into tokens. The query may be incomplete, e.g. while it is typed,
+// so every byte of the query belongs to a token and an unclosed quote ends at the end of the query.
+// The string literals are quoted and escaped by the rules of the dialect.
+func TokenizeSQL(query string, dialect SQLDialect) []SQLToken {
+	tokens := []SQLToken{}
+	for pos := 0; pos < len(query); {
+		r, size := utf8.DecodeRuneInString(query[pos:])
+		next := func(i int) rune {
+			if i >= len(query) {
+				return 0
+			}
+			r, _ := utf8.DecodeRuneInString(query[i:])
+			return r
+		}
+
+		token := SQLToken{Start: pos}
+		end := pos + size
+		switch {
+		case unicode.IsSpace(r):
+			token.Kind = SQLTokenSpace
+			for end < len(query) && unicode.IsSpace(next(end)) {
+				_, s := utf8.DecodeRuneInString(query[end:])
+				end += s
+			}
+		case r == '-' && next(end) == '-':
+			token.Kind = SQLTokenComment
+			end = len(query)
+			if i := strings.IndexByte(query[pos:], '\n'); i >= 0 {
+				end = pos + i
+			}
+		case r == '/' && next(end) == '*':
+			token.Kind = SQLTokenComment
+			if i := strings.Index(query[pos+2:], "*/"); i >= 0 {
+				end = pos + 2 + i + 2
+			} else {
+				end, token.Unterminated = len(query), true
+			}
+		case r == '\'' || r == '"' || r == '`' || r == '[':
+			token.Kind = SQLTokenQuotedIdentifier
+			if r == '\'' {
+				token.Kind = SQLTokenString
+			}
+			// MySQL quotes a string with double quotes too unless ANSI_QUOTES is set.
+			backslash := dialect == SQLDialectMySQL && (r == '\'' || r == '"')
+			end, token.Unterminated = quotedEnd(query, pos, backslash)
+		case unicode.IsDigit(r) || (r == '.' && unicode.IsDigit(next(end))):
+			token.Kind = SQLTokenNumber
+			end = numberEnd(query, pos)
+		case dialect == SQLDialectPostgreSQL && r == '$' && dollarTag(query[pos:]) != "":
+			token.Kind = SQLTokenString
+			tag := dollarTag(query[pos:])
+			if i := strings.Index(query[pos+len(tag):], tag); i >= 0 {
+				end = pos + len(tag) + i + len(tag)
+			} else {
+				end, token.Unterminated = len(query), true
+			}
+		case dialect == SQLDialectPostgreSQL && (r == 'E' || r == 'e') && next(end) == '\'':
+			token.Kind = SQLTokenString
+			end, token.Unterminated = quotedEnd(query, end, true)
+		case r == '?':
+			token.Kind = SQLTokenParameter
+		case (r == '$' || r == '@') && isWordRune(next(end)),
+			r == '@' && next(end) == '@',
+			r == ':' && isWordRune(next(end)) && (pos == 0 || query[pos-1] != ':'):
+			// $1 (PostgreSQL), @p1 and @@ROWCOUNT (SQL Server) and :name (Oracle Database).
+			// "::" is the cast of PostgreSQL.
+			token.Kind = SQLTokenParameter
+			if next(end) == '@' {
+				end++
+			}
+			for end < len(query) && isWordRune(next(end)) {
+				_, s := utf8.DecodeRuneInString(query[end:])
+				end += s
+			}
+		case isWordRune(r):
+			token.Kind = SQLTokenWord
+			for end < len(query) && (isWordRune(next(end)) || next(end) == '$') {
+				_, s := utf8.DecodeRuneInString(query[end:])
+				end += s
+			}
+		default:
+			token.Kind = SQLTokenPunctuation
+		}
+		token.End = end
+		token.Text = query[pos:end]
+		tokens = append(tokens, token)
+		pos = end
+	}
+	return tokens
+}
+
+// quotedEnd returns the end of the quoted text that starts at pos, and true if the quote is not closed.
+// A doubled closing quote is an escaped quote, and so is a quote after a backslash if backslash is true.
+func quotedEnd(query string, pos int, backslash bool) (int, bool) {
+	closing := query[pos]
+	if closing == '[' {
+		closing = ']'
+	}
+	for i := pos + 1; i < len(query); i++ {
+		if backslash && query[i] == '\\' {
+			i++
+			continue
+		}
+		if query[i] != closing {
+			continue
+		}
+		if i+1 < len(query) && query[i+1] == closing {
+			i++
+			continue
+		}
+		return i + 1, false
+	}
+	return len(query), true
+}
+
+// dollarTag returns the opening tag of the dollar-quoted string at the start of s, e.g. "$$" and "$body$",
+// or "" if s does not start with one. The tag is a word that does not start with a digit, so $1 is a parameter.
+func dollarTag(s string) string {
+	for i, r := range s[1:] {
+		switch {
+		case r == '$':
+			return s[:i+2]
+		case !isWordRune(r) || (i == 0 && unicode.IsDigit(r)):
+			return ""
+		}
+	}
+	return ""
+}
+
+// numberEnd returns the end of the number that starts at pos, e.g. 42, 3.14, .5 and 1e-3.
+func numberEnd(query string, pos int) int {
+	end := pos
+	for end < len(query) && (query[end] >= '0' && query[end] <= '9' || query[end] == '.') {
+		end++
+	}
+	if end < len(query) && (query[end] == 'e' || query[end] == 'E') {
+		exp := end + 1
+		if exp < len(query) && (query[exp] == '+' || query[exp] == '-') {
+			exp++
+		}
+		if exp < len(query) && query[exp] >= '0' && query[exp] <= '9' {
+			end = exp
+			for end < len(query) && query[end] >= '0' && query[end] <= '9' {
+				end++
+			}
+		}
+	}
+	return end
+}
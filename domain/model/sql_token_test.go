@@ -0,0 +1,180 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTokenizeSQL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		query   string
+		dialect SQLDialect
+		want    []SQLToken
+	}{
+		{
+			name:  "select with a string, a number and a parameter",
+			query: "SELECT a, 'it''s' FROM t WHERE x = 1.5e3 AND y = $1",
+			want: []SQLToken{
+				{Kind: SQLTokenWord, Text: "SELECT", Start: 0, End: 6},
+				{Kind: SQLTokenSpace, Text: " ", Start: 6, End: 7},
+				{Kind: SQLTokenWord, Text: "a", Start: 7, End: 8},
+				{Kind: SQLTokenPunctuation, Text: ",", Start: 8, End: 9},
+				{Kind: SQLTokenSpace, Text: " ", Start: 9, End: 10},
+				{Kind: SQLTokenString, Text: "'it''s'", Start: 10, End: 17},
+				{Kind: SQLTokenSpace, Text: " ", Start: 17, End: 18},
+				{Kind: SQLTokenWord, Text: "FROM", Start: 18, End: 22},
+				{Kind: SQLTokenSpace, Text: " ", Start: 22, End: 23},
+				{Kind: SQLTokenWord, Text: "t", Start: 23, End: 24},
+				{Kind: SQLTokenSpace, Text: " ", Start: 24, End: 25},
+				{Kind: SQLTokenWord, Text: "WHERE", Start: 25, End: 30},
+				{Kind: SQLTokenSpace, Text: " ", Start: 30, End: 31},
+				{Kind: SQLTokenWord, Text: "x", Start: 31, End: 32},
+				{Kind: SQLTokenSpace, Text: " ", Start: 32, End: 33},
+				{Kind: SQLTokenPunctuation, Text: "=", Start: 33, End: 34},
+				{Kind: SQLTokenSpace, Text: " ", Start: 34, End: 35},
+				{Kind: SQLTokenNumber, Text: "1.5e3", Start: 35, End: 40},
+				{Kind: SQLTokenSpace, Text: " ", Start: 40, End: 41},
+				{Kind: SQLTokenWord, Text: "AND", Start: 41, End: 44},
+				{Kind: SQLTokenSpace, Text: " ", Start: 44, End: 45},
+				{Kind: SQLTokenWord, Text: "y", Start: 45, End: 46},
+				{Kind: SQLTokenSpace, Text: " ", Start: 46, End: 47},
+				{Kind: SQLTokenPunctuation, Text: "=", Start: 47, End: 48},
+				{Kind: SQLTokenSpace, Text: " ", Start: 48, End: 49},
+				{Kind: SQLTokenParameter, Text: "$1", Start: 49, End: 51},
+			},
+		},
+		{
+			name:  "quoted identifiers and comments",
+			query: "`a`[b]\"c\"\"d\"-- x\n/* y */",
+			want: []SQLToken{
+				{Kind: SQLTokenQuotedIdentifier, Text: "`a`", Start: 0, End: 3},
+				{Kind: SQLTokenQuotedIdentifier, Text: "[b]", Start: 3, End: 6},
+				{Kind: SQLTokenQuotedIdentifier, Text: `"c""d"`, Start: 6, End: 12},
+				{Kind: SQLTokenComment, Text: "-- x", Start: 12, End: 16},
+				{Kind: SQLTokenSpace, Text: "\n", Start: 16, End: 17},
+				{Kind: SQLTokenComment, Text: "/* y */", Start: 17, End: 24},
+			},
+		},
+		{
+			name:  "parameters and a cast",
+			query: "?:name@p1::int",
+			want: []SQLToken{
+				{Kind: SQLTokenParameter, Text: "?", Start: 0, End: 1},
+				{Kind: SQLTokenParameter, Text: ":name", Start: 1, End: 6},
+				{Kind: SQLTokenParameter, Text: "@p1", Start: 6, End: 9},
+				{Kind: SQLTokenPunctuation, Text: ":", Start: 9, End: 10},
+				{Kind: SQLTokenPunctuation, Text: ":", Start: 10, End: 11},
+				{Kind: SQLTokenWord, Text: "int", Start: 11, End: 14},
+			},
+		},
+		{
+			name:  "unterminated string",
+			query: "'abc",
+			want:  []SQLToken{{Kind: SQLTokenString, Text: "'abc", Start: 0, End: 4, Unterminated: true}},
+		},
+		{
+			name:  "unterminated block comment",
+			query: "/* abc",
+			want:  []SQLToken{{Kind: SQLTokenComment, Text: "/* abc", Start: 0, End: 6, Unterminated: true}},
+		},
+		{
+			name:  "multibyte word",
+			query: "名前",
+			want:  []SQLToken{{Kind: SQLTokenWord, Text: "名前", Start: 0, End: 6}},
+		},
+		{
+			name:  "backslash is not an escape in the SQL standard",
+			query: `'a\' b`,
+			want: []SQLToken{
+				{Kind: SQLTokenString, Text: `'a\'`, Start: 0, End: 4},
+				{Kind: SQLTokenSpace, Text: " ", Start: 4, End: 5},
+				{Kind: SQLTokenWord, Text: "b", Start: 5, End: 6},
+			},
+		},
+		{
+			name:    "MySQL backslash escapes",
+			query:   `'It\'s' "a\"b"`,
+			dialect: SQLDialectMySQL,
+			want: []SQLToken{
+				{Kind: SQLTokenString, Text: `'It\'s'`, Start: 0, End: 7},
+				{Kind: SQLTokenSpace, Text: " ", Start: 7, End: 8},
+				{Kind: SQLTokenQuotedIdentifier, Text: `"a\"b"`, Start: 8, End: 14},
+			},
+		},
+		{
+			name:    "MySQL escaped backslash before the closing quote",
+			query:   `'a\\'b`,
+			dialect: SQLDialectMySQL,
+			want: []SQLToken{
+				{Kind: SQLTokenString, Text: `'a\\'`, Start: 0, End: 5},
+				{Kind: SQLTokenWord, Text: "b", Start: 5, End: 6},
+			},
+		},
+		{
+			name:    "PostgreSQL dollar quotes and a parameter",
+			query:   "$$It's$$ $fn$ a $$ b $fn$ $1",
+			dialect: SQLDialectPostgreSQL,
+			want: []SQLToken{
+				{Kind: SQLTokenString, Text: "$$It's$$", Start: 0, End: 8},
+				{Kind: SQLTokenSpace, Text: " ", Start: 8, End: 9},
+				{Kind: SQLTokenString, Text: "$fn$ a $$ b $fn$", Start: 9, End: 25},
+				{Kind: SQLTokenSpace, Text: " ", Start: 25, End: 26},
+				{Kind: SQLTokenParameter, Text: "$1", Start: 26, End: 28},
+			},
+		},
+		{
+			name:    "PostgreSQL escape string",
+			query:   `E'It\'s'`,
+			dialect: SQLDialectPostgreSQL,
+			want:    []SQLToken{{Kind: SQLTokenString, Text: `E'It\'s'`, Start: 0, End: 8}},
+		},
+		{
+			name:    "PostgreSQL unterminated dollar quote",
+			query:   "$body$ abc",
+			dialect: SQLDialectPostgreSQL,
+			want:    []SQLToken{{Kind: SQLTokenString, Text: "$body$ abc", Start: 0, End: 10, Unterminated: true}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tt.want, TokenizeSQL(tt.query, tt.dialect)); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSQLTokenIdentifier(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{name: "word", query: "users", want: "users"},
+		{name: "double quotes", query: `"a""b"`, want: `a"b`},
+		{name: "backquotes", query: "`a``b`", want: "a`b"},
+		{name: "brackets", query: "[a]]b]", want: "a]b"},
+		{name: "unterminated", query: `"ab`, want: "ab"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tokens := TokenizeSQL(tt.query, SQLDialectStandard)
+			if len(tokens) != 1 {
+				t.Fatalf("want 1 token, got %v", tokens)
+			}
+			if got := tokens[0].Identifier(); got != tt.want {
+				t.Errorf("Identifier() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
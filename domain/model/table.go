@@ -12,6 +12,11 @@ type Table struct {
 	header Header
 	// Records is table records.
 	records []Record
+	// schema is the schema (or database) that the table belongs to. It is empty for local files.
+	schema string
+	// nulls are the cells that are NULL in the database, keyed by row and column. Their values in records
+	// are empty strings. It is nil unless SetNull is called.
+	nulls map[[2]int]bool
 }
 
 // NewTable create new Table.
@@ -27,11 +32,43 @@ func NewTable(
 	}
 }
 
+// SetNull marks the cell as NULL, so that it is distinguished from an empty string.
+func (t *Table) SetNull(row, column int) {
+	if t.nulls == nil {
+		t.nulls = map[[2]int]bool{}
+	}
+	t.nulls[[2]int{row, column}] = true
+}
+
+// IsNull returns true if the cell is marked as NULL by SetNull.
+func (t *Table) IsNull(row, column int) bool {
+	return t.nulls[[2]int{row, column}]
+}
+
 // Name return table name.
 func (t *Table) Name() string {
 	return t.name
 }
 
+// Schema return the schema (or database) that the table belongs to.
+func (t *Table) Schema() string {
+	return t.schema
+}
+
+// SetSchema sets the schema (or database) that the table belongs to.
+func (t *Table) SetSchema(schema string) {
+	t.schema = schema
+}
+
+// QualifiedName return table name qualified with the schema, e.g. "public.user".
+// If the table does not belong to a schema, it returns the table name.
+func (t *Table) QualifiedName() string {
+	if t.schema == "" {
+		return t.name
+	}
+	return t.schema + "." + t.name
+}
+
 // Header return table header.
 func (t *Table) Header() Header {
 	return t.header
@@ -44,7 +81,7 @@ func (t *Table) Records() []Record {
 
 // Equal compare Table.
 func (t *Table) Equal(t2 *Table) bool {
-	if t.Name() != t2.Name() {
+	if t.Name() != t2.Name() || t.Schema() != t2.Schema() {
 		return false
 	}
 	if !t.header.Equal(t2.header) {
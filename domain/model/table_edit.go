@@ -0,0 +1,270 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// CellValue is a value entered in the result grid.
+type CellValue struct {
+	// Value is the text of the value. It is ignored if Null is true.
+	Value string
+	// Null is true if the value is NULL.
+	Null bool
+}
+
+// String returns the value, or "NULL".
+func (v CellValue) String() string {
+	if v.Null {
+		return "NULL"
+	}
+	return v.Value
+}
+
+// RowChangeKind is the kind of a row change.
+type RowChangeKind int
+
+const (
+	// RowChangeUpdate updates the columns of a row.
+	RowChangeUpdate RowChangeKind = iota
+	// RowChangeInsert inserts a row.
+	RowChangeInsert
+	// RowChangeDelete deletes a row.
+	RowChangeDelete
+)
+
+// RowChange is a change of a row to be written to a table.
+type RowChange struct {
+	// Kind is the kind of the change.
+	Kind RowChangeKind
+	// Columns and Values are the columns set by UPDATE or inserted by INSERT. They are empty for DELETE.
+	Columns []string
+	Values  []CellValue
+	// KeyColumns and KeyValues are the primary key of the row updated or deleted. They are empty for INSERT.
+	KeyColumns []string
+	KeyValues  []string
+}
+
+// TableEdit holds the edits of the records of a table shown in the result grid until they are
+// written to the table. The rows are identified by the primary key, so the result must have all
+// the primary key columns. A row index is the index of the shown records, and the inserted rows
+// follow the records.
+type TableEdit struct {
+	table      string
+	header     Header
+	primaryKey []int
+	records    []Record
+	updates    map[int]map[int]CellValue
+	deletes    map[int]bool
+	inserts    []map[int]CellValue
+}
+
+// NewTableEdit returns the edit of the result read from the table. tableName is the table as written
+// in the query, and primaryKey is the primary key columns of the table.
+func NewTableEdit(tableName string, result *Table, primaryKey []string) (*TableEdit, error) {
+	if len(primaryKey) == 0 {
+		return nil, fmt.Errorf("table %s has no primary key, so the rows cannot be identified", tableName)
+	}
+	if result.IsSameHeaderColumnName() {
+		return nil, errors.New("the result has duplicate column names")
+	}
+	header := result.Header()
+	keys := make([]int, 0, len(primaryKey))
+	for _, pk := range primaryKey {
+		i := slices.IndexFunc(header, func(c string) bool { return strings.EqualFold(c, pk) })
+		if i < 0 {
+			return nil, fmt.Errorf("the result does not have the primary key column %s", pk)
+		}
+		keys = append(keys, i)
+	}
+	return &TableEdit{
+		table:      tableName,
+		header:     header,
+		primaryKey: keys,
+		records:    result.Records(),
+		updates:    map[int]map[int]CellValue{},
+		deletes:    map[int]bool{},
+	}, nil
+}
+
+// Name returns the table as written in the query.
+func (e *TableEdit) Name() string {
+	return e.table
+}
+
+// Len returns the number of rows, including the inserted rows.
+func (e *TableEdit) Len() int {
+	return len(e.records) + len(e.inserts)
+}
+
+// IsInserted returns true if the row is inserted.
+func (e *TableEdit) IsInserted(row int) bool {
+	return row >= len(e.records) && row < e.Len()
+}
+
+// IsDeleted returns true if the row is marked for deletion.
+func (e *TableEdit) IsDeleted(row int) bool {
+	return e.deletes[row]
+}
+
+// IsChanged returns true if the value of the cell is entered.
+func (e *TableEdit) IsChanged(row, col int) bool {
+	if e.IsInserted(row) {
+		_, ok := e.inserts[row-len(e.records)][col]
+		return ok
+	}
+	_, ok := e.updates[row][col]
+	return ok
+}
+
+// Value returns the current value of the cell. A NULL in the records is an empty string,
+// because the records do not tell NULL from an empty string.
+func (e *TableEdit) Value(row, col int) CellValue {
+	if e.IsInserted(row) {
+		return e.inserts[row-len(e.records)][col]
+	}
+	if v, ok := e.updates[row][col]; ok {
+		return v
+	}
+	if row < 0 || row >= len(e.records) || col < 0 || col >= len(e.records[row]) {
+		return CellValue{}
+	}
+	return CellValue{Value: e.records[row][col]}
+}
+
+// SetCell sets the value of the cell. Setting the original value of a record cancels the update.
+func (e *TableEdit) SetCell(row, col int, v CellValue) error {
+	if row < 0 || row >= e.Len() || col < 0 || col >= len(e.header) {
+		return fmt.Errorf("cell (%d, %d) is out of range", row, col)
+	}
+	if e.IsDeleted(row) {
+		return errors.New("the row is marked for deletion")
+	}
+	if e.IsInserted(row) {
+		e.inserts[row-len(e.records)][col] = v
+		return nil
+	}
+	if !v.Null && col < len(e.records[row]) && v.Value == e.records[row][col] {
+		delete(e.updates[row], col)
+		return nil
+	}
+	if e.updates[row] == nil {
+		e.updates[row] = map[int]CellValue{}
+	}
+	e.updates[row][col] = v
+	return nil
+}
+
+// AddRow adds an empty row to be inserted and returns its row index. The columns whose value is not
+// entered are not written by INSERT, so they get the default values of the table.
+func (e *TableEdit) AddRow() int {
+	e.inserts = append(e.inserts, map[int]CellValue{})
+	return e.Len() - 1
+}
+
+// ToggleDelete marks the row for deletion, or unmarks it. An inserted row is removed instead,
+// and the rows inserted after it move up.
+func (e *TableEdit) ToggleDelete(row int) {
+	switch {
+	case e.IsInserted(row):
+		i := row - len(e.records)
+		e.inserts = append(e.inserts[:i], e.inserts[i+1:]...)
+	case row >= 0 && row < len(e.records):
+		if e.deletes[row] {
+			delete(e.deletes, row)
+			return
+		}
+		e.deletes[row] = true
+	}
+}
+
+// Table returns the rows with the current values. The entered NULL values are shown as "NULL".
+func (e *TableEdit) Table() *Table {
+	records := make([]Record, 0, e.Len())
+	for row := range e.Len() {
+		record := make(Record, len(e.header))
+		for col := range e.header {
+			v := e.Value(row, col)
+			if e.IsChanged(row, col) {
+				record[col] = v.String()
+				continue
+			}
+			record[col] = v.Value
+		}
+		records = append(records, record)
+	}
+	return NewTable(e.table, e.header, records)
+}
+
+// Changes returns the changes to be written to the table, in the order of deletions, updates and
+// insertions, so that a deleted key can be inserted again. An inserted row without values is an error.
+func (e *TableEdit) Changes() ([]*RowChange, error) {
+	changes := []*RowChange{}
+	for row := range e.records {
+		if e.deletes[row] {
+			changes = append(changes, e.keyChange(RowChangeDelete, row))
+		}
+	}
+	for row := range e.records {
+		if e.deletes[row] || len(e.updates[row]) == 0 {
+			continue
+		}
+		c := e.keyChange(RowChangeUpdate, row)
+		c.Columns, c.Values = e.columnValues(e.updates[row])
+		changes = append(changes, c)
+	}
+	for i, values := range e.inserts {
+		if len(values) == 0 {
+			return nil, fmt.Errorf("new row %d has no values", len(e.records)+i+1)
+		}
+		c := &RowChange{Kind: RowChangeInsert}
+		c.Columns, c.Values = e.columnValues(values)
+		changes = append(changes, c)
+	}
+	return changes, nil
+}
+
+// HasChanges returns true if any row is updated, inserted or deleted.
+func (e *TableEdit) HasChanges() bool {
+	for _, u := range e.updates {
+		if len(u) > 0 {
+			return true
+		}
+	}
+	return len(e.deletes) > 0 || len(e.inserts) > 0
+}
+
+// keyChange returns the change of the row identified by the original primary key values.
+func (e *TableEdit) keyChange(kind RowChangeKind, row int) *RowChange {
+	c := &RowChange{Kind: kind}
+	for _, col := range e.primaryKey {
+		c.KeyColumns = append(c.KeyColumns, e.header[col])
+		c.KeyValues = append(c.KeyValues, e.records[row][col])
+	}
+	return c
+}
+
+// columnValues returns the column names and the values in the order of the header.
+func (e *TableEdit) columnValues(values map[int]CellValue) ([]string, []CellValue) {
+	columns := make([]string, 0, len(values))
+	vs := make([]CellValue, 0, len(values))
+	for col, name := range e.header {
+		if v, ok := values[col]; ok {
+			columns = append(columns, name)
+			vs = append(vs, v)
+		}
+	}
+	return columns, vs
+}
+
+// EditStatement is a statement that writes a row change to a table. Query has the bind parameters
+// for Args, and Preview is the statement with the values written as literals for review.
+type EditStatement struct {
+	Query   string
+	Args    []any
+	Preview string
+	// OneRow is true if the statement must change exactly one row, i.e. UPDATE and DELETE by the primary key.
+	OneRow bool
+}
@@ -0,0 +1,139 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNewTableEdit(t *testing.T) {
+	t.Parallel()
+
+	result := NewTable("users", Header{"id", "name"}, []Record{{"1", "alice"}})
+	tests := []struct {
+		name       string
+		result     *Table
+		primaryKey []string
+		wantErr    bool
+	}{
+		{name: "primary key in the result", result: result, primaryKey: []string{"ID"}, wantErr: false},
+		{name: "no primary key", result: result, primaryKey: nil, wantErr: true},
+		{name: "primary key not in the result", result: result, primaryKey: []string{"id", "tenant_id"}, wantErr: true},
+		{
+			name:       "duplicate columns",
+			result:     NewTable("users", Header{"id", "id"}, []Record{{"1", "1"}}),
+			primaryKey: []string{"id"},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := NewTableEdit("users", tt.result, tt.primaryKey)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewTableEdit() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTableEditChanges(t *testing.T) {
+	t.Parallel()
+
+	result := NewTable("users",
+		Header{"name", "id", "note"},
+		[]Record{
+			{"alice", "1", "a"},
+			{"bob", "2", "b"},
+			{"carol", "3", ""},
+		})
+	edit, err := NewTableEdit("public.users", result, []string{"id"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Update bob, and change the primary key of carol.
+	for _, c := range []struct {
+		row, col int
+		v        CellValue
+	}{
+		{row: 1, col: 0, v: CellValue{Value: "robert"}},
+		{row: 1, col: 2, v: CellValue{Null: true}},
+		{row: 2, col: 1, v: CellValue{Value: "30"}},
+		{row: 2, col: 0, v: CellValue{Value: "caroline"}},
+		{row: 2, col: 0, v: CellValue{Value: "carol"}}, // back to the original value
+	} {
+		if err := edit.SetCell(c.row, c.col, c.v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Delete alice, and insert a row.
+	edit.ToggleDelete(0)
+	if err := edit.SetCell(0, 0, CellValue{Value: "x"}); err == nil {
+		t.Error("SetCell() on a deleted row should fail")
+	}
+	row := edit.AddRow()
+	if err := edit.SetCell(row, 0, CellValue{Value: "dave"}); err != nil {
+		t.Fatal(err)
+	}
+	// A new row is removed instead of being marked for deletion.
+	edit.ToggleDelete(edit.AddRow())
+
+	if !edit.HasChanges() {
+		t.Error("TableEdit.HasChanges() = false, want true")
+	}
+	if !edit.IsChanged(1, 2) || edit.IsChanged(2, 0) || !edit.IsInserted(3) || !edit.IsDeleted(0) {
+		t.Error("TableEdit does not track the edited cells")
+	}
+
+	got, err := edit.Changes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*RowChange{
+		{Kind: RowChangeDelete, KeyColumns: []string{"id"}, KeyValues: []string{"1"}},
+		{
+			Kind:       RowChangeUpdate,
+			Columns:    []string{"name", "note"},
+			Values:     []CellValue{{Value: "robert"}, {Null: true}},
+			KeyColumns: []string{"id"},
+			KeyValues:  []string{"2"},
+		},
+		{
+			Kind:       RowChangeUpdate,
+			Columns:    []string{"id"},
+			Values:     []CellValue{{Value: "30"}},
+			KeyColumns: []string{"id"},
+			KeyValues:  []string{"3"},
+		},
+		{Kind: RowChangeInsert, Columns: []string{"name"}, Values: []CellValue{{Value: "dave"}}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("TableEdit.Changes() mismatch (-want +got):\n%s", diff)
+	}
+
+	wantTable := NewTable("public.users",
+		Header{"name", "id", "note"},
+		[]Record{
+			{"alice", "1", "a"},
+			{"robert", "2", "NULL"},
+			{"carol", "30", ""},
+			{"dave", "", ""},
+		})
+	if diff := cmp.Diff(wantTable, edit.Table(), cmp.AllowUnexported(Table{})); diff != "" {
+		t.Errorf("TableEdit.Table() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestTableEditChangesEmptyNewRow(t *testing.T) {
+	t.Parallel()
+
+	edit, err := NewTableEdit("users", NewTable("users", Header{"id"}, []Record{}), []string{"id"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	edit.AddRow()
+	if _, err := edit.Changes(); err == nil {
+		t.Error("TableEdit.Changes() should fail for a new row without values")
+	}
+}
@@ -302,3 +302,26 @@ func TestTableEqual(t *testing.T) {
 		})
 	}
 }
+
+func TestTableQualifiedName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		schema string
+		want   string
+	}{
+		{name: "table in schema", schema: "public", want: "public.user"},
+		{name: "table without schema", schema: "", want: "user"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			table := NewTable("user", Header{"id"}, []Record{})
+			table.SetSchema(tt.schema)
+			if got := table.QualifiedName(); got != tt.want {
+				t.Errorf("Table.QualifiedName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
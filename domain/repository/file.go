@@ -39,4 +39,9 @@ type (
 	LTSVWriter interface {
 		WriteLTSV(ctx context.Context, file *model.File, table *model.Table) error
 	}
+
+	// JSONWriter is an interface for writing records to JSON files.
+	JSONWriter interface {
+		WriteJSON(ctx context.Context, file *model.File, table *model.Table) error
+	}
 )
@@ -38,4 +38,9 @@ type (
 	TableDDLGetter interface {
 		GetTableDDL(ctx context.Context, tableName string) ([]*model.Table, error)
 	}
+
+	// CreateTableStatementGetter gets the CREATE statement of a table in memory.
+	CreateTableStatementGetter interface {
+		GetCreateTableStatement(ctx context.Context, tableName string) (string, error)
+	}
 )
@@ -29,4 +29,76 @@ type (
 	TableDDLInRemoteGetter interface {
 		GetTableDDL(ctx context.Context, tableName string) ([]*model.Table, error)
 	}
+
+	// CreateTableStatementInRemoteGetter gets the CREATE statement of a table (or view) in database,
+	// including constraints, defaults and indexes.
+	CreateTableStatementInRemoteGetter interface {
+		GetCreateTableStatement(ctx context.Context, tableName string) (string, error)
+	}
+
+	// ObjectsInRemoteGetter gets the names of database objects other than tables, such as views and sequences.
+	ObjectsInRemoteGetter interface {
+		GetObjects(ctx context.Context, objectType model.ObjectType) ([]string, error)
+	}
+
+	// IndexesInRemoteGetter gets the indexes of a table in database.
+	IndexesInRemoteGetter interface {
+		GetIndexes(ctx context.Context, tableName string) ([]*model.Index, error)
+	}
+
+	// ForeignKeysInRemoteGetter gets the foreign keys of a table in database.
+	ForeignKeysInRemoteGetter interface {
+		GetForeignKeys(ctx context.Context, tableName string) ([]*model.ForeignKey, error)
+	}
+
+	// TriggersInRemoteGetter gets the triggers of a table in database.
+	TriggersInRemoteGetter interface {
+		GetTriggers(ctx context.Context, tableName string) ([]*model.Trigger, error)
+	}
+
+	// RemoteTableImporter imports the records of a file (or a query result) into a table in database.
+	// PlanImport returns the plan without changing the database, so it can be used as a dry-run.
+	// If types is nil, the column types of a new table are inferred from the records.
+	RemoteTableImporter interface {
+		PlanImport(ctx context.Context, table *model.Table, tableName string, types []model.ColumnType) (*model.ImportPlan, error)
+		Import(ctx context.Context, plan *model.ImportPlan) (int64, error)
+	}
+
+	// RemoteQueryStreamer executes a read-only query in database and passes the result to fn in batches
+	// of batchSize records, so that a large result is not held in memory. The types are the column types
+	// of the result mapped from the database types.
+	RemoteQueryStreamer interface {
+		StreamQuery(ctx context.Context, sql *model.SQL, batchSize int, fn func(batch *model.Table, types []model.ColumnType) error) error
+	}
+
+	// TableSchemasInRemoteGetter gets the columns, primary keys, indexes and foreign keys of the tables
+	// in database to compare schemas and to draw ER diagrams.
+	TableSchemasInRemoteGetter interface {
+		GetTableSchemas(ctx context.Context) ([]*model.TableSchema, error)
+	}
+
+	// QueryPlanInRemoteGetter gets the plan of a query in database. If analyze is true, the DBMS that
+	// supports it executes the query to measure the actual rows and time.
+	QueryPlanInRemoteGetter interface {
+		GetQueryPlan(ctx context.Context, sql *model.SQL, analyze bool) (*model.QueryPlan, error)
+	}
+
+	// RemoteTableEditor writes the rows edited in the result grid to a table in database.
+	// EditStatements returns the statements without changing the database, so they can be reviewed.
+	// ApplyEditStatements executes them in a transaction and returns the number of changed rows.
+	RemoteTableEditor interface {
+		EditStatements(edit *model.TableEdit) ([]*model.EditStatement, error)
+		ApplyEditStatements(ctx context.Context, stmts []*model.EditStatement) (int64, error)
+	}
+
+	// AlterStatementsGenerator generates the DDL statements that apply a schema diff to database.
+	AlterStatementsGenerator interface {
+		GenerateAlterStatements(diff *model.SchemaDiff) string
+	}
+
+	// SchemasInRemoteGetter gets the schemas (databases in MySQL and SQL Server) on the server.
+	SchemasInRemoteGetter interface {
+		GetSchemas(ctx context.Context) ([]string, error)
+		GetCurrentSchema(ctx context.Context) (string, error)
+	}
 )
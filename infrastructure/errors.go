@@ -1,11 +1,49 @@
 // Package infrastructure manage sqluv infrastructure logic.
 package infrastructure
 
-import "errors"
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"net"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+)
 
 var (
 	// ErrNoRows is same as sql.ErrNoRows
 	ErrNoRows = errors.New("execute query, however return no records")
 	// ErrNoLabel is error when label not found during LTSV parsing
 	ErrNoLabel = errors.New("no labels in the data")
+	// ErrTableNotFound is error when the table does not exist in the database
+	ErrTableNotFound = errors.New("table not found")
+	// ErrColumnNotFound is error when a file column does not exist in the table that the file is imported into
+	ErrColumnNotFound = errors.New("column not found")
+	// ErrReadOnlyConnection is error when a statement that modifies the database is executed on a read-only connection
+	ErrReadOnlyConnection = errors.New("the connection is read-only: data, schema and privilege changes are not allowed")
 )
+
+// IsConnectionError returns true if the error means that the connection to the database is lost,
+// e.g. the network is down or the database server is restarted. A canceled query is not a connection error.
+func IsConnectionError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, sql.ErrConnDone) || errors.Is(err, mysql.ErrInvalidConn) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		// Class 08 is connection exceptions, and 57P01-57P03 are the server shutdown and startup.
+		return pqErr.Code.Class() == "08" || pqErr.Code == "57P01" || pqErr.Code == "57P02" || pqErr.Code == "57P03"
+	}
+	return false
+}
@@ -0,0 +1,45 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"syscall"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+)
+
+func TestIsConnectionError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "bad connection", err: fmt.Errorf("query: %w", driver.ErrBadConn), want: true},
+		{name: "connection reset", err: &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}, want: true},
+		{name: "unexpected EOF", err: io.ErrUnexpectedEOF, want: true},
+		{name: "MySQL invalid connection", err: mysql.ErrInvalidConn, want: true},
+		{name: "PostgreSQL admin shutdown", err: &pq.Error{Code: "57P01"}, want: true},
+		{name: "PostgreSQL connection failure", err: &pq.Error{Code: "08006"}, want: true},
+		{name: "PostgreSQL syntax error", err: &pq.Error{Code: "42601"}, want: false},
+		{name: "canceled query", err: fmt.Errorf("query: %w", context.Canceled), want: false},
+		{name: "other error", err: errors.New("no such table: user"), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := IsConnectionError(tt.err); got != tt.want {
+				t.Errorf("IsConnectionError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
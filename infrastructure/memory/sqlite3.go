@@ -4,6 +4,8 @@ package memory
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
 
 	"github.com/nao1215/sqluv/config"
 	"github.com/nao1215/sqluv/domain/model"
@@ -76,9 +78,9 @@ func (g *tableGetter) GetTables(ctx context.Context) ([]*model.Table, error) {
 			return nil, err
 		}
 
-		// Retrieve column info for the table using PRAGMA table_info
-		pragmaQuery := "PRAGMA table_info('" + name + "')"
-		colRows, err := tx.QueryContext(ctx, pragmaQuery)
+		// Retrieve column info for the table using pragma_table_info. The name is a bind parameter
+		// because it comes from the file name and may contain quotes.
+		colRows, err := tx.QueryContext(ctx, `SELECT cid, name, type, "notnull", dflt_value, pk FROM pragma_table_info(?) ORDER BY cid`, name)
 		if err != nil {
 			return nil, err
 		}
@@ -226,9 +228,9 @@ func (d *ddlGetter) GetTableDDL(ctx context.Context, tableName string) ([]*model
 	}
 	defer tx.Rollback()
 
-	// PRAGMA table_info returns: cid, name, type, notnull, dflt_value, pk
-	query := "PRAGMA table_info(" + tableName + ")"
-	rows, err := tx.QueryContext(ctx, query)
+	// pragma_table_info returns: cid, name, type, notnull, dflt_value, pk
+	query := `SELECT cid, name, type, "notnull", dflt_value, pk FROM pragma_table_info(?) ORDER BY cid`
+	rows, err := tx.QueryContext(ctx, query, tableName)
 	if err != nil {
 		return nil, err
 	}
@@ -272,3 +274,29 @@ func (d *ddlGetter) GetTableDDL(ctx context.Context, tableName string) ([]*model
 	ddlTable := model.NewTable(tableName, header, records)
 	return []*model.Table{ddlTable}, nil
 }
+
+// _ interface implementation check
+var _ repository.CreateTableStatementGetter = (*createTableStatementGetter)(nil)
+
+type createTableStatementGetter struct {
+	db *sql.DB
+}
+
+// NewCreateTableStatementGetter return createTableStatementGetter
+func NewCreateTableStatementGetter(db config.MemoryDB) repository.CreateTableStatementGetter {
+	return &createTableStatementGetter{db: db}
+}
+
+// GetCreateTableStatement get the CREATE TABLE statement stored in sqlite_master
+func (c *createTableStatementGetter) GetCreateTableStatement(ctx context.Context, tableName string) (string, error) {
+	var stmt string
+	err := c.db.QueryRowContext(ctx,
+		"SELECT sql FROM sqlite_master WHERE type = 'table' AND name = ?", tableName).Scan(&stmt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", fmt.Errorf("%w: %s", infrastructure.ErrTableNotFound, tableName)
+	}
+	if err != nil {
+		return "", err
+	}
+	return stmt + ";", nil
+}
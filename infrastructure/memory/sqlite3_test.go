@@ -1,2 +1,70 @@
 // Package memory handle sqlite3 in memory mode
 package memory
+
+import (
+	"database/sql"
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/nao1215/sqluv/config"
+	"github.com/nao1215/sqluv/domain/model"
+	"github.com/nao1215/sqluv/internal/testutil"
+)
+
+func TestCatalogWithHostileTableNames(t *testing.T) {
+	t.Parallel()
+
+	db, closeDB, err := config.NewMemoryDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(closeDB)
+	// Each connection to ":memory:" has its own database.
+	(*sql.DB)(db).SetMaxOpenConns(1)
+
+	names := testutil.HostileTableNames()
+	header := model.NewHeader([]string{"id", "name"})
+	for _, name := range append([]string{"victim"}, names...) {
+		if err := NewTableCreator(db).CreateTable(t.Context(), model.NewTable(name, header, []model.Record{{"1", "a"}})); err != nil {
+			t.Fatalf("failed to create table %q: %v", name, err)
+		}
+	}
+
+	tables, err := NewTableGetter(db).GetTables(t.Context())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tables) != len(names)+1 {
+		t.Fatalf("want %d tables, got %d", len(names)+1, len(tables))
+	}
+	for _, table := range tables {
+		if diff := cmp.Diff(header, table.Header()); diff != "" {
+			t.Errorf("columns of %q mismatch (-want +got):\n%s", table.Name(), diff)
+		}
+	}
+
+	for _, name := range names {
+		t.Run(name, func(t *testing.T) {
+			ddl, err := NewTableDDLGetter(db).GetTableDDL(t.Context(), name)
+			if err != nil {
+				t.Fatalf("failed to get DDL of %q: %v", name, err)
+			}
+			columns := []string{}
+			for _, record := range ddl[0].Records() {
+				columns = append(columns, record[0])
+			}
+			if diff := cmp.Diff([]string{"id", "name"}, columns); diff != "" {
+				t.Errorf("DDL columns of %q mismatch (-want +got):\n%s", name, diff)
+			}
+		})
+	}
+
+	tables, err = NewTableGetter(db).GetTables(t.Context())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.ContainsFunc(tables, func(t *model.Table) bool { return t.Name() == "victim" }) {
+		t.Error("table victim was dropped")
+	}
+}
@@ -11,4 +11,5 @@ var Set = wire.NewSet(
 	NewQueryExecutor,
 	NewStatementExecutor,
 	NewTableDDLGetter,
+	NewCreateTableStatementGetter,
 )
@@ -391,3 +391,65 @@ func (c *MockLTSVWriterWriteLTSVCall) DoAndReturn(f func(context.Context, *model
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
+
+// MockJSONWriter is a mock of JSONWriter interface.
+type MockJSONWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockJSONWriterMockRecorder
+	isgomock struct{}
+}
+
+// MockJSONWriterMockRecorder is the mock recorder for MockJSONWriter.
+type MockJSONWriterMockRecorder struct {
+	mock *MockJSONWriter
+}
+
+// NewMockJSONWriter creates a new mock instance.
+func NewMockJSONWriter(ctrl *gomock.Controller) *MockJSONWriter {
+	mock := &MockJSONWriter{ctrl: ctrl}
+	mock.recorder = &MockJSONWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockJSONWriter) EXPECT() *MockJSONWriterMockRecorder {
+	return m.recorder
+}
+
+// WriteJSON mocks base method.
+func (m *MockJSONWriter) WriteJSON(ctx context.Context, file *model.File, table *model.Table) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteJSON", ctx, file, table)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WriteJSON indicates an expected call of WriteJSON.
+func (mr *MockJSONWriterMockRecorder) WriteJSON(ctx, file, table any) *MockJSONWriterWriteJSONCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteJSON", reflect.TypeOf((*MockJSONWriter)(nil).WriteJSON), ctx, file, table)
+	return &MockJSONWriterWriteJSONCall{Call: call}
+}
+
+// MockJSONWriterWriteJSONCall wrap *gomock.Call
+type MockJSONWriterWriteJSONCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockJSONWriterWriteJSONCall) Return(arg0 error) *MockJSONWriterWriteJSONCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockJSONWriterWriteJSONCall) Do(f func(context.Context, *model.File, *model.Table) error) *MockJSONWriterWriteJSONCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockJSONWriterWriteJSONCall) DoAndReturn(f func(context.Context, *model.File, *model.Table) error) *MockJSONWriterWriteJSONCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -268,3 +268,874 @@ func (c *MockTableDDLInRemoteGetterGetTableDDLCall) DoAndReturn(f func(context.C
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
+
+// MockCreateTableStatementInRemoteGetter is a mock of CreateTableStatementInRemoteGetter interface.
+type MockCreateTableStatementInRemoteGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockCreateTableStatementInRemoteGetterMockRecorder
+	isgomock struct{}
+}
+
+// MockCreateTableStatementInRemoteGetterMockRecorder is the mock recorder for MockCreateTableStatementInRemoteGetter.
+type MockCreateTableStatementInRemoteGetterMockRecorder struct {
+	mock *MockCreateTableStatementInRemoteGetter
+}
+
+// NewMockCreateTableStatementInRemoteGetter creates a new mock instance.
+func NewMockCreateTableStatementInRemoteGetter(ctrl *gomock.Controller) *MockCreateTableStatementInRemoteGetter {
+	mock := &MockCreateTableStatementInRemoteGetter{ctrl: ctrl}
+	mock.recorder = &MockCreateTableStatementInRemoteGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCreateTableStatementInRemoteGetter) EXPECT() *MockCreateTableStatementInRemoteGetterMockRecorder {
+	return m.recorder
+}
+
+// GetCreateTableStatement mocks base method.
+func (m *MockCreateTableStatementInRemoteGetter) GetCreateTableStatement(ctx context.Context, tableName string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCreateTableStatement", ctx, tableName)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCreateTableStatement indicates an expected call of GetCreateTableStatement.
+func (mr *MockCreateTableStatementInRemoteGetterMockRecorder) GetCreateTableStatement(ctx, tableName any) *MockCreateTableStatementInRemoteGetterGetCreateTableStatementCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCreateTableStatement", reflect.TypeOf((*MockCreateTableStatementInRemoteGetter)(nil).GetCreateTableStatement), ctx, tableName)
+	return &MockCreateTableStatementInRemoteGetterGetCreateTableStatementCall{Call: call}
+}
+
+// MockCreateTableStatementInRemoteGetterGetCreateTableStatementCall wrap *gomock.Call
+type MockCreateTableStatementInRemoteGetterGetCreateTableStatementCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockCreateTableStatementInRemoteGetterGetCreateTableStatementCall) Return(arg0 string, arg1 error) *MockCreateTableStatementInRemoteGetterGetCreateTableStatementCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockCreateTableStatementInRemoteGetterGetCreateTableStatementCall) Do(f func(context.Context, string) (string, error)) *MockCreateTableStatementInRemoteGetterGetCreateTableStatementCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockCreateTableStatementInRemoteGetterGetCreateTableStatementCall) DoAndReturn(f func(context.Context, string) (string, error)) *MockCreateTableStatementInRemoteGetterGetCreateTableStatementCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockObjectsInRemoteGetter is a mock of ObjectsInRemoteGetter interface.
+type MockObjectsInRemoteGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockObjectsInRemoteGetterMockRecorder
+	isgomock struct{}
+}
+
+// MockObjectsInRemoteGetterMockRecorder is the mock recorder for MockObjectsInRemoteGetter.
+type MockObjectsInRemoteGetterMockRecorder struct {
+	mock *MockObjectsInRemoteGetter
+}
+
+// NewMockObjectsInRemoteGetter creates a new mock instance.
+func NewMockObjectsInRemoteGetter(ctrl *gomock.Controller) *MockObjectsInRemoteGetter {
+	mock := &MockObjectsInRemoteGetter{ctrl: ctrl}
+	mock.recorder = &MockObjectsInRemoteGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockObjectsInRemoteGetter) EXPECT() *MockObjectsInRemoteGetterMockRecorder {
+	return m.recorder
+}
+
+// GetObjects mocks base method.
+func (m *MockObjectsInRemoteGetter) GetObjects(ctx context.Context, objectType model.ObjectType) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetObjects", ctx, objectType)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetObjects indicates an expected call of GetObjects.
+func (mr *MockObjectsInRemoteGetterMockRecorder) GetObjects(ctx, objectType any) *MockObjectsInRemoteGetterGetObjectsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObjects", reflect.TypeOf((*MockObjectsInRemoteGetter)(nil).GetObjects), ctx, objectType)
+	return &MockObjectsInRemoteGetterGetObjectsCall{Call: call}
+}
+
+// MockObjectsInRemoteGetterGetObjectsCall wrap *gomock.Call
+type MockObjectsInRemoteGetterGetObjectsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockObjectsInRemoteGetterGetObjectsCall) Return(arg0 []string, arg1 error) *MockObjectsInRemoteGetterGetObjectsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockObjectsInRemoteGetterGetObjectsCall) Do(f func(context.Context, model.ObjectType) ([]string, error)) *MockObjectsInRemoteGetterGetObjectsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockObjectsInRemoteGetterGetObjectsCall) DoAndReturn(f func(context.Context, model.ObjectType) ([]string, error)) *MockObjectsInRemoteGetterGetObjectsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockIndexesInRemoteGetter is a mock of IndexesInRemoteGetter interface.
+type MockIndexesInRemoteGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockIndexesInRemoteGetterMockRecorder
+	isgomock struct{}
+}
+
+// MockIndexesInRemoteGetterMockRecorder is the mock recorder for MockIndexesInRemoteGetter.
+type MockIndexesInRemoteGetterMockRecorder struct {
+	mock *MockIndexesInRemoteGetter
+}
+
+// NewMockIndexesInRemoteGetter creates a new mock instance.
+func NewMockIndexesInRemoteGetter(ctrl *gomock.Controller) *MockIndexesInRemoteGetter {
+	mock := &MockIndexesInRemoteGetter{ctrl: ctrl}
+	mock.recorder = &MockIndexesInRemoteGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIndexesInRemoteGetter) EXPECT() *MockIndexesInRemoteGetterMockRecorder {
+	return m.recorder
+}
+
+// GetIndexes mocks base method.
+func (m *MockIndexesInRemoteGetter) GetIndexes(ctx context.Context, tableName string) ([]*model.Index, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIndexes", ctx, tableName)
+	ret0, _ := ret[0].([]*model.Index)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIndexes indicates an expected call of GetIndexes.
+func (mr *MockIndexesInRemoteGetterMockRecorder) GetIndexes(ctx, tableName any) *MockIndexesInRemoteGetterGetIndexesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIndexes", reflect.TypeOf((*MockIndexesInRemoteGetter)(nil).GetIndexes), ctx, tableName)
+	return &MockIndexesInRemoteGetterGetIndexesCall{Call: call}
+}
+
+// MockIndexesInRemoteGetterGetIndexesCall wrap *gomock.Call
+type MockIndexesInRemoteGetterGetIndexesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockIndexesInRemoteGetterGetIndexesCall) Return(arg0 []*model.Index, arg1 error) *MockIndexesInRemoteGetterGetIndexesCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockIndexesInRemoteGetterGetIndexesCall) Do(f func(context.Context, string) ([]*model.Index, error)) *MockIndexesInRemoteGetterGetIndexesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockIndexesInRemoteGetterGetIndexesCall) DoAndReturn(f func(context.Context, string) ([]*model.Index, error)) *MockIndexesInRemoteGetterGetIndexesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockForeignKeysInRemoteGetter is a mock of ForeignKeysInRemoteGetter interface.
+type MockForeignKeysInRemoteGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockForeignKeysInRemoteGetterMockRecorder
+	isgomock struct{}
+}
+
+// MockForeignKeysInRemoteGetterMockRecorder is the mock recorder for MockForeignKeysInRemoteGetter.
+type MockForeignKeysInRemoteGetterMockRecorder struct {
+	mock *MockForeignKeysInRemoteGetter
+}
+
+// NewMockForeignKeysInRemoteGetter creates a new mock instance.
+func NewMockForeignKeysInRemoteGetter(ctrl *gomock.Controller) *MockForeignKeysInRemoteGetter {
+	mock := &MockForeignKeysInRemoteGetter{ctrl: ctrl}
+	mock.recorder = &MockForeignKeysInRemoteGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockForeignKeysInRemoteGetter) EXPECT() *MockForeignKeysInRemoteGetterMockRecorder {
+	return m.recorder
+}
+
+// GetForeignKeys mocks base method.
+func (m *MockForeignKeysInRemoteGetter) GetForeignKeys(ctx context.Context, tableName string) ([]*model.ForeignKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetForeignKeys", ctx, tableName)
+	ret0, _ := ret[0].([]*model.ForeignKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetForeignKeys indicates an expected call of GetForeignKeys.
+func (mr *MockForeignKeysInRemoteGetterMockRecorder) GetForeignKeys(ctx, tableName any) *MockForeignKeysInRemoteGetterGetForeignKeysCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetForeignKeys", reflect.TypeOf((*MockForeignKeysInRemoteGetter)(nil).GetForeignKeys), ctx, tableName)
+	return &MockForeignKeysInRemoteGetterGetForeignKeysCall{Call: call}
+}
+
+// MockForeignKeysInRemoteGetterGetForeignKeysCall wrap *gomock.Call
+type MockForeignKeysInRemoteGetterGetForeignKeysCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockForeignKeysInRemoteGetterGetForeignKeysCall) Return(arg0 []*model.ForeignKey, arg1 error) *MockForeignKeysInRemoteGetterGetForeignKeysCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockForeignKeysInRemoteGetterGetForeignKeysCall) Do(f func(context.Context, string) ([]*model.ForeignKey, error)) *MockForeignKeysInRemoteGetterGetForeignKeysCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockForeignKeysInRemoteGetterGetForeignKeysCall) DoAndReturn(f func(context.Context, string) ([]*model.ForeignKey, error)) *MockForeignKeysInRemoteGetterGetForeignKeysCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockTriggersInRemoteGetter is a mock of TriggersInRemoteGetter interface.
+type MockTriggersInRemoteGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockTriggersInRemoteGetterMockRecorder
+	isgomock struct{}
+}
+
+// MockTriggersInRemoteGetterMockRecorder is the mock recorder for MockTriggersInRemoteGetter.
+type MockTriggersInRemoteGetterMockRecorder struct {
+	mock *MockTriggersInRemoteGetter
+}
+
+// NewMockTriggersInRemoteGetter creates a new mock instance.
+func NewMockTriggersInRemoteGetter(ctrl *gomock.Controller) *MockTriggersInRemoteGetter {
+	mock := &MockTriggersInRemoteGetter{ctrl: ctrl}
+	mock.recorder = &MockTriggersInRemoteGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTriggersInRemoteGetter) EXPECT() *MockTriggersInRemoteGetterMockRecorder {
+	return m.recorder
+}
+
+// GetTriggers mocks base method.
+func (m *MockTriggersInRemoteGetter) GetTriggers(ctx context.Context, tableName string) ([]*model.Trigger, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTriggers", ctx, tableName)
+	ret0, _ := ret[0].([]*model.Trigger)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTriggers indicates an expected call of GetTriggers.
+func (mr *MockTriggersInRemoteGetterMockRecorder) GetTriggers(ctx, tableName any) *MockTriggersInRemoteGetterGetTriggersCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTriggers", reflect.TypeOf((*MockTriggersInRemoteGetter)(nil).GetTriggers), ctx, tableName)
+	return &MockTriggersInRemoteGetterGetTriggersCall{Call: call}
+}
+
+// MockTriggersInRemoteGetterGetTriggersCall wrap *gomock.Call
+type MockTriggersInRemoteGetterGetTriggersCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTriggersInRemoteGetterGetTriggersCall) Return(arg0 []*model.Trigger, arg1 error) *MockTriggersInRemoteGetterGetTriggersCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTriggersInRemoteGetterGetTriggersCall) Do(f func(context.Context, string) ([]*model.Trigger, error)) *MockTriggersInRemoteGetterGetTriggersCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTriggersInRemoteGetterGetTriggersCall) DoAndReturn(f func(context.Context, string) ([]*model.Trigger, error)) *MockTriggersInRemoteGetterGetTriggersCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockRemoteTableImporter is a mock of RemoteTableImporter interface.
+type MockRemoteTableImporter struct {
+	ctrl     *gomock.Controller
+	recorder *MockRemoteTableImporterMockRecorder
+	isgomock struct{}
+}
+
+// MockRemoteTableImporterMockRecorder is the mock recorder for MockRemoteTableImporter.
+type MockRemoteTableImporterMockRecorder struct {
+	mock *MockRemoteTableImporter
+}
+
+// NewMockRemoteTableImporter creates a new mock instance.
+func NewMockRemoteTableImporter(ctrl *gomock.Controller) *MockRemoteTableImporter {
+	mock := &MockRemoteTableImporter{ctrl: ctrl}
+	mock.recorder = &MockRemoteTableImporterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRemoteTableImporter) EXPECT() *MockRemoteTableImporterMockRecorder {
+	return m.recorder
+}
+
+// Import mocks base method.
+func (m *MockRemoteTableImporter) Import(ctx context.Context, plan *model.ImportPlan) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", ctx, plan)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockRemoteTableImporterMockRecorder) Import(ctx, plan any) *MockRemoteTableImporterImportCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockRemoteTableImporter)(nil).Import), ctx, plan)
+	return &MockRemoteTableImporterImportCall{Call: call}
+}
+
+// MockRemoteTableImporterImportCall wrap *gomock.Call
+type MockRemoteTableImporterImportCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRemoteTableImporterImportCall) Return(arg0 int64, arg1 error) *MockRemoteTableImporterImportCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRemoteTableImporterImportCall) Do(f func(context.Context, *model.ImportPlan) (int64, error)) *MockRemoteTableImporterImportCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRemoteTableImporterImportCall) DoAndReturn(f func(context.Context, *model.ImportPlan) (int64, error)) *MockRemoteTableImporterImportCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// PlanImport mocks base method.
+func (m *MockRemoteTableImporter) PlanImport(ctx context.Context, table *model.Table, tableName string, types []model.ColumnType) (*model.ImportPlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PlanImport", ctx, table, tableName, types)
+	ret0, _ := ret[0].(*model.ImportPlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PlanImport indicates an expected call of PlanImport.
+func (mr *MockRemoteTableImporterMockRecorder) PlanImport(ctx, table, tableName, types any) *MockRemoteTableImporterPlanImportCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlanImport", reflect.TypeOf((*MockRemoteTableImporter)(nil).PlanImport), ctx, table, tableName, types)
+	return &MockRemoteTableImporterPlanImportCall{Call: call}
+}
+
+// MockRemoteTableImporterPlanImportCall wrap *gomock.Call
+type MockRemoteTableImporterPlanImportCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRemoteTableImporterPlanImportCall) Return(arg0 *model.ImportPlan, arg1 error) *MockRemoteTableImporterPlanImportCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRemoteTableImporterPlanImportCall) Do(f func(context.Context, *model.Table, string, []model.ColumnType) (*model.ImportPlan, error)) *MockRemoteTableImporterPlanImportCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRemoteTableImporterPlanImportCall) DoAndReturn(f func(context.Context, *model.Table, string, []model.ColumnType) (*model.ImportPlan, error)) *MockRemoteTableImporterPlanImportCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockRemoteQueryStreamer is a mock of RemoteQueryStreamer interface.
+type MockRemoteQueryStreamer struct {
+	ctrl     *gomock.Controller
+	recorder *MockRemoteQueryStreamerMockRecorder
+	isgomock struct{}
+}
+
+// MockRemoteQueryStreamerMockRecorder is the mock recorder for MockRemoteQueryStreamer.
+type MockRemoteQueryStreamerMockRecorder struct {
+	mock *MockRemoteQueryStreamer
+}
+
+// NewMockRemoteQueryStreamer creates a new mock instance.
+func NewMockRemoteQueryStreamer(ctrl *gomock.Controller) *MockRemoteQueryStreamer {
+	mock := &MockRemoteQueryStreamer{ctrl: ctrl}
+	mock.recorder = &MockRemoteQueryStreamerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRemoteQueryStreamer) EXPECT() *MockRemoteQueryStreamerMockRecorder {
+	return m.recorder
+}
+
+// StreamQuery mocks base method.
+func (m *MockRemoteQueryStreamer) StreamQuery(ctx context.Context, sql *model.SQL, batchSize int, fn func(*model.Table, []model.ColumnType) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamQuery", ctx, sql, batchSize, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamQuery indicates an expected call of StreamQuery.
+func (mr *MockRemoteQueryStreamerMockRecorder) StreamQuery(ctx, sql, batchSize, fn any) *MockRemoteQueryStreamerStreamQueryCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamQuery", reflect.TypeOf((*MockRemoteQueryStreamer)(nil).StreamQuery), ctx, sql, batchSize, fn)
+	return &MockRemoteQueryStreamerStreamQueryCall{Call: call}
+}
+
+// MockRemoteQueryStreamerStreamQueryCall wrap *gomock.Call
+type MockRemoteQueryStreamerStreamQueryCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRemoteQueryStreamerStreamQueryCall) Return(arg0 error) *MockRemoteQueryStreamerStreamQueryCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRemoteQueryStreamerStreamQueryCall) Do(f func(context.Context, *model.SQL, int, func(*model.Table, []model.ColumnType) error) error) *MockRemoteQueryStreamerStreamQueryCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRemoteQueryStreamerStreamQueryCall) DoAndReturn(f func(context.Context, *model.SQL, int, func(*model.Table, []model.ColumnType) error) error) *MockRemoteQueryStreamerStreamQueryCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockTableSchemasInRemoteGetter is a mock of TableSchemasInRemoteGetter interface.
+type MockTableSchemasInRemoteGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockTableSchemasInRemoteGetterMockRecorder
+	isgomock struct{}
+}
+
+// MockTableSchemasInRemoteGetterMockRecorder is the mock recorder for MockTableSchemasInRemoteGetter.
+type MockTableSchemasInRemoteGetterMockRecorder struct {
+	mock *MockTableSchemasInRemoteGetter
+}
+
+// NewMockTableSchemasInRemoteGetter creates a new mock instance.
+func NewMockTableSchemasInRemoteGetter(ctrl *gomock.Controller) *MockTableSchemasInRemoteGetter {
+	mock := &MockTableSchemasInRemoteGetter{ctrl: ctrl}
+	mock.recorder = &MockTableSchemasInRemoteGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTableSchemasInRemoteGetter) EXPECT() *MockTableSchemasInRemoteGetterMockRecorder {
+	return m.recorder
+}
+
+// GetTableSchemas mocks base method.
+func (m *MockTableSchemasInRemoteGetter) GetTableSchemas(ctx context.Context) ([]*model.TableSchema, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTableSchemas", ctx)
+	ret0, _ := ret[0].([]*model.TableSchema)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTableSchemas indicates an expected call of GetTableSchemas.
+func (mr *MockTableSchemasInRemoteGetterMockRecorder) GetTableSchemas(ctx any) *MockTableSchemasInRemoteGetterGetTableSchemasCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTableSchemas", reflect.TypeOf((*MockTableSchemasInRemoteGetter)(nil).GetTableSchemas), ctx)
+	return &MockTableSchemasInRemoteGetterGetTableSchemasCall{Call: call}
+}
+
+// MockTableSchemasInRemoteGetterGetTableSchemasCall wrap *gomock.Call
+type MockTableSchemasInRemoteGetterGetTableSchemasCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTableSchemasInRemoteGetterGetTableSchemasCall) Return(arg0 []*model.TableSchema, arg1 error) *MockTableSchemasInRemoteGetterGetTableSchemasCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTableSchemasInRemoteGetterGetTableSchemasCall) Do(f func(context.Context) ([]*model.TableSchema, error)) *MockTableSchemasInRemoteGetterGetTableSchemasCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTableSchemasInRemoteGetterGetTableSchemasCall) DoAndReturn(f func(context.Context) ([]*model.TableSchema, error)) *MockTableSchemasInRemoteGetterGetTableSchemasCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockQueryPlanInRemoteGetter is a mock of QueryPlanInRemoteGetter interface.
+type MockQueryPlanInRemoteGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockQueryPlanInRemoteGetterMockRecorder
+	isgomock struct{}
+}
+
+// MockQueryPlanInRemoteGetterMockRecorder is the mock recorder for MockQueryPlanInRemoteGetter.
+type MockQueryPlanInRemoteGetterMockRecorder struct {
+	mock *MockQueryPlanInRemoteGetter
+}
+
+// NewMockQueryPlanInRemoteGetter creates a new mock instance.
+func NewMockQueryPlanInRemoteGetter(ctrl *gomock.Controller) *MockQueryPlanInRemoteGetter {
+	mock := &MockQueryPlanInRemoteGetter{ctrl: ctrl}
+	mock.recorder = &MockQueryPlanInRemoteGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockQueryPlanInRemoteGetter) EXPECT() *MockQueryPlanInRemoteGetterMockRecorder {
+	return m.recorder
+}
+
+// GetQueryPlan mocks base method.
+func (m *MockQueryPlanInRemoteGetter) GetQueryPlan(ctx context.Context, sql *model.SQL, analyze bool) (*model.QueryPlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQueryPlan", ctx, sql, analyze)
+	ret0, _ := ret[0].(*model.QueryPlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQueryPlan indicates an expected call of GetQueryPlan.
+func (mr *MockQueryPlanInRemoteGetterMockRecorder) GetQueryPlan(ctx, sql, analyze any) *MockQueryPlanInRemoteGetterGetQueryPlanCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQueryPlan", reflect.TypeOf((*MockQueryPlanInRemoteGetter)(nil).GetQueryPlan), ctx, sql, analyze)
+	return &MockQueryPlanInRemoteGetterGetQueryPlanCall{Call: call}
+}
+
+// MockQueryPlanInRemoteGetterGetQueryPlanCall wrap *gomock.Call
+type MockQueryPlanInRemoteGetterGetQueryPlanCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockQueryPlanInRemoteGetterGetQueryPlanCall) Return(arg0 *model.QueryPlan, arg1 error) *MockQueryPlanInRemoteGetterGetQueryPlanCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockQueryPlanInRemoteGetterGetQueryPlanCall) Do(f func(context.Context, *model.SQL, bool) (*model.QueryPlan, error)) *MockQueryPlanInRemoteGetterGetQueryPlanCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockQueryPlanInRemoteGetterGetQueryPlanCall) DoAndReturn(f func(context.Context, *model.SQL, bool) (*model.QueryPlan, error)) *MockQueryPlanInRemoteGetterGetQueryPlanCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockRemoteTableEditor is a mock of RemoteTableEditor interface.
+type MockRemoteTableEditor struct {
+	ctrl     *gomock.Controller
+	recorder *MockRemoteTableEditorMockRecorder
+	isgomock struct{}
+}
+
+// MockRemoteTableEditorMockRecorder is the mock recorder for MockRemoteTableEditor.
+type MockRemoteTableEditorMockRecorder struct {
+	mock *MockRemoteTableEditor
+}
+
+// NewMockRemoteTableEditor creates a new mock instance.
+func NewMockRemoteTableEditor(ctrl *gomock.Controller) *MockRemoteTableEditor {
+	mock := &MockRemoteTableEditor{ctrl: ctrl}
+	mock.recorder = &MockRemoteTableEditorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRemoteTableEditor) EXPECT() *MockRemoteTableEditorMockRecorder {
+	return m.recorder
+}
+
+// ApplyEditStatements mocks base method.
+func (m *MockRemoteTableEditor) ApplyEditStatements(ctx context.Context, stmts []*model.EditStatement) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyEditStatements", ctx, stmts)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyEditStatements indicates an expected call of ApplyEditStatements.
+func (mr *MockRemoteTableEditorMockRecorder) ApplyEditStatements(ctx, stmts any) *MockRemoteTableEditorApplyEditStatementsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyEditStatements", reflect.TypeOf((*MockRemoteTableEditor)(nil).ApplyEditStatements), ctx, stmts)
+	return &MockRemoteTableEditorApplyEditStatementsCall{Call: call}
+}
+
+// MockRemoteTableEditorApplyEditStatementsCall wrap *gomock.Call
+type MockRemoteTableEditorApplyEditStatementsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRemoteTableEditorApplyEditStatementsCall) Return(arg0 int64, arg1 error) *MockRemoteTableEditorApplyEditStatementsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRemoteTableEditorApplyEditStatementsCall) Do(f func(context.Context, []*model.EditStatement) (int64, error)) *MockRemoteTableEditorApplyEditStatementsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRemoteTableEditorApplyEditStatementsCall) DoAndReturn(f func(context.Context, []*model.EditStatement) (int64, error)) *MockRemoteTableEditorApplyEditStatementsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// EditStatements mocks base method.
+func (m *MockRemoteTableEditor) EditStatements(edit *model.TableEdit) ([]*model.EditStatement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EditStatements", edit)
+	ret0, _ := ret[0].([]*model.EditStatement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EditStatements indicates an expected call of EditStatements.
+func (mr *MockRemoteTableEditorMockRecorder) EditStatements(edit any) *MockRemoteTableEditorEditStatementsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditStatements", reflect.TypeOf((*MockRemoteTableEditor)(nil).EditStatements), edit)
+	return &MockRemoteTableEditorEditStatementsCall{Call: call}
+}
+
+// MockRemoteTableEditorEditStatementsCall wrap *gomock.Call
+type MockRemoteTableEditorEditStatementsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRemoteTableEditorEditStatementsCall) Return(arg0 []*model.EditStatement, arg1 error) *MockRemoteTableEditorEditStatementsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRemoteTableEditorEditStatementsCall) Do(f func(*model.TableEdit) ([]*model.EditStatement, error)) *MockRemoteTableEditorEditStatementsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRemoteTableEditorEditStatementsCall) DoAndReturn(f func(*model.TableEdit) ([]*model.EditStatement, error)) *MockRemoteTableEditorEditStatementsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockAlterStatementsGenerator is a mock of AlterStatementsGenerator interface.
+type MockAlterStatementsGenerator struct {
+	ctrl     *gomock.Controller
+	recorder *MockAlterStatementsGeneratorMockRecorder
+	isgomock struct{}
+}
+
+// MockAlterStatementsGeneratorMockRecorder is the mock recorder for MockAlterStatementsGenerator.
+type MockAlterStatementsGeneratorMockRecorder struct {
+	mock *MockAlterStatementsGenerator
+}
+
+// NewMockAlterStatementsGenerator creates a new mock instance.
+func NewMockAlterStatementsGenerator(ctrl *gomock.Controller) *MockAlterStatementsGenerator {
+	mock := &MockAlterStatementsGenerator{ctrl: ctrl}
+	mock.recorder = &MockAlterStatementsGeneratorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAlterStatementsGenerator) EXPECT() *MockAlterStatementsGeneratorMockRecorder {
+	return m.recorder
+}
+
+// GenerateAlterStatements mocks base method.
+func (m *MockAlterStatementsGenerator) GenerateAlterStatements(diff *model.SchemaDiff) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateAlterStatements", diff)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GenerateAlterStatements indicates an expected call of GenerateAlterStatements.
+func (mr *MockAlterStatementsGeneratorMockRecorder) GenerateAlterStatements(diff any) *MockAlterStatementsGeneratorGenerateAlterStatementsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateAlterStatements", reflect.TypeOf((*MockAlterStatementsGenerator)(nil).GenerateAlterStatements), diff)
+	return &MockAlterStatementsGeneratorGenerateAlterStatementsCall{Call: call}
+}
+
+// MockAlterStatementsGeneratorGenerateAlterStatementsCall wrap *gomock.Call
+type MockAlterStatementsGeneratorGenerateAlterStatementsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAlterStatementsGeneratorGenerateAlterStatementsCall) Return(arg0 string) *MockAlterStatementsGeneratorGenerateAlterStatementsCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAlterStatementsGeneratorGenerateAlterStatementsCall) Do(f func(*model.SchemaDiff) string) *MockAlterStatementsGeneratorGenerateAlterStatementsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAlterStatementsGeneratorGenerateAlterStatementsCall) DoAndReturn(f func(*model.SchemaDiff) string) *MockAlterStatementsGeneratorGenerateAlterStatementsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockSchemasInRemoteGetter is a mock of SchemasInRemoteGetter interface.
+type MockSchemasInRemoteGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockSchemasInRemoteGetterMockRecorder
+	isgomock struct{}
+}
+
+// MockSchemasInRemoteGetterMockRecorder is the mock recorder for MockSchemasInRemoteGetter.
+type MockSchemasInRemoteGetterMockRecorder struct {
+	mock *MockSchemasInRemoteGetter
+}
+
+// NewMockSchemasInRemoteGetter creates a new mock instance.
+func NewMockSchemasInRemoteGetter(ctrl *gomock.Controller) *MockSchemasInRemoteGetter {
+	mock := &MockSchemasInRemoteGetter{ctrl: ctrl}
+	mock.recorder = &MockSchemasInRemoteGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSchemasInRemoteGetter) EXPECT() *MockSchemasInRemoteGetterMockRecorder {
+	return m.recorder
+}
+
+// GetCurrentSchema mocks base method.
+func (m *MockSchemasInRemoteGetter) GetCurrentSchema(ctx context.Context) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrentSchema", ctx)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrentSchema indicates an expected call of GetCurrentSchema.
+func (mr *MockSchemasInRemoteGetterMockRecorder) GetCurrentSchema(ctx any) *MockSchemasInRemoteGetterGetCurrentSchemaCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentSchema", reflect.TypeOf((*MockSchemasInRemoteGetter)(nil).GetCurrentSchema), ctx)
+	return &MockSchemasInRemoteGetterGetCurrentSchemaCall{Call: call}
+}
+
+// MockSchemasInRemoteGetterGetCurrentSchemaCall wrap *gomock.Call
+type MockSchemasInRemoteGetterGetCurrentSchemaCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSchemasInRemoteGetterGetCurrentSchemaCall) Return(arg0 string, arg1 error) *MockSchemasInRemoteGetterGetCurrentSchemaCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSchemasInRemoteGetterGetCurrentSchemaCall) Do(f func(context.Context) (string, error)) *MockSchemasInRemoteGetterGetCurrentSchemaCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSchemasInRemoteGetterGetCurrentSchemaCall) DoAndReturn(f func(context.Context) (string, error)) *MockSchemasInRemoteGetterGetCurrentSchemaCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetSchemas mocks base method.
+func (m *MockSchemasInRemoteGetter) GetSchemas(ctx context.Context) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSchemas", ctx)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSchemas indicates an expected call of GetSchemas.
+func (mr *MockSchemasInRemoteGetterMockRecorder) GetSchemas(ctx any) *MockSchemasInRemoteGetterGetSchemasCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchemas", reflect.TypeOf((*MockSchemasInRemoteGetter)(nil).GetSchemas), ctx)
+	return &MockSchemasInRemoteGetterGetSchemasCall{Call: call}
+}
+
+// MockSchemasInRemoteGetterGetSchemasCall wrap *gomock.Call
+type MockSchemasInRemoteGetterGetSchemasCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSchemasInRemoteGetterGetSchemasCall) Return(arg0 []string, arg1 error) *MockSchemasInRemoteGetterGetSchemasCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSchemasInRemoteGetterGetSchemasCall) Do(f func(context.Context) ([]string, error)) *MockSchemasInRemoteGetterGetSchemasCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSchemasInRemoteGetterGetSchemasCall) DoAndReturn(f func(context.Context) ([]string, error)) *MockSchemasInRemoteGetterGetSchemasCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
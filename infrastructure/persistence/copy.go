@@ -0,0 +1,148 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/nao1215/sqluv/config"
+	"github.com/nao1215/sqluv/domain/model"
+	"github.com/nao1215/sqluv/domain/repository"
+	"github.com/nao1215/sqluv/infrastructure"
+)
+
+// _ interface implementation check
+var _ repository.RemoteQueryStreamer = (*remoteQueryStreamer)(nil)
+
+// remoteQueryStreamer reads a query result in batches to copy it to another database.
+type remoteQueryStreamer struct {
+	db       *sql.DB
+	dbmsType config.DBMSType
+	readOnly bool
+}
+
+// NewRemoteQueryStreamer returns RemoteQueryStreamer.
+func NewRemoteQueryStreamer(db config.DBMS, conf *config.DBConnection) repository.RemoteQueryStreamer {
+	return &remoteQueryStreamer{
+		db:       db,
+		dbmsType: conf.Type,
+		readOnly: conf.ReadOnly,
+	}
+}
+
+// StreamQuery executes the query and passes the result to fn in batches. The values are formatted
+// so that the other DBMS accept them: timestamps in "2006-01-02 15:04:05" format and dates in
+// "2006-01-02" format. NULL is an empty string marked by Table.SetNull, so that it is not copied as
+// an empty string. fn is not called if the result has no rows. It stops when fn returns an error.
+func (s *remoteQueryStreamer) StreamQuery(
+	ctx context.Context,
+	query *model.SQL,
+	batchSize int,
+	fn func(batch *model.Table, types []model.ColumnType) error,
+) error {
+	if !query.IsReadOnly(config.NewDialect(s.dbmsType).SQLDialect()) {
+		return errors.New("only a query that does not modify the database can be copied")
+	}
+	if batchSize <= 0 {
+		return fmt.Errorf("invalid batch size: %d", batchSize)
+	}
+
+	tx, err := beginTx(ctx, s.db, s.dbmsType, s.readOnly)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, query.String())
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return err
+	}
+	if len(columnTypes) == 0 {
+		return infrastructure.ErrNoRows
+	}
+	header := make(model.Header, 0, len(columnTypes))
+	types := make([]model.ColumnType, 0, len(columnTypes))
+	for _, ct := range columnTypes {
+		header = append(header, ct.Name())
+		types = append(types, model.ColumnTypeOf(ct.DatabaseTypeName()))
+	}
+
+	tableName := infrastructure.ExtractTableName(query)
+	values := make([]any, len(header))
+	scanDest := make([]any, len(header))
+	for i := range values {
+		scanDest[i] = &values[i]
+	}
+	records := make([]model.Record, 0, batchSize)
+	nulls := [][2]int{} // The rows and the columns of NULL in records.
+	flush := func() error {
+		batch := model.NewTable(tableName, header, records)
+		for _, null := range nulls {
+			batch.SetNull(null[0], null[1])
+		}
+		records, nulls = make([]model.Record, 0, batchSize), [][2]int{}
+		return fn(batch, types)
+	}
+	for rows.Next() {
+		if err := rows.Scan(scanDest...); err != nil {
+			return err
+		}
+		record := make(model.Record, len(values))
+		for i, v := range values {
+			record[i] = formatValue(v, types[i])
+			if v == nil {
+				nulls = append(nulls, [2]int{len(records), i})
+			}
+		}
+		records = append(records, record)
+
+		if len(records) == batchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if len(records) > 0 {
+		if err := flush(); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// formatValue converts the value scanned from the driver to a string.
+func formatValue(v any, ct model.ColumnType) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case []byte:
+		return string(v)
+	case string:
+		return v
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case time.Time:
+		if ct == model.ColumnTypeDate {
+			return v.Format(time.DateOnly)
+		}
+		return v.Format("2006-01-02 15:04:05.999999")
+	default:
+		return fmt.Sprint(v)
+	}
+}
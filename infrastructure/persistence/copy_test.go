@@ -0,0 +1,124 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/nao1215/sqluv/config"
+	"github.com/nao1215/sqluv/domain/model"
+)
+
+func TestRemoteQueryStreamerSQLite3(t *testing.T) {
+	t.Parallel()
+
+	srcPath := newTestSQLite3DB(t,
+		"CREATE TABLE item (id INTEGER, price NUMERIC, name TEXT, created_at DATETIME)",
+		"INSERT INTO item VALUES (1, 10.5, 'apple', '2024-01-02 03:04:05')",
+		"INSERT INTO item VALUES (2, NULL, 'banana', NULL)",
+		"INSERT INTO item VALUES (3, 3, NULL, '2024-12-31 23:59:59')",
+		"INSERT INTO item VALUES (4, 4, '', NULL)",
+	)
+	src, closeSrc, err := config.NewSQLite3DB(config.NewSQLite3Config(srcPath, true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(closeSrc)
+	dstPath := newTestSQLite3DB(t)
+	dst, closeDst, err := config.NewSQLite3DB(config.NewSQLite3Config(dstPath, false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(closeDst)
+
+	streamer := NewRemoteQueryStreamer(src, &config.DBConnection{Type: config.SQLite3, Database: srcPath, ReadOnly: true})
+	importer := NewRemoteTableImporter(dst, &config.DBConnection{Type: config.SQLite3, Database: dstPath})
+	ctx := context.Background()
+
+	query, err := model.NewSQL("SELECT id, price, name, created_at FROM item ORDER BY id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var plan *model.ImportPlan
+	batches := []int{}
+	err = streamer.StreamQuery(ctx, query, 2, func(batch *model.Table, types []model.ColumnType) error {
+		batches = append(batches, len(batch.Records()))
+		if plan == nil {
+			wantTypes := []model.ColumnType{
+				model.ColumnTypeInteger, model.ColumnTypeDecimal, model.ColumnTypeText, model.ColumnTypeTimestamp,
+			}
+			if diff := cmp.Diff(wantTypes, types); diff != "" {
+				t.Errorf("StreamQuery() types mismatch (-want +got):\n%s", diff)
+			}
+			if plan, err = importer.PlanImport(ctx, batch, "item_copy", types); err != nil {
+				return err
+			}
+		} else {
+			plan = plan.NextBatch(batch)
+		}
+		_, err := importer.Import(ctx, plan)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]int{2, 2}, batches); diff != "" {
+		t.Errorf("StreamQuery() batches mismatch (-want +got):\n%s", diff)
+	}
+
+	rows, err := (*sql.DB)(dst).Query("SELECT id, price, name, created_at FROM item_copy ORDER BY id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	got := [][]string{}
+	for rows.Next() {
+		var id int
+		var price, name, createdAt sql.NullString
+		if err := rows.Scan(&id, &price, &name, &createdAt); err != nil {
+			t.Fatal(err)
+		}
+		values := []string{}
+		for _, v := range []sql.NullString{price, name, createdAt} {
+			if !v.Valid {
+				v.String = "NULL"
+			}
+			values = append(values, v.String)
+		}
+		got = append(got, values)
+	}
+	want := [][]string{
+		{"10.5", "apple", "2024-01-02 03:04:05"},
+		{"NULL", "banana", "NULL"},
+		{"3", "NULL", "2024-12-31 23:59:59"},
+		{"4", "", "NULL"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("copied rows mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestRemoteQueryStreamerRejectsStatement(t *testing.T) {
+	t.Parallel()
+
+	path := newTestSQLite3DB(t, "CREATE TABLE item (id INTEGER)")
+	db, closeDB, err := config.NewSQLite3DB(config.NewSQLite3Config(path, false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(closeDB)
+
+	query, err := model.NewSQL("DELETE FROM item")
+	if err != nil {
+		t.Fatal(err)
+	}
+	streamer := NewRemoteQueryStreamer(db, &config.DBConnection{Type: config.SQLite3, Database: path})
+	err = streamer.StreamQuery(context.Background(), query, 10, func(*model.Table, []model.ColumnType) error {
+		t.Error("fn is called for a DELETE statement")
+		return nil
+	})
+	if err == nil {
+		t.Error("StreamQuery() returns no error for a DELETE statement")
+	}
+}
@@ -3,8 +3,10 @@ package persistence
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/nao1215/sqluv/config"
 	"github.com/nao1215/sqluv/domain/model"
@@ -16,29 +18,68 @@ import (
 var _ repository.QueryToRemoteExecutor = (*queryExecutor)(nil)
 
 type queryExecutor struct {
-	db *sql.DB
+	db       *sql.DB
+	dbmsType config.DBMSType
+	readOnly bool
 }
 
 // NewQueryExecutor returns queryExecutor
-func NewQueryExecutor(db config.DBMS) repository.QueryToRemoteExecutor {
-	return &queryExecutor{db: db}
+func NewQueryExecutor(db config.DBMS, conf *config.DBConnection) repository.QueryToRemoteExecutor {
+	return &queryExecutor{
+		db:       db,
+		dbmsType: conf.Type,
+		readOnly: conf.ReadOnly,
+	}
 }
 
-// ExecuteQuery executes query in a database
+// ExecuteQuery executes query in a database. If the connection is lost, e.g. the VPN drops or the database
+// restarts, a read query that is safe to run twice is executed again once with a new connection.
 func (e *queryExecutor) ExecuteQuery(ctx context.Context, sql *model.SQL) (*model.Table, error) {
-	tx, err := e.db.BeginTx(ctx, nil)
+	dialect := config.NewDialect(e.dbmsType).SQLDialect()
+	if e.readOnly && !sql.IsReadOnly(dialect) {
+		return nil, infrastructure.ErrReadOnlyConnection
+	}
+
+	table, err := e.executeQuery(ctx, sql)
+	if err != nil && sql.IsRetryable(dialect) && infrastructure.IsConnectionError(err) && ctx.Err() == nil {
+		// database/sql discards the broken connection, so the query is executed with a new one.
+		return e.executeQuery(ctx, sql)
+	}
+	return table, err
+}
+
+// executeQuery executes query in a transaction.
+func (e *queryExecutor) executeQuery(ctx context.Context, sql *model.SQL) (*model.Table, error) {
+	tx, err := beginTx(ctx, e.db, e.dbmsType, e.readOnly)
 	if err != nil {
 		return nil, err
 	}
 	defer tx.Rollback()
 
-	table, err := infrastructure.Query(ctx, tx, sql)
+	table, err := infrastructure.Query(ctx, tx, statement(e.dbmsType, sql))
 	if err != nil {
 		return nil, err
 	}
 	return model.NewTable(infrastructure.ExtractTableName(sql), table.Header(), table.Records()), nil
 }
 
+// statement returns the SQL to send to the database. Oracle Database rejects a SQL statement terminated
+// by a semicolon, so it is removed except for PL/SQL blocks, which end with "END;".
+func statement(dbmsType config.DBMSType, sql *model.SQL) *model.SQL {
+	if dbmsType != config.Oracle {
+		return sql
+	}
+	query := strings.TrimSpace(sql.String())
+	if !strings.HasSuffix(query, ";") || strings.HasSuffix(strings.ToUpper(query), "END;") {
+		return sql
+	}
+	trimmed, err := model.NewSQL(strings.TrimSpace(strings.TrimSuffix(query, ";")))
+	if err != nil {
+		return sql
+	}
+	return trimmed
+}
+
 // _ interface implementation check
 var _ repository.TablesInRemoteGetter = (*tablesGetter)(nil)
 
@@ -59,24 +100,32 @@ func NewTablesGetter(db config.DBMS, conf *config.DBConnection) repository.Table
 	}
 }
 
-// GetTables gets tables in the database and their columns.
+// GetTables gets tables in the current schema (or database) and their columns.
+// In SQL Server, tables in all schemas of the current database are returned.
+// In Oracle Database, the current schema is the schema of the user unless it is changed by the connection.
 func (g *tablesGetter) GetTables(ctx context.Context) ([]*model.Table, error) {
 	var err error
 	var query string
 	var rows *sql.Rows
 	switch g.dbmsType {
 	case config.MySQL:
-		query = "SELECT TABLE_NAME FROM information_schema.tables WHERE table_schema = ?"
+		query = "SELECT TABLE_SCHEMA, TABLE_NAME FROM information_schema.tables WHERE table_schema = ? AND table_type = 'BASE TABLE' ORDER BY TABLE_NAME"
 		rows, err = g.db.QueryContext(ctx, query, g.database)
 	case config.PostgreSQL:
-		query = "SELECT table_name FROM information_schema.tables WHERE table_schema = $1 OR table_schema = 'public'"
-		rows, err = g.db.QueryContext(ctx, query, g.user)
+		query = "SELECT table_schema, table_name FROM information_schema.tables WHERE table_schema = current_schema() AND table_type = 'BASE TABLE' ORDER BY table_name"
+		rows, err = g.db.QueryContext(ctx, query)
 	case config.SQLite3:
-		query = "SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%'"
+		query = "SELECT '', name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%'"
 		rows, err = g.db.QueryContext(ctx, query)
 	case config.SQLServer:
-		query = "SELECT TABLE_NAME FROM INFORMATION_SCHEMA.TABLES WHERE TABLE_CATALOG = ?"
-		rows, err = g.db.QueryContext(ctx, query, g.database)
+		query = "SELECT TABLE_SCHEMA, TABLE_NAME FROM INFORMATION_SCHEMA.TABLES WHERE TABLE_TYPE = 'BASE TABLE' ORDER BY TABLE_SCHEMA, TABLE_NAME"
+		rows, err = g.db.QueryContext(ctx, query)
+	case config.Oracle:
+		// Tables in the recycle bin, nested tables and secondary tables of domain indexes are not listed.
+		query = `SELECT OWNER, TABLE_NAME FROM ALL_TABLES
+            WHERE OWNER = SYS_CONTEXT('USERENV', 'CURRENT_SCHEMA') AND DROPPED = 'NO' AND NESTED = 'NO' AND SECONDARY = 'N'
+            ORDER BY TABLE_NAME`
+		rows, err = g.db.QueryContext(ctx, query)
 	default:
 		return nil, fmt.Errorf("unsupported dbms type: %v", g.dbmsType)
 	}
@@ -87,17 +136,18 @@ func (g *tablesGetter) GetTables(ctx context.Context) ([]*model.Table, error) {
 
 	tables := []*model.Table{}
 	for rows.Next() {
-		var tableName string
-		if err := rows.Scan(&tableName); err != nil {
+		var schema, tableName string
+		if err := rows.Scan(&schema, &tableName); err != nil {
 			return nil, err
 		}
 
-		columns, err := g.getColumns(ctx, tableName)
+		columns, err := g.getColumns(ctx, schema, tableName)
 		if err != nil {
 			return nil, err
 		}
 		header := model.NewHeader(columns)
 		tbl := model.NewTable(tableName, header, []model.Record{})
+		tbl.SetSchema(schema)
 		tables = append(tables, tbl)
 	}
 	if err = rows.Err(); err != nil {
@@ -107,24 +157,29 @@ func (g *tablesGetter) GetTables(ctx context.Context) ([]*model.Table, error) {
 }
 
 // getColumns retrieves the column names for a given table.
-func (g *tablesGetter) getColumns(ctx context.Context, tableName string) ([]string, error) {
+func (g *tablesGetter) getColumns(ctx context.Context, schema, tableName string) ([]string, error) {
 	var columnQuery string
 	var colRows *sql.Rows
 	var err error
 
 	switch g.dbmsType {
 	case config.MySQL:
-		columnQuery = "SELECT column_name FROM information_schema.columns WHERE table_schema = ? AND table_name = ?"
-		colRows, err = g.db.QueryContext(ctx, columnQuery, g.database, tableName)
+		columnQuery = "SELECT column_name FROM information_schema.columns WHERE table_schema = ? AND table_name = ? ORDER BY ordinal_position"
+		colRows, err = g.db.QueryContext(ctx, columnQuery, schema, tableName)
 	case config.PostgreSQL:
-		columnQuery = "SELECT column_name FROM information_schema.columns WHERE table_schema = $1 AND table_name = $2"
-		colRows, err = g.db.QueryContext(ctx, columnQuery, g.user, tableName)
+		columnQuery = "SELECT column_name FROM information_schema.columns WHERE table_schema = $1 AND table_name = $2 ORDER BY ordinal_position"
+		colRows, err = g.db.QueryContext(ctx, columnQuery, schema, tableName)
 	case config.SQLite3:
-		columnQuery = fmt.Sprintf("PRAGMA table_info(%s)", tableName)
-		colRows, err = g.db.QueryContext(ctx, columnQuery)
+		// The table name is a bind parameter of pragma_table_info, so that a name with quotes or
+		// parentheses, e.g. the name of an imported file, is never parsed as SQL.
+		columnQuery = "SELECT name FROM pragma_table_info(?) ORDER BY cid"
+		colRows, err = g.db.QueryContext(ctx, columnQuery, tableName)
 	case config.SQLServer:
-		columnQuery = "SELECT COLUMN_NAME FROM INFORMATION_SCHEMA.COLUMNS WHERE TABLE_CATALOG = ? AND TABLE_NAME = ?"
-		colRows, err = g.db.QueryContext(ctx, columnQuery, g.database, tableName)
+		columnQuery = "SELECT COLUMN_NAME FROM INFORMATION_SCHEMA.COLUMNS WHERE TABLE_SCHEMA = @p1 AND TABLE_NAME = @p2 ORDER BY ORDINAL_POSITION"
+		colRows, err = g.db.QueryContext(ctx, columnQuery, schema, tableName)
+	case config.Oracle:
+		columnQuery = "SELECT COLUMN_NAME FROM ALL_TAB_COLUMNS WHERE OWNER = :1 AND TABLE_NAME = :2 ORDER BY COLUMN_ID"
+		colRows, err = g.db.QueryContext(ctx, columnQuery, schema, tableName)
 	default:
 		return nil, fmt.Errorf("unsupported dbms type: %v", g.dbmsType)
 	}
@@ -134,26 +189,12 @@ func (g *tablesGetter) getColumns(ctx context.Context, tableName string) ([]stri
 	defer colRows.Close()
 
 	columns := []string{}
-	switch g.dbmsType {
-	case config.SQLite3:
-		var cid int
-		var name, colType string
-		var notnull, pk int
-		var dfltValue sql.NullString
-		for colRows.Next() {
-			if err := colRows.Scan(&cid, &name, &colType, &notnull, &dfltValue, &pk); err != nil {
-				return nil, err
-			}
-			columns = append(columns, name)
-		}
-	default:
-		var colName string
-		for colRows.Next() {
-			if err := colRows.Scan(&colName); err != nil {
-				return nil, err
-			}
-			columns = append(columns, colName)
+	var colName string
+	for colRows.Next() {
+		if err := colRows.Scan(&colName); err != nil {
+			return nil, err
 		}
+		columns = append(columns, colName)
 	}
 	if err = colRows.Err(); err != nil {
 		return nil, err
@@ -165,23 +206,33 @@ func (g *tablesGetter) getColumns(ctx context.Context, tableName string) ([]stri
 var _ repository.StatementToRemoteExecutor = (*statementExecutor)(nil)
 
 type statementExecutor struct {
-	db *sql.DB
+	db       *sql.DB
+	dbmsType config.DBMSType
+	readOnly bool
 }
 
 // NewStatementExecutor return statementExecutor
-func NewStatementExecutor(db config.DBMS) repository.StatementToRemoteExecutor {
-	return &statementExecutor{db: db}
+func NewStatementExecutor(db config.DBMS, conf *config.DBConnection) repository.StatementToRemoteExecutor {
+	return &statementExecutor{
+		db:       db,
+		dbmsType: conf.Type,
+		readOnly: conf.ReadOnly,
+	}
 }
 
 // ExecuteStatement execute statement
 func (e *statementExecutor) ExecuteStatement(ctx context.Context, sql *model.SQL) (int64, error) {
-	tx, err := e.db.BeginTx(ctx, nil)
+	if e.readOnly && !sql.IsReadOnly(config.NewDialect(e.dbmsType).SQLDialect()) {
+		return 0, infrastructure.ErrReadOnlyConnection
+	}
+
+	tx, err := beginTx(ctx, e.db, e.dbmsType, e.readOnly)
 	if err != nil {
 		return 0, err
 	}
 	defer tx.Rollback()
 
-	result, err := tx.ExecContext(ctx, sql.String())
+	result, err := tx.ExecContext(ctx, statement(e.dbmsType, sql).String())
 	if err != nil {
 		return 0, err
 	}
@@ -192,6 +243,27 @@ func (e *statementExecutor) ExecuteStatement(ctx context.Context, sql *model.SQL
 	return result.RowsAffected()
 }
 
+// beginTx starts a transaction that is read-only if the connection is read-only. PostgreSQL, MySQL and
+// SQLite3 use read-only transactions of the driver, and Oracle Database uses SET TRANSACTION READ ONLY.
+// The SQL Server driver has no read-only transaction, so the statement classification is the only guard
+// for it; the account of a read-only SQL Server connection should only be a db_datareader.
+func beginTx(ctx context.Context, db *sql.DB, dbmsType config.DBMSType, readOnly bool) (*sql.Tx, error) {
+	var opts *sql.TxOptions
+	if readOnly && dbmsType != config.SQLServer && dbmsType != config.Oracle {
+		opts = &sql.TxOptions{ReadOnly: true}
+	}
+	tx, err := db.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	if readOnly && dbmsType == config.Oracle {
+		if _, err := tx.ExecContext(ctx, "SET TRANSACTION READ ONLY"); err != nil {
+			return nil, errors.Join(err, tx.Rollback())
+		}
+	}
+	return tx, nil
+}
+
 type ddlGetter struct {
 	db       *sql.DB
 	dbmsType config.DBMSType
@@ -224,26 +296,72 @@ func (d *ddlGetter) GetTableDDL(ctx context.Context, tableName string) ([]*model
             SELECT COLUMN_NAME, DATA_TYPE, IFNULL(CHARACTER_MAXIMUM_LENGTH, 0),
                    IS_NULLABLE, IFNULL(COLUMN_DEFAULT, ''), COLUMN_KEY
             FROM information_schema.columns
-            WHERE table_schema=? AND table_name=?`
+            WHERE table_schema=? AND table_name=?
+            ORDER BY ORDINAL_POSITION`
 		rows, err = d.db.QueryContext(ctx, query, d.database, tableName)
 	case config.PostgreSQL:
 		query = `
-            SELECT column_name, data_type, COALESCE(character_maximum_length, 0),
-                   is_nullable, COALESCE(column_default, ''), ''
-            FROM information_schema.columns
-            WHERE table_schema='public' OR table_schema=$1 AND table_name=$2::text`
-		rows, err = d.db.QueryContext(ctx, query, d.user, tableName)
+            SELECT c.column_name, c.data_type, COALESCE(c.character_maximum_length, 0),
+                   c.is_nullable, COALESCE(c.column_default, ''),
+                   CASE WHEN EXISTS (
+                       SELECT 1
+                       FROM information_schema.table_constraints tc
+                       JOIN information_schema.key_column_usage kcu
+                         ON tc.constraint_schema = kcu.constraint_schema
+                        AND tc.constraint_name = kcu.constraint_name
+                       WHERE tc.constraint_type = 'PRIMARY KEY'
+                         AND tc.table_schema = c.table_schema
+                         AND tc.table_name = c.table_name
+                         AND kcu.column_name = c.column_name
+                   ) THEN 'PRI' ELSE '' END
+            FROM information_schema.columns c
+            WHERE c.table_schema=current_schema() AND c.table_name=$1
+            ORDER BY c.ordinal_position`
+		rows, err = d.db.QueryContext(ctx, query, tableName)
 	case config.SQLite3:
-		// PRAGMA table_info returns: cid, name, type, notnull, dflt_value, pk
-		query = "PRAGMA table_info(" + tableName + ")"
-		rows, err = d.db.QueryContext(ctx, query)
+		// pragma_table_info returns: cid, name, type, notnull, dflt_value, pk
+		query = `SELECT cid, name, type, "notnull", dflt_value, pk FROM pragma_table_info(?) ORDER BY cid`
+		rows, err = d.db.QueryContext(ctx, query, tableName)
 	case config.SQLServer:
 		query = `
-            SELECT COLUMN_NAME, DATA_TYPE, ISNULL(CHARACTER_MAXIMUM_LENGTH, 0),
-                   IS_NULLABLE, ISNULL(COLUMN_DEFAULT, ''), '' as column_key
-            FROM INFORMATION_SCHEMA.COLUMNS 
-            WHERE TABLE_CATALOG=? AND TABLE_NAME=?`
-		rows, err = d.db.QueryContext(ctx, query, d.database, tableName)
+            SELECT c.COLUMN_NAME, c.DATA_TYPE, ISNULL(c.CHARACTER_MAXIMUM_LENGTH, 0),
+                   c.IS_NULLABLE, ISNULL(c.COLUMN_DEFAULT, ''),
+                   CASE WHEN EXISTS (
+                       SELECT 1
+                       FROM INFORMATION_SCHEMA.TABLE_CONSTRAINTS tc
+                       JOIN INFORMATION_SCHEMA.KEY_COLUMN_USAGE kcu
+                         ON tc.CONSTRAINT_SCHEMA = kcu.CONSTRAINT_SCHEMA
+                        AND tc.CONSTRAINT_NAME = kcu.CONSTRAINT_NAME
+                       WHERE tc.CONSTRAINT_TYPE = 'PRIMARY KEY'
+                         AND tc.TABLE_SCHEMA = c.TABLE_SCHEMA
+                         AND tc.TABLE_NAME = c.TABLE_NAME
+                         AND kcu.COLUMN_NAME = c.COLUMN_NAME
+                   ) THEN 'PRI' ELSE '' END AS column_key
+            FROM INFORMATION_SCHEMA.COLUMNS c
+            WHERE OBJECT_ID(QUOTENAME(c.TABLE_SCHEMA) + '.' + QUOTENAME(c.TABLE_NAME)) = OBJECT_ID(@p1)
+            ORDER BY c.ORDINAL_POSITION`
+		rows, err = d.db.QueryContext(ctx, query, tableName)
+	case config.Oracle:
+		// DATA_DEFAULT is LONG, which cannot be passed to NVL, and an empty string is NULL in Oracle Database,
+		// so the default value and the key are scanned as nullable strings.
+		query = `
+            SELECT c.COLUMN_NAME, c.DATA_TYPE, CASE WHEN c.CHAR_USED IS NULL THEN 0 ELSE c.CHAR_LENGTH END,
+                   CASE c.NULLABLE WHEN 'Y' THEN 'YES' ELSE 'NO' END, c.DATA_DEFAULT,
+                   CASE WHEN EXISTS (
+                       SELECT 1
+                       FROM ALL_CONSTRAINTS tc
+                       JOIN ALL_CONS_COLUMNS kcu
+                         ON tc.OWNER = kcu.OWNER
+                        AND tc.CONSTRAINT_NAME = kcu.CONSTRAINT_NAME
+                       WHERE tc.CONSTRAINT_TYPE = 'P'
+                         AND tc.OWNER = c.OWNER
+                         AND tc.TABLE_NAME = c.TABLE_NAME
+                         AND kcu.COLUMN_NAME = c.COLUMN_NAME
+                   ) THEN 'PRI' END
+            FROM ALL_TAB_COLUMNS c
+            WHERE c.OWNER = SYS_CONTEXT('USERENV', 'CURRENT_SCHEMA') AND c.TABLE_NAME = :1
+            ORDER BY c.COLUMN_ID`
+		rows, err = d.db.QueryContext(ctx, query, tableName)
 	default:
 		return nil, fmt.Errorf("unsupported DBMS type: %v", d.dbmsType)
 	}
@@ -282,6 +400,16 @@ func (d *ddlGetter) GetTableDDL(ctx context.Context, tableName string) ([]*model
 			records = append(records, model.NewRecord([]string{
 				colName, colType, "0", nullable, dfltValue, key,
 			}))
+		case config.Oracle:
+			var colName, dataType, nullable string
+			var precision int
+			var dflt, columnKey sql.NullString
+			if err := rows.Scan(&colName, &dataType, &precision, &nullable, &dflt, &columnKey); err != nil {
+				return nil, err
+			}
+			records = append(records, model.NewRecord([]string{
+				colName, dataType, strconv.Itoa(precision), nullable, strings.TrimSpace(dflt.String), columnKey.String,
+			}))
 		default:
 			var colName, dataType, nullable, dfltValue, columnKey string
 			var precision int
@@ -0,0 +1,471 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net"
+	"path/filepath"
+	"sync/atomic"
+	"syscall"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/nao1215/sqluv/config"
+	"github.com/nao1215/sqluv/domain/model"
+	"github.com/nao1215/sqluv/infrastructure"
+	"github.com/nao1215/sqluv/internal/testutil"
+	"modernc.org/sqlite"
+)
+
+// newTestSQLite3DB creates a SQLite3 database file with the given statements.
+func newTestSQLite3DB(t *testing.T, statements ...string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "test.db")
+	db, closeDB, err := config.NewSQLite3DB(config.NewSQLite3Config(path, false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer closeDB()
+
+	for _, stmt := range statements {
+		if _, err := (*sql.DB)(db).Exec(stmt); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return path
+}
+
+func TestReadOnlyConnection(t *testing.T) {
+	t.Parallel()
+
+	path := newTestSQLite3DB(t,
+		"CREATE TABLE user (id INTEGER, name TEXT)",
+		"INSERT INTO user VALUES (1, 'gina')",
+	)
+	conn := &config.DBConnection{Type: config.SQLite3, Database: path, ReadOnly: true}
+	db, closeDB, err := config.NewSQLite3DB(config.NewSQLite3Config(path, true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(closeDB)
+
+	t.Run("select is allowed", func(t *testing.T) {
+		t.Parallel()
+
+		sql, err := model.NewSQL("SELECT * FROM user")
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := NewQueryExecutor(db, conn).ExecuteQuery(t.Context(), sql)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got.Records()) != 1 {
+			t.Errorf("want 1 record, got %d", len(got.Records()))
+		}
+	})
+
+	t.Run("modifications are rejected", func(t *testing.T) {
+		t.Parallel()
+
+		for _, query := range []string{
+			"DELETE FROM user",
+			"UPDATE user SET name = 'a' WHERE id = 1",
+			"DROP TABLE user",
+			"GRANT SELECT ON user TO public",
+		} {
+			sql, err := model.NewSQL(query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := NewStatementExecutor(db, conn).ExecuteStatement(t.Context(), sql); !errors.Is(err, infrastructure.ErrReadOnlyConnection) {
+				t.Errorf("%s: want ErrReadOnlyConnection, got %v", query, err)
+			}
+		}
+	})
+
+	t.Run("data-modifying CTE is rejected by the database", func(t *testing.T) {
+		t.Parallel()
+
+		sql, err := model.NewSQL("WITH x AS (SELECT 1) INSERT INTO user SELECT 2, 'b' FROM x")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := NewStatementExecutor(db, conn).ExecuteStatement(t.Context(), sql); err == nil {
+			t.Error("want error, got nil")
+		}
+	})
+}
+
+func TestSchemaGetterSQLite3(t *testing.T) {
+	t.Parallel()
+
+	path := newTestSQLite3DB(t,
+		"CREATE TABLE user (id INTEGER PRIMARY KEY, email TEXT UNIQUE, name TEXT)",
+		"CREATE TABLE post (id INTEGER, user_id INTEGER, title TEXT, FOREIGN KEY (user_id) REFERENCES user(id))",
+		"CREATE INDEX idx_post_user ON post (user_id, title)",
+		"CREATE VIEW user_name AS SELECT name FROM user",
+		"CREATE TRIGGER trg_post_insert AFTER INSERT ON post BEGIN SELECT 1; END",
+		"CREATE TRIGGER trg_post_delete DELETE ON post BEGIN SELECT 1; END",
+	)
+	conn := &config.DBConnection{Type: config.SQLite3, Database: path}
+	db, closeDB, err := config.NewSQLite3DB(config.NewSQLite3Config(path, false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(closeDB)
+
+	t.Run("get views", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := NewObjectsGetter(db, conn).GetObjects(t.Context(), model.ObjectTypeView)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]string{"user_name"}, got); diff != "" {
+			t.Errorf("mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("SQLite3 has no sequences", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := NewObjectsGetter(db, conn).GetObjects(t.Context(), model.ObjectTypeSequence)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != 0 {
+			t.Errorf("want no sequences, got %v", got)
+		}
+	})
+
+	t.Run("get indexes", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := NewIndexesGetter(db, conn).GetIndexes(t.Context(), "post")
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []*model.Index{
+			{Name: "idx_post_user", Columns: []string{"user_id", "title"}},
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("get unique indexes", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := NewIndexesGetter(db, conn).GetIndexes(t.Context(), "user")
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []*model.Index{
+			{Name: "sqlite_autoindex_user_1", Columns: []string{"email"}, Unique: true},
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("get foreign keys", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := NewForeignKeysGetter(db, conn).GetForeignKeys(t.Context(), "post")
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []*model.ForeignKey{
+			{Columns: []string{"user_id"}, RefTable: "user", RefColumns: []string{"id"}},
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("get triggers", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := NewTriggersGetter(db, conn).GetTriggers(t.Context(), "post")
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []*model.Trigger{
+			{Name: "trg_post_delete", Timing: "BEFORE", Events: []string{"DELETE"}},
+			{Name: "trg_post_insert", Timing: "AFTER", Events: []string{"INSERT"}},
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestTablesGetterSQLite3(t *testing.T) {
+	t.Parallel()
+
+	path := newTestSQLite3DB(t,
+		"CREATE TABLE user (id INTEGER, name TEXT)",
+		"CREATE VIEW user_name AS SELECT name FROM user",
+	)
+	conn := &config.DBConnection{Type: config.SQLite3, Database: path}
+	db, closeDB, err := config.NewSQLite3DB(config.NewSQLite3Config(path, false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(closeDB)
+
+	got, err := NewTablesGetter(db, conn).GetTables(t.Context())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*model.Table{
+		model.NewTable("user", model.NewHeader([]string{"id", "name"}), []model.Record{}),
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	schemas, err := NewSchemasGetter(db, conn).GetSchemas(t.Context())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(schemas) != 0 {
+		t.Errorf("SQLite3 has no schemas, got %v", schemas)
+	}
+}
+
+func TestCatalogSQLite3WithHostileTableNames(t *testing.T) {
+	t.Parallel()
+
+	names := testutil.HostileTableNames()
+	stmts := []string{"CREATE TABLE victim (id INTEGER)"}
+	for _, name := range names {
+		stmts = append(stmts, "CREATE TABLE "+config.NewDialect(config.SQLite3).QuoteIdentifier(name)+" (id INTEGER PRIMARY KEY, name TEXT NOT NULL)")
+	}
+	path := newTestSQLite3DB(t, stmts...)
+	conn := &config.DBConnection{Type: config.SQLite3, Database: path}
+	db, closeDB, err := config.NewSQLite3DB(config.NewSQLite3Config(path, false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(closeDB)
+
+	tables, err := NewTablesGetter(db, conn).GetTables(t.Context())
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]model.Header{}
+	for _, table := range tables {
+		got[table.Name()] = table.Header()
+	}
+	want := map[string]model.Header{"victim": model.NewHeader([]string{"id"})}
+	for _, name := range names {
+		want[name] = model.NewHeader([]string{"id", "name"})
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("tables mismatch (-want +got):\n%s", diff)
+	}
+
+	for _, name := range names {
+		t.Run(name, func(t *testing.T) {
+			ddl, err := NewTableDDLGetter(db, conn).GetTableDDL(t.Context(), name)
+			if err != nil {
+				t.Fatalf("failed to get DDL of %q: %v", name, err)
+			}
+			want := []model.Record{
+				{"id", "INTEGER", "0", "YES", "", "PRI"},
+				{"name", "TEXT", "0", "NO", "", ""},
+			}
+			if diff := cmp.Diff(want, ddl[0].Records()); diff != "" {
+				t.Errorf("DDL of %q mismatch (-want +got):\n%s", name, diff)
+			}
+		})
+	}
+}
+
+func TestCreateTableStatementGetterSQLite3(t *testing.T) {
+	t.Parallel()
+
+	path := newTestSQLite3DB(t,
+		"CREATE TABLE user (id INTEGER PRIMARY KEY, email TEXT UNIQUE, name TEXT DEFAULT 'anonymous')",
+		"CREATE INDEX idx_user_name ON user (name)",
+		"CREATE VIEW user_name AS SELECT name FROM user",
+	)
+	conn := &config.DBConnection{Type: config.SQLite3, Database: path}
+	db, closeDB, err := config.NewSQLite3DB(config.NewSQLite3Config(path, false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(closeDB)
+
+	tests := []struct {
+		name      string
+		tableName string
+		want      string
+		wantErr   error
+	}{
+		{
+			name:      "table with index",
+			tableName: "user",
+			want: "CREATE TABLE user (id INTEGER PRIMARY KEY, email TEXT UNIQUE, name TEXT DEFAULT 'anonymous');\n\n" +
+				"CREATE INDEX idx_user_name ON user (name);",
+		},
+		{
+			name:      "view",
+			tableName: "user_name",
+			want:      "CREATE VIEW user_name AS SELECT name FROM user;",
+		},
+		{
+			name:      "table not found",
+			tableName: "not_exist",
+			wantErr:   infrastructure.ErrTableNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := NewCreateTableStatementGetter(db, conn).GetCreateTableStatement(t.Context(), tt.tableName)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want error %v, got %v", tt.wantErr, err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// flakyConnector is a connector of a SQLite3 database file that fails to connect as many times as failures,
+// like a database behind a dropped VPN.
+type flakyConnector struct {
+	path     string
+	failures atomic.Int32
+}
+
+// Connect returns a connection reset error while failures remain.
+func (c *flakyConnector) Connect(_ context.Context) (driver.Conn, error) {
+	if c.failures.Add(-1) >= 0 {
+		return nil, &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}
+	}
+	return c.Driver().Open(c.path)
+}
+
+// Driver returns the SQLite3 driver.
+func (c *flakyConnector) Driver() driver.Driver {
+	return &sqlite.Driver{}
+}
+
+func TestQueryExecutorRetry(t *testing.T) {
+	t.Parallel()
+
+	path := newTestSQLite3DB(t,
+		"CREATE TABLE user (id INTEGER, name TEXT)",
+		"INSERT INTO user VALUES (1, 'gina')",
+	)
+
+	tests := []struct {
+		name     string
+		query    string
+		failures int32
+		wantErr  bool
+	}{
+		{
+			name:     "read query is retried",
+			query:    "SELECT * FROM user",
+			failures: 1,
+		},
+		{
+			name:     "read query is retried only once",
+			query:    "SELECT * FROM user",
+			failures: 2,
+			wantErr:  true,
+		},
+		{
+			name:     "data-modifying CTE is not retried",
+			query:    "WITH x AS (SELECT 1) INSERT INTO user SELECT 2, 'b' FROM x",
+			failures: 1,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			connector := &flakyConnector{path: path}
+			connector.failures.Store(tt.failures)
+			db := sql.OpenDB(connector)
+			t.Cleanup(func() { db.Close() })
+
+			query, err := model.NewSQL(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			executor := NewQueryExecutor(db, &config.DBConnection{Type: config.SQLite3, Database: path})
+			_, err = executor.ExecuteQuery(t.Context(), query)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ExecuteQuery() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !infrastructure.IsConnectionError(err) {
+				t.Errorf("ExecuteQuery() error = %v, want a connection error", err)
+			}
+		})
+	}
+}
+
+func TestStatement(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		dbmsType config.DBMSType
+		query    string
+		want     string
+	}{
+		{name: "Oracle SQL", dbmsType: config.Oracle, query: "SELECT * FROM dual; \n", want: "SELECT * FROM dual"},
+		{name: "Oracle PL/SQL block", dbmsType: config.Oracle, query: "BEGIN\n  NULL;\nEND;", want: "BEGIN\n  NULL;\nEND;"},
+		{name: "Oracle without a semicolon", dbmsType: config.Oracle, query: "SELECT 1 FROM dual", want: "SELECT 1 FROM dual"},
+		{name: "other DBMS", dbmsType: config.PostgreSQL, query: "SELECT 1;", want: "SELECT 1;"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			sql, err := model.NewSQL(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := statement(tt.dbmsType, sql).String(); got != tt.want {
+				t.Errorf("statement() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOracleTrigger(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		triggerType     string
+		triggeringEvent string
+		want            *model.Trigger
+	}{
+		{"BEFORE EACH ROW", "INSERT OR UPDATE", &model.Trigger{Name: "trg", Timing: "BEFORE", Events: []string{"INSERT", "UPDATE"}}},
+		{"AFTER STATEMENT", "DELETE", &model.Trigger{Name: "trg", Timing: "AFTER", Events: []string{"DELETE"}}},
+		{"INSTEAD OF", "UPDATE", &model.Trigger{Name: "trg", Timing: "INSTEAD OF", Events: []string{"UPDATE"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.triggerType, func(t *testing.T) {
+			t.Parallel()
+
+			got := oracleTrigger("trg", tt.triggerType, tt.triggeringEvent)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("oracleTrigger() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
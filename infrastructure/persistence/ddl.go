@@ -0,0 +1,342 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/nao1215/sqluv/config"
+	"github.com/nao1215/sqluv/domain/repository"
+	"github.com/nao1215/sqluv/infrastructure"
+)
+
+// _ interface implementation check
+var _ repository.CreateTableStatementInRemoteGetter = (*createTableStatementGetter)(nil)
+
+// createTableStatementGetter builds CREATE statements of tables and views.
+// MySQL and SQLite3 keep the statement, PostgreSQL and SQL Server statements are
+// reconstructed from the system catalog, and Oracle Database statements are generated by DBMS_METADATA.
+type createTableStatementGetter struct {
+	*schemaGetter
+}
+
+// NewCreateTableStatementGetter returns CreateTableStatementInRemoteGetter.
+func NewCreateTableStatementGetter(db config.DBMS, conf *config.DBConnection) repository.CreateTableStatementInRemoteGetter {
+	return &createTableStatementGetter{
+		schemaGetter: newSchemaGetter(db, conf),
+	}
+}
+
+// GetCreateTableStatement gets the CREATE statement of the table (or view) with its indexes.
+// Statements are terminated by a semicolon and separated by a blank line.
+func (g *createTableStatementGetter) GetCreateTableStatement(ctx context.Context, tableName string) (string, error) {
+	var stmts []string
+	var err error
+	switch g.dbmsType {
+	case config.MySQL:
+		stmts, err = g.mysqlStatements(ctx, tableName)
+	case config.PostgreSQL:
+		stmts, err = g.postgresStatements(ctx, tableName)
+	case config.SQLite3:
+		stmts, err = g.sqliteStatements(ctx, tableName)
+	case config.SQLServer:
+		stmts, err = g.sqlserverStatements(ctx, tableName)
+	case config.Oracle:
+		stmts, err = g.oracleStatements(ctx, tableName)
+	default:
+		return "", fmt.Errorf("unsupported dbms type: %v", g.dbmsType)
+	}
+	if err != nil {
+		return "", err
+	}
+	if len(stmts) == 0 {
+		return "", fmt.Errorf("%w: %s", infrastructure.ErrTableNotFound, tableName)
+	}
+	return joinStatements(stmts), nil
+}
+
+// mysqlStatements returns the result of SHOW CREATE TABLE. It also works for views.
+func (g *createTableStatementGetter) mysqlStatements(ctx context.Context, tableName string) ([]string, error) {
+	query := "SHOW CREATE TABLE " + config.NewDialect(config.MySQL).QuoteIdentifier(tableName)
+	rows, err := g.queryStrings(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	stmts := make([]string, 0, len(rows))
+	for _, row := range rows {
+		stmts = append(stmts, row[1])
+	}
+	return stmts, nil
+}
+
+// sqliteStatements returns the statements stored in sqlite_master: the table, its indexes and triggers.
+// Automatically created indexes have no statement, so they are skipped.
+func (g *createTableStatementGetter) sqliteStatements(ctx context.Context, tableName string) ([]string, error) {
+	query := `
+        SELECT sql FROM sqlite_master
+        WHERE tbl_name = ? AND sql IS NOT NULL
+        ORDER BY CASE type WHEN 'table' THEN 0 WHEN 'view' THEN 0 WHEN 'index' THEN 1 ELSE 2 END, name`
+	rows, err := g.queryStrings(ctx, query, tableName)
+	if err != nil {
+		return nil, err
+	}
+	stmts := make([]string, 0, len(rows))
+	for _, row := range rows {
+		stmts = append(stmts, row[0])
+	}
+	return stmts, nil
+}
+
+// oracleStatements returns the statements of the table (or view) and its indexes generated by DBMS_METADATA.
+// The indexes of the primary key and unique constraints are created by the table, so they are skipped.
+// The transform parameters apply to the session, so the statements run on one connection.
+func (g *createTableStatementGetter) oracleStatements(ctx context.Context, tableName string) ([]string, error) {
+	conn, err := g.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	// The storage and tablespace clauses are omitted, so that the statements can be run in another database.
+	if _, err := conn.ExecContext(ctx,
+		"BEGIN DBMS_METADATA.SET_TRANSFORM_PARAM(DBMS_METADATA.SESSION_TRANSFORM, 'SEGMENT_ATTRIBUTES', FALSE); END;"); err != nil {
+		return nil, err
+	}
+	defer conn.ExecContext(context.Background(), //nolint:errcheck // the connection is returned to the pool anyway
+		"BEGIN DBMS_METADATA.SET_TRANSFORM_PARAM(DBMS_METADATA.SESSION_TRANSFORM, 'DEFAULT'); END;")
+
+	rows, err := conn.QueryContext(ctx, `
+        SELECT DBMS_METADATA.GET_DDL(o.OBJECT_TYPE, o.OBJECT_NAME, o.OWNER)
+        FROM ALL_OBJECTS o
+        WHERE o.OWNER = SYS_CONTEXT('USERENV', 'CURRENT_SCHEMA') AND o.OBJECT_NAME = :1 AND o.OBJECT_TYPE IN ('TABLE', 'VIEW')
+        UNION ALL
+        SELECT DBMS_METADATA.GET_DDL('INDEX', i.INDEX_NAME, i.OWNER)
+        FROM ALL_INDEXES i
+        WHERE i.TABLE_OWNER = SYS_CONTEXT('USERENV', 'CURRENT_SCHEMA') AND i.TABLE_NAME = :2 AND i.INDEX_TYPE <> 'LOB'
+          AND NOT EXISTS (
+              SELECT 1 FROM ALL_CONSTRAINTS c
+              WHERE c.OWNER = i.TABLE_OWNER AND c.INDEX_NAME = i.INDEX_NAME AND c.CONSTRAINT_TYPE IN ('P', 'U')
+          )`, tableName, tableName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stmts := []string{}
+	for rows.Next() {
+		var stmt string
+		if err := rows.Scan(&stmt); err != nil {
+			return nil, err
+		}
+		stmts = append(stmts, stmt)
+	}
+	return stmts, rows.Err()
+}
+
+// postgresStatements reconstructs CREATE TABLE from pg_catalog. Constraints are rendered by
+// pg_get_constraintdef, and indexes that do not back a constraint by pg_get_indexdef.
+func (g *createTableStatementGetter) postgresStatements(ctx context.Context, tableName string) ([]string, error) {
+	rows, err := g.queryStrings(ctx, `
+        SELECT c.oid, c.relkind, quote_ident(n.nspname) || '.' || quote_ident(c.relname)
+        FROM pg_catalog.pg_class c
+        JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+        WHERE n.nspname = current_schema() AND c.relname = $1`, tableName)
+	if err != nil || len(rows) == 0 {
+		return nil, err
+	}
+	oid, relkind, name := rows[0][0], rows[0][1], rows[0][2]
+
+	switch relkind {
+	case "v", "m":
+		view := "VIEW"
+		if relkind == "m" {
+			view = "MATERIALIZED VIEW"
+		}
+		def, err := g.queryStrings(ctx, "SELECT pg_get_viewdef($1::oid, true)", oid)
+		if err != nil {
+			return nil, err
+		}
+		return []string{fmt.Sprintf("CREATE %s %s AS\n%s", view, name, def[0][0])}, nil
+	}
+
+	columns, err := g.queryStrings(ctx, `
+        SELECT quote_ident(a.attname), pg_catalog.format_type(a.atttypid, a.atttypmod), a.attnotnull,
+               COALESCE(pg_get_expr(d.adbin, d.adrelid), ''), a.attidentity, a.attgenerated
+        FROM pg_catalog.pg_attribute a
+        LEFT JOIN pg_catalog.pg_attrdef d ON d.adrelid = a.attrelid AND d.adnum = a.attnum
+        WHERE a.attrelid = $1::oid AND a.attnum > 0 AND NOT a.attisdropped
+        ORDER BY a.attnum`, oid)
+	if err != nil {
+		return nil, err
+	}
+	lines := make([]string, 0, len(columns))
+	for _, col := range columns {
+		line := col[0] + " " + col[1]
+		switch {
+		case col[5] == "s":
+			line += fmt.Sprintf(" GENERATED ALWAYS AS (%s) STORED", col[3])
+		case col[4] == "a":
+			line += " GENERATED ALWAYS AS IDENTITY"
+		case col[4] == "d":
+			line += " GENERATED BY DEFAULT AS IDENTITY"
+		case col[3] != "":
+			line += " DEFAULT " + col[3]
+		}
+		if isTrue(col[2]) {
+			line += " NOT NULL"
+		}
+		lines = append(lines, line)
+	}
+
+	constraints, err := g.queryStrings(ctx, `
+        SELECT quote_ident(conname), pg_get_constraintdef(oid, true)
+        FROM pg_catalog.pg_constraint
+        WHERE conrelid = $1::oid AND contype IN ('p', 'u', 'c', 'f', 'x')
+        ORDER BY CASE contype WHEN 'p' THEN 0 WHEN 'u' THEN 1 WHEN 'c' THEN 2 WHEN 'f' THEN 3 ELSE 4 END, conname`, oid)
+	if err != nil {
+		return nil, err
+	}
+	for _, con := range constraints {
+		lines = append(lines, fmt.Sprintf("CONSTRAINT %s %s", con[0], con[1]))
+	}
+	stmts := []string{createTable(name, lines)}
+
+	indexes, err := g.queryStrings(ctx, `
+        SELECT pg_get_indexdef(i.indexrelid)
+        FROM pg_catalog.pg_index i
+        JOIN pg_catalog.pg_class ic ON ic.oid = i.indexrelid
+        WHERE i.indrelid = $1::oid
+          AND NOT EXISTS (SELECT 1 FROM pg_catalog.pg_constraint c WHERE c.conrelid = i.indrelid AND c.conindid = i.indexrelid)
+        ORDER BY ic.relname`, oid)
+	if err != nil {
+		return nil, err
+	}
+	for _, index := range indexes {
+		stmts = append(stmts, index[0])
+	}
+	return stmts, nil
+}
+
+// sqlserverStatements reconstructs CREATE TABLE from the sys catalog views.
+// View definitions are stored in sys.sql_modules as written.
+func (g *createTableStatementGetter) sqlserverStatements(ctx context.Context, tableName string) ([]string, error) {
+	rows, err := g.queryStrings(ctx, `
+        SELECT RTRIM(o.type), QUOTENAME(SCHEMA_NAME(o.schema_id)) + '.' + QUOTENAME(o.name), COALESCE(m.definition, '')
+        FROM sys.objects o
+        LEFT JOIN sys.sql_modules m ON m.object_id = o.object_id
+        WHERE o.object_id = OBJECT_ID(@p1)`, tableName)
+	if err != nil || len(rows) == 0 {
+		return nil, err
+	}
+	objectType, name := rows[0][0], rows[0][1]
+	sqlServer := config.NewDialect(config.SQLServer)
+	if objectType == "V" {
+		return []string{rows[0][2]}, nil
+	}
+
+	columns, err := g.queryStrings(ctx, `
+        SELECT QUOTENAME(c.name),
+               CASE
+                 WHEN t.name IN ('varchar', 'char', 'varbinary', 'binary')
+                   THEN t.name + '(' + CASE WHEN c.max_length = -1 THEN 'MAX' ELSE CAST(c.max_length AS varchar(10)) END + ')'
+                 WHEN t.name IN ('nvarchar', 'nchar')
+                   THEN t.name + '(' + CASE WHEN c.max_length = -1 THEN 'MAX' ELSE CAST(c.max_length / 2 AS varchar(10)) END + ')'
+                 WHEN t.name IN ('decimal', 'numeric')
+                   THEN t.name + '(' + CAST(c.precision AS varchar(10)) + ', ' + CAST(c.scale AS varchar(10)) + ')'
+                 WHEN t.name IN ('datetime2', 'time', 'datetimeoffset')
+                   THEN t.name + '(' + CAST(c.scale AS varchar(10)) + ')'
+                 ELSE t.name
+               END,
+               c.is_nullable,
+               CASE WHEN ic.column_id IS NULL THEN ''
+                    ELSE 'IDENTITY(' + CAST(ic.seed_value AS varchar(40)) + ', ' + CAST(ic.increment_value AS varchar(40)) + ')' END,
+               COALESCE(dc.definition, ''),
+               COALESCE(cc.definition, '')
+        FROM sys.columns c
+        JOIN sys.types t ON t.user_type_id = c.user_type_id
+        LEFT JOIN sys.identity_columns ic ON ic.object_id = c.object_id AND ic.column_id = c.column_id
+        LEFT JOIN sys.default_constraints dc ON dc.parent_object_id = c.object_id AND dc.parent_column_id = c.column_id
+        LEFT JOIN sys.computed_columns cc ON cc.object_id = c.object_id AND cc.column_id = c.column_id
+        WHERE c.object_id = OBJECT_ID(@p1)
+        ORDER BY c.column_id`, tableName)
+	if err != nil {
+		return nil, err
+	}
+	lines := make([]string, 0, len(columns))
+	for _, col := range columns {
+		if col[5] != "" {
+			lines = append(lines, fmt.Sprintf("%s AS %s", col[0], col[5]))
+			continue
+		}
+		line := col[0] + " " + col[1]
+		if col[3] != "" {
+			line += " " + col[3]
+		}
+		if isTrue(col[2]) {
+			line += " NULL"
+		} else {
+			line += " NOT NULL"
+		}
+		if col[4] != "" {
+			line += " DEFAULT " + col[4]
+		}
+		lines = append(lines, line)
+	}
+
+	indexes, err := g.GetIndexes(ctx, tableName)
+	if err != nil {
+		return nil, err
+	}
+	createIndexes := []string{}
+	for _, index := range indexes {
+		if index.Primary {
+			lines = append(lines, fmt.Sprintf("CONSTRAINT %s PRIMARY KEY (%s)",
+				sqlServer.QuoteIdentifier(index.Name), sqlServer.QuoteIdentifiers(index.Columns)))
+			continue
+		}
+		unique := ""
+		if index.Unique {
+			unique = "UNIQUE "
+		}
+		createIndexes = append(createIndexes, fmt.Sprintf("CREATE %sINDEX %s ON %s (%s)",
+			unique, sqlServer.QuoteIdentifier(index.Name), name, sqlServer.QuoteIdentifiers(index.Columns)))
+	}
+
+	checks, err := g.queryStrings(ctx, `
+        SELECT QUOTENAME(name), definition
+        FROM sys.check_constraints
+        WHERE parent_object_id = OBJECT_ID(@p1)
+        ORDER BY name`, tableName)
+	if err != nil {
+		return nil, err
+	}
+	for _, check := range checks {
+		lines = append(lines, fmt.Sprintf("CONSTRAINT %s CHECK %s", check[0], check[1]))
+	}
+
+	foreignKeys, err := g.GetForeignKeys(ctx, tableName)
+	if err != nil {
+		return nil, err
+	}
+	for _, fk := range foreignKeys {
+		lines = append(lines, fmt.Sprintf("CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s (%s)",
+			sqlServer.QuoteIdentifier(fk.Name), sqlServer.QuoteIdentifiers(fk.Columns),
+			sqlServer.QualifiedName(fk.RefSchema, fk.RefTable), sqlServer.QuoteIdentifiers(fk.RefColumns)))
+	}
+	return append([]string{createTable(name, lines)}, createIndexes...), nil
+}
+
+// createTable returns CREATE TABLE statement with one column or constraint per line.
+func createTable(name string, lines []string) string {
+	return fmt.Sprintf("CREATE TABLE %s (\n    %s\n)", name, strings.Join(lines, ",\n    "))
+}
+
+// joinStatements terminates each statement with a semicolon and separates them by a blank line.
+func joinStatements(stmts []string) string {
+	terminated := make([]string, 0, len(stmts))
+	for _, stmt := range stmts {
+		stmt = strings.TrimSuffix(strings.TrimSpace(stmt), ";")
+		terminated = append(terminated, stmt+";")
+	}
+	return strings.Join(terminated, "\n\n")
+}
@@ -1,10 +1,12 @@
 package persistence
 
 import (
+	"bufio"
 	"compress/bzip2"
 	"compress/gzip"
 	"context"
 	"encoding/csv"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -364,3 +366,53 @@ func (l *ltsvWriter) WriteLTSV(_ context.Context, file *model.File, table *model
 	}
 	return w.WriteAll(records)
 }
+
+// _ interface implementation check
+var _ repository.JSONWriter = (*jsonWriter)(nil)
+
+type jsonWriter struct{}
+
+// NewJSONWriter return new JSONWriter.
+func NewJSONWriter() repository.JSONWriter {
+	return &jsonWriter{}
+}
+
+// WriteJSON write records to JSON files. The file is an array of objects, one per record,
+// and the keys are written in the order of the header.
+func (j *jsonWriter) WriteJSON(_ context.Context, file *model.File, table *model.Table) error {
+	f, err := file.Create()
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	if _, err := w.WriteString("["); err != nil {
+		return err
+	}
+	for i, record := range table.Records() {
+		fields := make([]string, 0, len(record))
+		for j, data := range record {
+			key, err := json.Marshal(table.Header()[j])
+			if err != nil {
+				return err
+			}
+			value, err := json.Marshal(data)
+			if err != nil {
+				return err
+			}
+			fields = append(fields, string(key)+": "+string(value))
+		}
+		sep := ","
+		if i == len(table.Records())-1 {
+			sep = ""
+		}
+		if _, err := w.WriteString("\n  {" + strings.Join(fields, ", ") + "}" + sep); err != nil {
+			return err
+		}
+	}
+	if _, err := w.WriteString("\n]\n"); err != nil {
+		return err
+	}
+	return w.Flush()
+}
@@ -3,6 +3,7 @@ package persistence
 import (
 	"errors"
 	"io"
+	"os"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -44,6 +45,40 @@ func TestCSVReaderReadCSV(t *testing.T) {
 	})
 }
 
+func TestJSONWriterWriteJSON(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "diff.json")
+	file, err := model.NewFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	table := model.NewTable(
+		"diff",
+		model.NewHeader([]string{"diff", "id", "name"}),
+		[]model.Record{
+			model.NewRecord([]string{"added", "1", "John \"JD\" Doe"}),
+			model.NewRecord([]string{"removed", "2", ""}),
+		},
+	)
+	if err := NewJSONWriter().WriteJSON(t.Context(), file, table); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `[
+  {"diff": "added", "id": "1", "name": "John \"JD\" Doe"},
+  {"diff": "removed", "id": "2", "name": ""}
+]
+`
+	if diff := cmp.Diff(want, string(got)); diff != "" {
+		t.Errorf("value is mismatch (-want +got):\n%s", diff)
+	}
+}
+
 func TestTSVReaderReadTSV(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,277 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/nao1215/sqluv/config"
+	"github.com/nao1215/sqluv/domain/model"
+	"github.com/nao1215/sqluv/domain/repository"
+	"github.com/nao1215/sqluv/infrastructure"
+)
+
+// _ interface implementation check
+var _ repository.RemoteTableImporter = (*remoteTableImporter)(nil)
+
+// remoteTableImporter imports the records of a file into a table in database.
+// PostgreSQL uses COPY, and the other DBMS use multi-row INSERT statements.
+type remoteTableImporter struct {
+	db        *sql.DB
+	dbmsType  config.DBMSType
+	dialect   config.Dialect
+	readOnly  bool
+	ddlGetter *ddlGetter
+}
+
+// NewRemoteTableImporter returns RemoteTableImporter.
+func NewRemoteTableImporter(db config.DBMS, conf *config.DBConnection) repository.RemoteTableImporter {
+	return &remoteTableImporter{
+		db:       db,
+		dbmsType: conf.Type,
+		dialect:  config.NewDialect(conf.Type),
+		readOnly: conf.ReadOnly,
+		ddlGetter: &ddlGetter{
+			db:       db,
+			database: conf.Database,
+			dbmsType: conf.Type,
+		},
+	}
+}
+
+// PlanImport maps the file columns to the columns of the table. If the table exists, the file columns
+// are matched with the table columns by name (case-insensitive). Otherwise, the plan has the CREATE TABLE
+// statement with the given column types, or the types inferred from the file values if types is nil.
+func (i *remoteTableImporter) PlanImport(ctx context.Context, table *model.Table, tableName string, types []model.ColumnType) (*model.ImportPlan, error) {
+	if i.readOnly {
+		return nil, infrastructure.ErrReadOnlyConnection
+	}
+	if err := table.Valid(); err != nil {
+		return nil, err
+	}
+
+	ddl, err := i.ddlGetter.GetTableDDL(ctx, tableName)
+	if err != nil {
+		return nil, err
+	}
+	plan := &model.ImportPlan{
+		Source: table,
+		Target: tableName,
+	}
+	if len(ddl[0].Records()) > 0 {
+		if plan.Columns, err = mapImportColumns(table.Header(), ddl[0]); err != nil {
+			return nil, fmt.Errorf("%w in %s", err, tableName)
+		}
+		return plan, nil
+	}
+
+	if types == nil {
+		types = model.InferColumnTypes(table)
+	}
+	if len(types) != len(table.Header()) {
+		return nil, fmt.Errorf("%d column types are given for %d columns", len(types), len(table.Header()))
+	}
+	lines := make([]string, 0, len(table.Header()))
+	for idx, ct := range types {
+		dataType := i.dataType(ct)
+		plan.Columns = append(plan.Columns, model.ImportColumn{
+			Source:      table.Header()[idx],
+			Target:      table.Header()[idx],
+			DataType:    dataType,
+			EmptyAsNull: ct != model.ColumnTypeText,
+		})
+		lines = append(lines, i.dialect.QuoteIdentifier(table.Header()[idx])+" "+dataType)
+	}
+	plan.CreateStatement = createTable(i.dialect.QuoteIdentifier(tableName), lines)
+	return plan, nil
+}
+
+// mapImportColumns matches the file columns with the columns in the DDL info returned by GetTableDDL.
+func mapImportColumns(header model.Header, ddl *model.Table) ([]model.ImportColumn, error) {
+	columns := make([]model.ImportColumn, 0, len(header))
+	for _, source := range header {
+		var found bool
+		for _, record := range ddl.Records() {
+			if !strings.EqualFold(strings.TrimSpace(source), record[0]) {
+				continue
+			}
+			columns = append(columns, model.ImportColumn{
+				Source:      source,
+				Target:      record[0],
+				DataType:    record[1],
+				EmptyAsNull: !isTextType(record[1]),
+			})
+			found = true
+			break
+		}
+		if !found {
+			return nil, fmt.Errorf("%w: %s", infrastructure.ErrColumnNotFound, source)
+		}
+	}
+	return columns, nil
+}
+
+// isTextType returns true if the data type stores strings, so that an empty value is kept as is.
+func isTextType(dataType string) bool {
+	dataType = strings.ToLower(dataType)
+	for _, t := range []string{"char", "text", "clob", "string"} {
+		if strings.Contains(dataType, t) {
+			return true
+		}
+	}
+	return false
+}
+
+// Import imports the records in a transaction. The table is created first if the plan has
+// the CREATE TABLE statement. It returns the number of imported records.
+func (i *remoteTableImporter) Import(ctx context.Context, plan *model.ImportPlan) (int64, error) {
+	if i.readOnly {
+		return 0, infrastructure.ErrReadOnlyConnection
+	}
+
+	tx, err := i.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	if plan.CreatesTable() {
+		if _, err := tx.ExecContext(ctx, plan.CreateStatement); err != nil {
+			return 0, err
+		}
+	}
+
+	if i.dbmsType == config.PostgreSQL {
+		err = copyRecords(ctx, tx, plan)
+	} else {
+		err = i.insertRecords(ctx, tx, plan)
+	}
+	if err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return int64(len(plan.Source.Records())), nil
+}
+
+// copyRecords imports the records with PostgreSQL COPY FROM STDIN.
+func copyRecords(ctx context.Context, tx *sql.Tx, plan *model.ImportPlan) error {
+	stmt, err := tx.PrepareContext(ctx, pq.CopyIn(plan.Target, plan.TargetColumns()...))
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for row := range plan.Source.Records() {
+		if _, err := stmt.ExecContext(ctx, plan.Values(row)...); err != nil {
+			return err
+		}
+	}
+	// Exec without arguments flushes the buffered records.
+	_, err = stmt.ExecContext(ctx)
+	return err
+}
+
+// insertRecords imports the records with multi-row INSERT statements. The number of rows in
+// a statement is limited so that the placeholders do not exceed the limit of SQL Server (2100).
+// Oracle Database does not support multi-row VALUES before 23ai, so INSERT ALL is used for it.
+func (i *remoteTableImporter) insertRecords(ctx context.Context, tx *sql.Tx, plan *model.ImportPlan) error {
+	columns := make([]string, 0, len(plan.Columns))
+	for _, c := range plan.TargetColumns() {
+		columns = append(columns, i.dialect.QuoteIdentifier(c))
+	}
+	into := fmt.Sprintf("INTO %s (%s) VALUES ", i.dialect.QuoteIdentifier(plan.Target), strings.Join(columns, ", "))
+	batchSize := max(1, min(1000, 2000/len(columns)))
+
+	records := plan.Source.Records()
+	for start := 0; start < len(records); start += batchSize {
+		end := min(start+batchSize, len(records))
+		rows := make([]string, 0, end-start)
+		args := make([]any, 0, (end-start)*len(columns))
+		for row := start; row < end; row++ {
+			placeholders := make([]string, 0, len(columns))
+			for range columns {
+				placeholders = append(placeholders, i.dialect.Placeholder(len(args)+len(placeholders)+1))
+			}
+			rows = append(rows, "("+strings.Join(placeholders, ", ")+")")
+			args = append(args, plan.Values(row)...)
+		}
+		query := "INSERT " + into + strings.Join(rows, ", ")
+		if i.dbmsType == config.Oracle {
+			query = "INSERT ALL " + into + strings.Join(rows, " "+into) + " SELECT 1 FROM DUAL"
+		}
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dataType returns the data type of the DBMS for the column type.
+func (i *remoteTableImporter) dataType(ct model.ColumnType) string {
+	switch ct {
+	case model.ColumnTypeInteger:
+		switch i.dbmsType {
+		case config.SQLite3:
+			return "INTEGER"
+		case config.Oracle:
+			return "NUMBER(19)"
+		default:
+			return "BIGINT"
+		}
+	case model.ColumnTypeFloat:
+		switch i.dbmsType {
+		case config.PostgreSQL:
+			return "DOUBLE PRECISION"
+		case config.MySQL:
+			return "DOUBLE"
+		case config.SQLServer:
+			return "FLOAT"
+		case config.Oracle:
+			return "BINARY_DOUBLE"
+		default:
+			return "REAL"
+		}
+	case model.ColumnTypeDecimal:
+		switch i.dbmsType {
+		case config.MySQL:
+			return "DECIMAL(65,30)"
+		case config.SQLServer:
+			return "DECIMAL(38,10)"
+		case config.Oracle:
+			return "NUMBER"
+		default:
+			return "NUMERIC"
+		}
+	case model.ColumnTypeDate:
+		if i.dbmsType == config.SQLite3 {
+			return "TEXT"
+		}
+		return "DATE"
+	case model.ColumnTypeTimestamp:
+		switch i.dbmsType {
+		case config.PostgreSQL:
+			return "TIMESTAMP"
+		case config.MySQL:
+			return "DATETIME"
+		case config.SQLServer:
+			return "DATETIME2"
+		case config.Oracle:
+			return "TIMESTAMP"
+		default:
+			return "TEXT"
+		}
+	default:
+		switch i.dbmsType {
+		case config.SQLServer:
+			return "NVARCHAR(MAX)"
+		case config.Oracle:
+			return "VARCHAR2(4000)"
+		default:
+			return "TEXT"
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/nao1215/sqluv/config"
+	"github.com/nao1215/sqluv/domain/model"
+	"github.com/nao1215/sqluv/infrastructure"
+)
+
+func TestRemoteTableImporterSQLite3(t *testing.T) {
+	t.Parallel()
+
+	path := newTestSQLite3DB(t, "CREATE TABLE user (id INTEGER, name TEXT, age INTEGER)")
+	conn := &config.DBConnection{Type: config.SQLite3, Database: path}
+	db, closeDB, err := config.NewSQLite3DB(config.NewSQLite3Config(path, false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(closeDB)
+	importer := NewRemoteTableImporter(db, conn)
+	ctx := context.Background()
+
+	// count returns the number of rows that match the condition.
+	count := func(t *testing.T, query string) int {
+		t.Helper()
+		var n int
+		if err := (*sql.DB)(db).QueryRow(query).Scan(&n); err != nil {
+			t.Fatal(err)
+		}
+		return n
+	}
+
+	t.Run("import into an existing table", func(t *testing.T) {
+		file := model.NewTable("user.csv",
+			model.Header{"NAME", "id", "age"},
+			[]model.Record{{"gina", "1", "20"}, {"", "2", ""}})
+		plan, err := importer.PlanImport(ctx, file, "user", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []model.ImportColumn{
+			{Source: "NAME", Target: "name", DataType: "TEXT"},
+			{Source: "id", Target: "id", DataType: "INTEGER", EmptyAsNull: true},
+			{Source: "age", Target: "age", DataType: "INTEGER", EmptyAsNull: true},
+		}
+		if diff := cmp.Diff(want, plan.Columns); diff != "" {
+			t.Errorf("PlanImport() columns mismatch (-want +got):\n%s", diff)
+		}
+		if plan.CreatesTable() {
+			t.Error("PlanImport() creates the existing table")
+		}
+
+		n, err := importer.Import(ctx, plan)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != 2 {
+			t.Errorf("Import() = %d, want 2", n)
+		}
+		if got := count(t, "SELECT COUNT(*) FROM user WHERE name = '' AND age IS NULL"); got != 1 {
+			t.Errorf("empty values: got %d rows, want 1", got)
+		}
+	})
+
+	t.Run("create a table", func(t *testing.T) {
+		file := model.NewTable("score.csv",
+			model.Header{"id", "score", "taken_at"},
+			func() []model.Record {
+				records := make([]model.Record, 0, 1500)
+				for range 1500 {
+					records = append(records, model.Record{"1", "98.5", "2024-01-02 03:04:05"})
+				}
+				return records
+			}())
+		plan, err := importer.PlanImport(ctx, file, "score", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		wantStmt := "CREATE TABLE \"score\" (\n    \"id\" INTEGER,\n    \"score\" REAL,\n    \"taken_at\" TEXT\n)"
+		if diff := cmp.Diff(wantStmt, plan.CreateStatement); diff != "" {
+			t.Errorf("PlanImport() statement mismatch (-want +got):\n%s", diff)
+		}
+
+		if _, err := importer.Import(ctx, plan); err != nil {
+			t.Fatal(err)
+		}
+		if got := count(t, "SELECT COUNT(*) FROM score"); got != 1500 {
+			t.Errorf("got %d rows, want 1500", got)
+		}
+	})
+
+	t.Run("column not found", func(t *testing.T) {
+		file := model.NewTable("user.csv", model.Header{"id", "email"}, []model.Record{{"1", "a@example.com"}})
+		if _, err := importer.PlanImport(ctx, file, "user", nil); !errors.Is(err, infrastructure.ErrColumnNotFound) {
+			t.Errorf("PlanImport() error = %v, want %v", err, infrastructure.ErrColumnNotFound)
+		}
+	})
+
+	t.Run("read-only connection", func(t *testing.T) {
+		readOnly := NewRemoteTableImporter(db, &config.DBConnection{Type: config.SQLite3, Database: path, ReadOnly: true})
+		file := model.NewTable("user.csv", model.Header{"id"}, []model.Record{{"1"}})
+		if _, err := readOnly.PlanImport(ctx, file, "user", nil); !errors.Is(err, infrastructure.ErrReadOnlyConnection) {
+			t.Errorf("PlanImport() error = %v, want %v", err, infrastructure.ErrReadOnlyConnection)
+		}
+	})
+}
@@ -0,0 +1,123 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/nao1215/sqluv/config"
+	"github.com/nao1215/sqluv/domain/model"
+	"github.com/nao1215/sqluv/domain/repository"
+	"github.com/nao1215/sqluv/infrastructure"
+)
+
+// _ interface implementation check
+var _ repository.QueryPlanInRemoteGetter = (*queryPlanGetter)(nil)
+
+// queryPlanGetter runs EXPLAIN in the format that each DBMS can return as a tree.
+type queryPlanGetter struct {
+	db       *sql.DB
+	dbmsType config.DBMSType
+	readOnly bool
+}
+
+// NewQueryPlanGetter returns QueryPlanInRemoteGetter.
+func NewQueryPlanGetter(db config.DBMS, conf *config.DBConnection) repository.QueryPlanInRemoteGetter {
+	return &queryPlanGetter{
+		db:       db,
+		dbmsType: conf.Type,
+		readOnly: conf.ReadOnly,
+	}
+}
+
+// GetQueryPlan returns the plan of the query. The query is given without EXPLAIN.
+// If analyze is true, PostgreSQL executes the query to measure the actual rows and time. It is allowed
+// only for a query that does not modify data, and the query runs in a transaction that is rolled back.
+// The other DBMS return the estimated plan regardless of analyze.
+func (g *queryPlanGetter) GetQueryPlan(ctx context.Context, query *model.SQL, analyze bool) (*model.QueryPlan, error) {
+	if query.IsExplain() {
+		return nil, errors.New("enter the query without EXPLAIN")
+	}
+	if analyze && !query.IsReadOnly(config.NewDialect(g.dbmsType).SQLDialect()) {
+		return nil, errors.New("EXPLAIN ANALYZE is allowed only for a query that does not modify data")
+	}
+
+	switch g.dbmsType {
+	case config.PostgreSQL:
+		explain := "EXPLAIN (FORMAT JSON) "
+		if analyze {
+			explain = "EXPLAIN (ANALYZE, FORMAT JSON) "
+		}
+		raw, err := g.queryPlanText(ctx, explain+query.String())
+		if err != nil {
+			return nil, err
+		}
+		return model.ParsePostgreSQLPlan(raw)
+	case config.MySQL:
+		raw, err := g.queryPlanText(ctx, "EXPLAIN FORMAT=JSON "+query.String())
+		if err != nil {
+			return nil, err
+		}
+		return model.ParseMySQLPlan(raw)
+	case config.SQLite3:
+		explain, err := model.NewSQL("EXPLAIN QUERY PLAN " + query.String())
+		if err != nil {
+			return nil, err
+		}
+		tx, err := beginTx(ctx, g.db, g.dbmsType, g.readOnly)
+		if err != nil {
+			return nil, err
+		}
+		defer tx.Rollback()
+		table, err := infrastructure.Query(ctx, tx, explain)
+		if err != nil {
+			return nil, err
+		}
+		return model.ParseSQLite3Plan(table.Records())
+	case config.SQLServer:
+		raw, err := g.sqlServerShowplan(ctx, query.String())
+		if err != nil {
+			return nil, err
+		}
+		return model.ParseSQLServerPlan(raw)
+	default:
+		return nil, fmt.Errorf("unsupported dbms type: %v", g.dbmsType)
+	}
+}
+
+// queryPlanText runs EXPLAIN that returns the plan as one value, in a transaction that is rolled back.
+func (g *queryPlanGetter) queryPlanText(ctx context.Context, explain string) (string, error) {
+	tx, err := beginTx(ctx, g.db, g.dbmsType, g.readOnly)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+
+	var raw string
+	if err := tx.QueryRowContext(ctx, explain).Scan(&raw); err != nil {
+		return "", err
+	}
+	return raw, nil
+}
+
+// sqlServerShowplan returns the XML showplan of the query. SET SHOWPLAN_XML applies to the session,
+// so the statements run on one connection, and the query is compiled but not executed.
+func (g *queryPlanGetter) sqlServerShowplan(ctx context.Context, query string) (string, error) {
+	conn, err := g.db.Conn(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "SET SHOWPLAN_XML ON"); err != nil {
+		return "", err
+	}
+	defer conn.ExecContext(context.Background(), "SET SHOWPLAN_XML OFF") //nolint:errcheck // the connection is returned to the pool anyway
+
+	var raw string
+	if err := conn.QueryRowContext(ctx, query).Scan(&raw); err != nil {
+		return "", err
+	}
+	return raw, nil
+}
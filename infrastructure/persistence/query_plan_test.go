@@ -0,0 +1,65 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/nao1215/sqluv/config"
+	"github.com/nao1215/sqluv/domain/model"
+)
+
+func TestQueryPlanGetterSQLite3(t *testing.T) {
+	t.Parallel()
+
+	path := newTestSQLite3DB(t,
+		"CREATE TABLE team (id INTEGER PRIMARY KEY, name TEXT)",
+		"CREATE TABLE user (id INTEGER PRIMARY KEY, team_id INTEGER, name TEXT)",
+	)
+	db, closeDB, err := config.NewSQLite3DB(config.NewSQLite3Config(path, true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(closeDB)
+	getter := NewQueryPlanGetter(db, &config.DBConnection{Type: config.SQLite3, Database: path, ReadOnly: true})
+	ctx := context.Background()
+
+	query, err := model.NewSQL("SELECT * FROM user u JOIN team t ON u.team_id = t.id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan, err := getter.GetQueryPlan(ctx, query, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := []string{}
+	fullScans := []string{}
+	for _, n := range plan.Root.Children {
+		got = append(got, n.Operation)
+		if n.FullScan {
+			fullScans = append(fullScans, n.Operation)
+		}
+	}
+	want := []string{"SCAN u", "SEARCH t USING INTEGER PRIMARY KEY (rowid=?)"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("GetQueryPlan() mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"SCAN u"}, fullScans); diff != "" {
+		t.Errorf("GetQueryPlan() full scans mismatch (-want +got):\n%s", diff)
+	}
+
+	explain, err := model.NewSQL("EXPLAIN SELECT * FROM user")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := getter.GetQueryPlan(ctx, explain, false); err == nil {
+		t.Error("GetQueryPlan() returns no error for EXPLAIN")
+	}
+	update, err := model.NewSQL("UPDATE user SET name = 'x'")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := getter.GetQueryPlan(ctx, update, true); err == nil {
+		t.Error("GetQueryPlan() returns no error for ANALYZE of UPDATE")
+	}
+}
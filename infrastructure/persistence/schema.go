@@ -0,0 +1,505 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/nao1215/sqluv/config"
+	"github.com/nao1215/sqluv/domain/model"
+	"github.com/nao1215/sqluv/domain/repository"
+)
+
+// _ interface implementation check
+var (
+	_ repository.ObjectsInRemoteGetter     = (*schemaGetter)(nil)
+	_ repository.IndexesInRemoteGetter     = (*schemaGetter)(nil)
+	_ repository.ForeignKeysInRemoteGetter = (*schemaGetter)(nil)
+	_ repository.TriggersInRemoteGetter    = (*schemaGetter)(nil)
+	_ repository.SchemasInRemoteGetter     = (*schemaGetter)(nil)
+)
+
+// schemaGetter reads database objects other than tables from the system catalog.
+// The objects are fetched lazily, so each method runs only the query for the requested object.
+type schemaGetter struct {
+	db       *sql.DB
+	database string
+	dbmsType config.DBMSType
+}
+
+// newSchemaGetter returns schemaGetter.
+func newSchemaGetter(db config.DBMS, conf *config.DBConnection) *schemaGetter {
+	return &schemaGetter{
+		db:       db,
+		database: conf.Database,
+		dbmsType: conf.Type,
+	}
+}
+
+// NewObjectsGetter returns ObjectsInRemoteGetter.
+func NewObjectsGetter(db config.DBMS, conf *config.DBConnection) repository.ObjectsInRemoteGetter {
+	return newSchemaGetter(db, conf)
+}
+
+// NewIndexesGetter returns IndexesInRemoteGetter.
+func NewIndexesGetter(db config.DBMS, conf *config.DBConnection) repository.IndexesInRemoteGetter {
+	return newSchemaGetter(db, conf)
+}
+
+// NewForeignKeysGetter returns ForeignKeysInRemoteGetter.
+func NewForeignKeysGetter(db config.DBMS, conf *config.DBConnection) repository.ForeignKeysInRemoteGetter {
+	return newSchemaGetter(db, conf)
+}
+
+// NewTriggersGetter returns TriggersInRemoteGetter.
+func NewTriggersGetter(db config.DBMS, conf *config.DBConnection) repository.TriggersInRemoteGetter {
+	return newSchemaGetter(db, conf)
+}
+
+// NewSchemasGetter returns SchemasInRemoteGetter.
+func NewSchemasGetter(db config.DBMS, conf *config.DBConnection) repository.SchemasInRemoteGetter {
+	return newSchemaGetter(db, conf)
+}
+
+// GetSchemas gets the schemas that the user can switch to.
+// They are databases in MySQL and SQL Server, and users in Oracle Database. SQLite3 has no schemas,
+// so it returns an empty list.
+func (g *schemaGetter) GetSchemas(ctx context.Context) ([]string, error) {
+	var query string
+	switch g.dbmsType {
+	case config.MySQL:
+		query = "SELECT SCHEMA_NAME FROM information_schema.schemata ORDER BY SCHEMA_NAME"
+	case config.PostgreSQL:
+		query = "SELECT nspname FROM pg_catalog.pg_namespace WHERE nspname NOT LIKE 'pg\\_%' AND nspname <> 'information_schema' ORDER BY nspname"
+	case config.SQLite3:
+		return []string{}, nil
+	case config.SQLServer:
+		query = "SELECT name FROM sys.databases WHERE HAS_DBACCESS(name) = 1 ORDER BY name"
+	case config.Oracle:
+		// The schemas of the system users are not listed.
+		query = "SELECT USERNAME FROM ALL_USERS WHERE ORACLE_MAINTAINED = 'N' ORDER BY USERNAME"
+	default:
+		return nil, fmt.Errorf("unsupported dbms type: %v", g.dbmsType)
+	}
+
+	rows, err := g.queryStrings(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	schemas := make([]string, 0, len(rows))
+	for _, row := range rows {
+		schemas = append(schemas, row[0])
+	}
+	return schemas, nil
+}
+
+// GetCurrentSchema gets the schema that the connection uses.
+// It returns an empty string if no schema is selected or the DBMS has no schemas.
+func (g *schemaGetter) GetCurrentSchema(ctx context.Context) (string, error) {
+	var query string
+	switch g.dbmsType {
+	case config.MySQL:
+		query = "SELECT DATABASE()"
+	case config.PostgreSQL:
+		query = "SELECT current_schema()"
+	case config.SQLite3:
+		return "", nil
+	case config.SQLServer:
+		query = "SELECT DB_NAME()"
+	case config.Oracle:
+		query = "SELECT SYS_CONTEXT('USERENV', 'CURRENT_SCHEMA') FROM DUAL"
+	default:
+		return "", fmt.Errorf("unsupported dbms type: %v", g.dbmsType)
+	}
+
+	var schema sql.NullString
+	if err := g.db.QueryRowContext(ctx, query).Scan(&schema); err != nil {
+		return "", err
+	}
+	return schema.String, nil
+}
+
+// GetObjects gets the names of database objects of the given type.
+// If the DBMS does not have the object type (e.g. sequences in MySQL), it returns an empty list.
+func (g *schemaGetter) GetObjects(ctx context.Context, objectType model.ObjectType) ([]string, error) {
+	var query string
+	var args []any
+
+	switch g.dbmsType {
+	case config.MySQL:
+		switch objectType {
+		case model.ObjectTypeView:
+			query = "SELECT TABLE_NAME FROM information_schema.views WHERE TABLE_SCHEMA = ? ORDER BY TABLE_NAME"
+		case model.ObjectTypeFunction:
+			query = "SELECT ROUTINE_NAME FROM information_schema.routines WHERE ROUTINE_SCHEMA = ? ORDER BY ROUTINE_NAME"
+		default:
+			return []string{}, nil
+		}
+		args = []any{g.database}
+	case config.PostgreSQL:
+		switch objectType {
+		case model.ObjectTypeView:
+			query = "SELECT table_name FROM information_schema.views WHERE table_schema = current_schema() ORDER BY table_name"
+		case model.ObjectTypeMaterializedView:
+			query = "SELECT matviewname FROM pg_catalog.pg_matviews WHERE schemaname = current_schema() ORDER BY matviewname"
+		case model.ObjectTypeSequence:
+			query = "SELECT sequence_name FROM information_schema.sequences WHERE sequence_schema = current_schema() ORDER BY sequence_name"
+		case model.ObjectTypeFunction:
+			query = "SELECT DISTINCT routine_name FROM information_schema.routines WHERE routine_schema = current_schema() ORDER BY routine_name"
+		default:
+			return nil, fmt.Errorf("unsupported object type: %v", objectType)
+		}
+	case config.SQLite3:
+		if objectType != model.ObjectTypeView {
+			return []string{}, nil
+		}
+		query = "SELECT name FROM sqlite_master WHERE type = 'view' ORDER BY name"
+	case config.SQLServer:
+		switch objectType {
+		case model.ObjectTypeView:
+			query = "SELECT TABLE_NAME FROM INFORMATION_SCHEMA.VIEWS WHERE TABLE_CATALOG = @p1 ORDER BY TABLE_NAME"
+			args = []any{g.database}
+		case model.ObjectTypeSequence:
+			query = "SELECT name FROM sys.sequences ORDER BY name"
+		case model.ObjectTypeFunction:
+			query = "SELECT ROUTINE_NAME FROM INFORMATION_SCHEMA.ROUTINES WHERE ROUTINE_CATALOG = @p1 ORDER BY ROUTINE_NAME"
+			args = []any{g.database}
+		default:
+			return []string{}, nil
+		}
+	case config.Oracle:
+		switch objectType {
+		case model.ObjectTypeView:
+			query = "SELECT VIEW_NAME FROM ALL_VIEWS WHERE OWNER = SYS_CONTEXT('USERENV', 'CURRENT_SCHEMA') ORDER BY VIEW_NAME"
+		case model.ObjectTypeMaterializedView:
+			query = "SELECT MVIEW_NAME FROM ALL_MVIEWS WHERE OWNER = SYS_CONTEXT('USERENV', 'CURRENT_SCHEMA') ORDER BY MVIEW_NAME"
+		case model.ObjectTypeSequence:
+			query = "SELECT SEQUENCE_NAME FROM ALL_SEQUENCES WHERE SEQUENCE_OWNER = SYS_CONTEXT('USERENV', 'CURRENT_SCHEMA') ORDER BY SEQUENCE_NAME"
+		case model.ObjectTypeFunction:
+			query = `SELECT OBJECT_NAME FROM ALL_OBJECTS
+                WHERE OWNER = SYS_CONTEXT('USERENV', 'CURRENT_SCHEMA') AND OBJECT_TYPE IN ('FUNCTION', 'PROCEDURE')
+                ORDER BY OBJECT_NAME`
+		default:
+			return nil, fmt.Errorf("unsupported object type: %v", objectType)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported dbms type: %v", g.dbmsType)
+	}
+
+	rows, err := g.queryStrings(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(rows))
+	for _, row := range rows {
+		names = append(names, row[0])
+	}
+	return names, nil
+}
+
+// GetIndexes gets the indexes of the table.
+func (g *schemaGetter) GetIndexes(ctx context.Context, tableName string) ([]*model.Index, error) {
+	var query string
+	var args []any
+
+	// Each query returns: index name, column name, unique, primary. Rows are ordered by index and column position.
+	switch g.dbmsType {
+	case config.MySQL:
+		query = `
+            SELECT INDEX_NAME, COLUMN_NAME, NON_UNIQUE = 0, INDEX_NAME = 'PRIMARY'
+            FROM information_schema.statistics
+            WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ?
+            ORDER BY INDEX_NAME, SEQ_IN_INDEX`
+		args = []any{g.database, tableName}
+	case config.PostgreSQL:
+		query = `
+            SELECT i.relname, a.attname, ix.indisunique, ix.indisprimary
+            FROM pg_catalog.pg_index ix
+            JOIN pg_catalog.pg_class t ON t.oid = ix.indrelid
+            JOIN pg_catalog.pg_class i ON i.oid = ix.indexrelid
+            JOIN pg_catalog.pg_namespace n ON n.oid = t.relnamespace
+            JOIN LATERAL unnest(ix.indkey) WITH ORDINALITY AS k(attnum, ord) ON true
+            JOIN pg_catalog.pg_attribute a ON a.attrelid = t.oid AND a.attnum = k.attnum
+            WHERE n.nspname = current_schema() AND t.relname = $1
+            ORDER BY i.relname, k.ord`
+		args = []any{tableName}
+	case config.SQLite3:
+		query = `
+            SELECT il.name, ii.name, il."unique", il.origin = 'pk'
+            FROM pragma_index_list(?) AS il
+            JOIN pragma_index_info(il.name) AS ii
+            ORDER BY il.name, ii.seqno`
+		args = []any{tableName}
+	case config.SQLServer:
+		query = `
+            SELECT i.name, c.name, i.is_unique, i.is_primary_key
+            FROM sys.indexes i
+            JOIN sys.index_columns ic ON ic.object_id = i.object_id AND ic.index_id = i.index_id
+            JOIN sys.columns c ON c.object_id = ic.object_id AND c.column_id = ic.column_id
+            WHERE i.object_id = OBJECT_ID(@p1) AND ic.is_included_column = 0
+            ORDER BY i.name, ic.key_ordinal`
+		args = []any{tableName}
+	case config.Oracle:
+		query = `
+            SELECT i.INDEX_NAME, ic.COLUMN_NAME, CASE i.UNIQUENESS WHEN 'UNIQUE' THEN 1 ELSE 0 END,
+                   CASE WHEN EXISTS (
+                       SELECT 1 FROM ALL_CONSTRAINTS c
+                       WHERE c.OWNER = i.TABLE_OWNER AND c.INDEX_NAME = i.INDEX_NAME AND c.CONSTRAINT_TYPE = 'P'
+                   ) THEN 1 ELSE 0 END
+            FROM ALL_INDEXES i
+            JOIN ALL_IND_COLUMNS ic ON ic.INDEX_OWNER = i.OWNER AND ic.INDEX_NAME = i.INDEX_NAME
+            WHERE i.TABLE_OWNER = SYS_CONTEXT('USERENV', 'CURRENT_SCHEMA') AND i.TABLE_NAME = :1
+            ORDER BY i.INDEX_NAME, ic.COLUMN_POSITION`
+		args = []any{tableName}
+	default:
+		return nil, fmt.Errorf("unsupported dbms type: %v", g.dbmsType)
+	}
+
+	rows, err := g.queryStrings(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	indexes := []*model.Index{}
+	for _, row := range rows {
+		if len(indexes) == 0 || indexes[len(indexes)-1].Name != row[0] {
+			indexes = append(indexes, &model.Index{
+				Name:    row[0],
+				Unique:  isTrue(row[2]),
+				Primary: isTrue(row[3]),
+			})
+		}
+		index := indexes[len(indexes)-1]
+		index.Columns = append(index.Columns, row[1])
+	}
+	return indexes, nil
+}
+
+// GetForeignKeys gets the foreign keys of the table.
+func (g *schemaGetter) GetForeignKeys(ctx context.Context, tableName string) ([]*model.ForeignKey, error) {
+	var query string
+	var args []any
+
+	// Each query returns: constraint name, column, referenced table, referenced column.
+	// SQL Server also returns the schema of the referenced table.
+	// Rows are ordered by constraint and column position.
+	switch g.dbmsType {
+	case config.MySQL:
+		query = `
+            SELECT CONSTRAINT_NAME, COLUMN_NAME, REFERENCED_TABLE_NAME, REFERENCED_COLUMN_NAME
+            FROM information_schema.key_column_usage
+            WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ? AND REFERENCED_TABLE_NAME IS NOT NULL
+            ORDER BY CONSTRAINT_NAME, ORDINAL_POSITION`
+		args = []any{g.database, tableName}
+	case config.PostgreSQL:
+		query = `
+            SELECT c.conname, a.attname, rt.relname, ra.attname
+            FROM pg_catalog.pg_constraint c
+            JOIN pg_catalog.pg_class t ON t.oid = c.conrelid
+            JOIN pg_catalog.pg_namespace n ON n.oid = t.relnamespace
+            JOIN pg_catalog.pg_class rt ON rt.oid = c.confrelid
+            JOIN LATERAL unnest(c.conkey, c.confkey) WITH ORDINALITY AS k(attnum, refattnum, ord) ON true
+            JOIN pg_catalog.pg_attribute a ON a.attrelid = c.conrelid AND a.attnum = k.attnum
+            JOIN pg_catalog.pg_attribute ra ON ra.attrelid = c.confrelid AND ra.attnum = k.refattnum
+            WHERE c.contype = 'f' AND n.nspname = current_schema() AND t.relname = $1
+            ORDER BY c.conname, k.ord`
+		args = []any{tableName}
+	case config.SQLite3:
+		// SQLite3 foreign keys have no name, so the id is used to group the columns.
+		query = `SELECT id, "from", "table", "to" FROM pragma_foreign_key_list(?) ORDER BY id, seq`
+		args = []any{tableName}
+	case config.SQLServer:
+		query = `
+            SELECT fk.name, pc.name, rt.name, rc.name, SCHEMA_NAME(rt.schema_id)
+            FROM sys.foreign_keys fk
+            JOIN sys.foreign_key_columns fkc ON fkc.constraint_object_id = fk.object_id
+            JOIN sys.columns pc ON pc.object_id = fkc.parent_object_id AND pc.column_id = fkc.parent_column_id
+            JOIN sys.tables rt ON rt.object_id = fkc.referenced_object_id
+            JOIN sys.columns rc ON rc.object_id = fkc.referenced_object_id AND rc.column_id = fkc.referenced_column_id
+            WHERE fk.parent_object_id = OBJECT_ID(@p1)
+            ORDER BY fk.name, fkc.constraint_column_id`
+		args = []any{tableName}
+	case config.Oracle:
+		query = `
+            SELECT c.CONSTRAINT_NAME, cc.COLUMN_NAME, rc.TABLE_NAME, rcc.COLUMN_NAME
+            FROM ALL_CONSTRAINTS c
+            JOIN ALL_CONS_COLUMNS cc ON cc.OWNER = c.OWNER AND cc.CONSTRAINT_NAME = c.CONSTRAINT_NAME
+            JOIN ALL_CONSTRAINTS rc ON rc.OWNER = c.R_OWNER AND rc.CONSTRAINT_NAME = c.R_CONSTRAINT_NAME
+            JOIN ALL_CONS_COLUMNS rcc ON rcc.OWNER = rc.OWNER AND rcc.CONSTRAINT_NAME = rc.CONSTRAINT_NAME AND rcc.POSITION = cc.POSITION
+            WHERE c.CONSTRAINT_TYPE = 'R' AND c.OWNER = SYS_CONTEXT('USERENV', 'CURRENT_SCHEMA') AND c.TABLE_NAME = :1
+            ORDER BY c.CONSTRAINT_NAME, cc.POSITION`
+		args = []any{tableName}
+	default:
+		return nil, fmt.Errorf("unsupported dbms type: %v", g.dbmsType)
+	}
+
+	rows, err := g.queryStrings(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	foreignKeys := []*model.ForeignKey{}
+	lastKey := ""
+	for i, row := range rows {
+		if i == 0 || row[0] != lastKey {
+			name := row[0]
+			if g.dbmsType == config.SQLite3 {
+				name = ""
+			}
+			foreignKeys = append(foreignKeys, &model.ForeignKey{
+				Name:     name,
+				RefTable: row[2],
+			})
+			if g.dbmsType == config.SQLServer {
+				foreignKeys[len(foreignKeys)-1].RefSchema = row[4]
+			}
+			lastKey = row[0]
+		}
+		fk := foreignKeys[len(foreignKeys)-1]
+		fk.Columns = append(fk.Columns, row[1])
+		fk.RefColumns = append(fk.RefColumns, row[3])
+	}
+	return foreignKeys, nil
+}
+
+// sqliteTriggerRegexp extracts the timing and the event from CREATE TRIGGER statement of SQLite3.
+var sqliteTriggerRegexp = regexp.MustCompile(`(?is)\bTRIGGER\s+.*?\s(BEFORE\s+|AFTER\s+|INSTEAD\s+OF\s+)?(INSERT|UPDATE|DELETE)\b`)
+
+// GetTriggers gets the triggers of the table.
+func (g *schemaGetter) GetTriggers(ctx context.Context, tableName string) ([]*model.Trigger, error) {
+	var query string
+	var args []any
+
+	// Each query returns: trigger name, timing, event. A trigger with multiple events has multiple rows.
+	switch g.dbmsType {
+	case config.MySQL:
+		query = `
+            SELECT TRIGGER_NAME, ACTION_TIMING, EVENT_MANIPULATION
+            FROM information_schema.triggers
+            WHERE EVENT_OBJECT_SCHEMA = ? AND EVENT_OBJECT_TABLE = ?
+            ORDER BY TRIGGER_NAME`
+		args = []any{g.database, tableName}
+	case config.PostgreSQL:
+		query = `
+            SELECT trigger_name, action_timing, event_manipulation
+            FROM information_schema.triggers
+            WHERE event_object_schema = current_schema() AND event_object_table = $1
+            ORDER BY trigger_name, event_manipulation`
+		args = []any{tableName}
+	case config.SQLite3:
+		// sqlite_master has only the CREATE TRIGGER statement, so the timing and the event are parsed from it.
+		query = "SELECT name, sql, '' FROM sqlite_master WHERE type = 'trigger' AND tbl_name = ? ORDER BY name"
+		args = []any{tableName}
+	case config.SQLServer:
+		query = `
+            SELECT t.name, CASE WHEN t.is_instead_of_trigger = 1 THEN 'INSTEAD OF' ELSE 'AFTER' END, te.type_desc
+            FROM sys.triggers t
+            JOIN sys.trigger_events te ON te.object_id = t.object_id
+            WHERE t.parent_id = OBJECT_ID(@p1)
+            ORDER BY t.name, te.type_desc`
+		args = []any{tableName}
+	case config.Oracle:
+		// TRIGGER_TYPE is e.g. "BEFORE EACH ROW", and TRIGGERING_EVENT is e.g. "INSERT OR UPDATE".
+		query = `
+            SELECT TRIGGER_NAME, TRIGGER_TYPE, TRIGGERING_EVENT
+            FROM ALL_TRIGGERS
+            WHERE TABLE_OWNER = SYS_CONTEXT('USERENV', 'CURRENT_SCHEMA') AND TABLE_NAME = :1
+            ORDER BY TRIGGER_NAME`
+		args = []any{tableName}
+	default:
+		return nil, fmt.Errorf("unsupported dbms type: %v", g.dbmsType)
+	}
+
+	rows, err := g.queryStrings(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	triggers := []*model.Trigger{}
+	for _, row := range rows {
+		name, timing, event := row[0], row[1], row[2]
+		if g.dbmsType == config.Oracle {
+			triggers = append(triggers, oracleTrigger(name, timing, event))
+			continue
+		}
+		if g.dbmsType == config.SQLite3 {
+			timing, event = "BEFORE", ""
+			if m := sqliteTriggerRegexp.FindStringSubmatch(row[1]); m != nil {
+				if m[1] != "" {
+					timing = strings.Join(strings.Fields(strings.ToUpper(m[1])), " ")
+				}
+				event = strings.ToUpper(m[2])
+			}
+		}
+		if len(triggers) == 0 || triggers[len(triggers)-1].Name != name {
+			triggers = append(triggers, &model.Trigger{Name: name, Timing: timing})
+		}
+		if event != "" {
+			trigger := triggers[len(triggers)-1]
+			trigger.Events = append(trigger.Events, event)
+		}
+	}
+	return triggers, nil
+}
+
+// oracleTrigger returns the trigger of Oracle Database from TRIGGER_TYPE (e.g. "BEFORE EACH ROW")
+// and TRIGGERING_EVENT (e.g. "INSERT OR UPDATE") of ALL_TRIGGERS.
+func oracleTrigger(name, triggerType, triggeringEvent string) *model.Trigger {
+	timing, _, _ := strings.Cut(triggerType, " EACH ")
+	timing, _, _ = strings.Cut(timing, " STATEMENT")
+	trigger := &model.Trigger{Name: name, Timing: timing}
+	for _, event := range strings.Split(triggeringEvent, " OR ") {
+		if event = strings.TrimSpace(event); event != "" {
+			trigger.Events = append(trigger.Events, event)
+		}
+	}
+	return trigger
+}
+
+// queryStrings executes the query and returns all rows as strings. NULL is converted to an empty string.
+func (g *schemaGetter) queryStrings(ctx context.Context, query string, args ...any) ([][]string, error) {
+	rows, err := g.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	result := [][]string{}
+	for rows.Next() {
+		values := make([]sql.NullString, len(columns))
+		dest := make([]any, len(columns))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+
+		row := make([]string, len(columns))
+		for i, v := range values {
+			row[i] = v.String
+		}
+		result = append(result, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// isTrue returns true if the catalog value represents true. Drivers return boolean as "1", "true" or "t".
+func isTrue(s string) bool {
+	switch strings.ToLower(s) {
+	case "1", "true", "t", "yes":
+		return true
+	default:
+		return false
+	}
+}
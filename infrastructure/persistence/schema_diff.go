@@ -0,0 +1,400 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/nao1215/sqluv/config"
+	"github.com/nao1215/sqluv/domain/model"
+	"github.com/nao1215/sqluv/domain/repository"
+)
+
+// _ interface implementation check
+var (
+	_ repository.TableSchemasInRemoteGetter = (*tableSchemasGetter)(nil)
+	_ repository.AlterStatementsGenerator   = (*alterStatementsGenerator)(nil)
+)
+
+// tableSchemasGetter reads the columns, primary keys, indexes and foreign keys of all tables
+// to compare schemas and to draw ER diagrams.
+type tableSchemasGetter struct {
+	tablesGetter *tablesGetter
+	ddlGetter    *ddlGetter
+	schemaGetter *schemaGetter
+}
+
+// NewTableSchemasGetter returns TableSchemasInRemoteGetter.
+func NewTableSchemasGetter(db config.DBMS, conf *config.DBConnection) repository.TableSchemasInRemoteGetter {
+	return &tableSchemasGetter{
+		tablesGetter: &tablesGetter{db: db, database: conf.Database, user: conf.User, dbmsType: conf.Type},
+		ddlGetter:    &ddlGetter{db: db, database: conf.Database, user: conf.User, dbmsType: conf.Type},
+		schemaGetter: newSchemaGetter(db, conf),
+	}
+}
+
+// GetTableSchemas gets the definitions of the tables in the current schema (or database), including the foreign keys.
+// The indexes that SQLite3 creates for UNIQUE and PRIMARY KEY constraints are not included,
+// because they cannot be dropped or created by name.
+func (g *tableSchemasGetter) GetTableSchemas(ctx context.Context) ([]*model.TableSchema, error) {
+	tables, err := g.tablesGetter.GetTables(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	schemas := make([]*model.TableSchema, 0, len(tables))
+	for _, table := range tables {
+		ddl, err := g.ddlGetter.GetTableDDL(ctx, table.Name())
+		if err != nil {
+			return nil, err
+		}
+		fullColumns, err := g.fullColumns(ctx, table.Name())
+		if err != nil {
+			return nil, err
+		}
+		schema := &model.TableSchema{Name: table.Name()}
+		keyColumns := []string{}
+		// Each record is: column name, type, length, nullable, default, key.
+		for _, r := range ddl[0].Records() {
+			column := &model.ColumnSchema{
+				Name:     r[0],
+				Type:     typeWithLength(r[1], r[2]),
+				Nullable: r[3] == "YES",
+				Default:  r[4],
+			}
+			switch full, ok := fullColumns[r[0]]; {
+			case ok:
+				column.Type, column.Extra, column.Collation, column.Comment = full.Type, full.Extra, full.Collation, full.Comment
+			case g.schemaGetter.dbmsType != config.SQLite3:
+				// The declared type of SQLite3 is the full type.
+				column.TypeIncomplete = true
+			}
+			schema.Columns = append(schema.Columns, column)
+			if r[5] == "PRI" {
+				keyColumns = append(keyColumns, r[0])
+			}
+		}
+
+		indexes, err := g.schemaGetter.GetIndexes(ctx, table.Name())
+		if err != nil {
+			return nil, err
+		}
+		for _, index := range indexes {
+			switch {
+			case index.Primary:
+				schema.PrimaryKey = index.Columns
+				schema.PrimaryKeyName = index.Name
+			case strings.HasPrefix(index.Name, "sqlite_autoindex_"):
+				continue
+			default:
+				schema.Indexes = append(schema.Indexes, index)
+			}
+		}
+		// A SQLite3 INTEGER PRIMARY KEY has no index, so the key columns of the DDL are used.
+		if len(schema.PrimaryKey) == 0 && len(keyColumns) > 0 {
+			schema.PrimaryKey = keyColumns
+		}
+
+		if schema.ForeignKeys, err = g.schemaGetter.GetForeignKeys(ctx, table.Name()); err != nil {
+			return nil, err
+		}
+		schemas = append(schemas, schema)
+	}
+	return schemas, nil
+}
+
+// fullColumns returns the full types of the columns by column name, e.g. "decimal(10,2) unsigned"
+// instead of "decimal". MySQL columns also have the attributes, the collation and the comment.
+// It returns nil for the DBMS whose full types are not read.
+func (g *tableSchemasGetter) fullColumns(ctx context.Context, tableName string) (map[string]*model.ColumnSchema, error) {
+	var rows [][]string
+	var err error
+	switch g.schemaGetter.dbmsType {
+	case config.MySQL:
+		rows, err = g.schemaGetter.queryStrings(ctx, `
+            SELECT COLUMN_NAME, COLUMN_TYPE, EXTRA, COLLATION_NAME, COLUMN_COMMENT
+            FROM information_schema.columns
+            WHERE table_schema=? AND table_name=?`, g.schemaGetter.database, tableName)
+	case config.PostgreSQL:
+		rows, err = g.schemaGetter.queryStrings(ctx, `
+            SELECT a.attname, pg_catalog.format_type(a.atttypid, a.atttypmod)
+            FROM pg_catalog.pg_attribute a
+            JOIN pg_catalog.pg_class c ON c.oid = a.attrelid
+            JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+            WHERE n.nspname = current_schema() AND c.relname = $1 AND a.attnum > 0 AND NOT a.attisdropped`, tableName)
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make(map[string]*model.ColumnSchema, len(rows))
+	for _, r := range rows {
+		column := &model.ColumnSchema{Name: r[0], Type: r[1]}
+		if len(r) == 5 {
+			// MySQL 8.0 marks a default expression with DEFAULT_GENERATED, which is not written in DDL.
+			column.Extra = strings.Join(strings.Fields(strings.ReplaceAll(r[2], "DEFAULT_GENERATED", "")), " ")
+			column.Collation, column.Comment = r[3], r[4]
+		}
+		columns[r[0]] = column
+	}
+	return columns, nil
+}
+
+// typeWithLength appends the length to the character and binary types, e.g. "varchar(255)".
+// The length -1 means max in SQL Server.
+func typeWithLength(dataType, length string) string {
+	if length == "" || length == "0" || strings.Contains(dataType, "(") {
+		return dataType
+	}
+	lower := strings.ToLower(dataType)
+	if !strings.Contains(lower, "char") && !strings.Contains(lower, "binary") {
+		return dataType
+	}
+	if length == "-1" {
+		length = "max"
+	}
+	return dataType + "(" + length + ")"
+}
+
+// alterStatementsGenerator writes the DDL statements that apply a schema diff for the DBMS.
+type alterStatementsGenerator struct {
+	dbmsType config.DBMSType
+	dialect  config.Dialect
+}
+
+// NewAlterStatementsGenerator returns AlterStatementsGenerator for the DBMS of the connection.
+func NewAlterStatementsGenerator(conf *config.DBConnection) repository.AlterStatementsGenerator {
+	return &alterStatementsGenerator{dbmsType: conf.Type, dialect: config.NewDialect(conf.Type)}
+}
+
+// GenerateAlterStatements returns the statements that turn the "from" schema of the diff into the "to" schema.
+// Tables are created first and dropped last. A change that the DBMS cannot apply with ALTER TABLE,
+// such as changing a column in SQLite3, is written as a comment. It returns an empty string if there is no difference.
+func (g *alterStatementsGenerator) GenerateAlterStatements(diff *model.SchemaDiff) string {
+	stmts := []string{}
+	for _, t := range diff.AddedTables {
+		stmts = append(stmts, g.createTable(t)...)
+	}
+	for _, t := range diff.ChangedTables {
+		stmts = append(stmts, g.alterTable(t)...)
+	}
+	for _, t := range diff.DroppedTables {
+		stmts = append(stmts, "DROP TABLE "+g.dialect.QuoteIdentifier(t.Name))
+	}
+
+	lines := make([]string, 0, len(stmts))
+	for _, stmt := range stmts {
+		if !strings.HasPrefix(stmt, "--") {
+			stmt += ";"
+		}
+		lines = append(lines, stmt)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// createTable returns CREATE TABLE and CREATE INDEX statements for the table.
+func (g *alterStatementsGenerator) createTable(t *model.TableSchema) []string {
+	lines := make([]string, 0, len(t.Columns)+1)
+	for _, c := range t.Columns {
+		lines = append(lines, g.columnDefinition(c))
+	}
+	if len(t.PrimaryKey) > 0 {
+		lines = append(lines, "PRIMARY KEY ("+g.dialect.QuoteIdentifiers(t.PrimaryKey)+")")
+	}
+	stmts := []string{createTable(g.dialect.QuoteIdentifier(t.Name), lines)}
+	for _, index := range t.Indexes {
+		stmts = append(stmts, g.createIndex(t.Name, index))
+	}
+	return stmts
+}
+
+// alterTable returns the statements for the changed table. Indexes and the primary key are dropped
+// before the columns are changed, and created after that.
+func (g *alterStatementsGenerator) alterTable(t *model.TableDiff) []string {
+	table := g.dialect.QuoteIdentifier(t.Name)
+	stmts := []string{}
+	for _, index := range t.DroppedIndexes {
+		stmts = append(stmts, g.dropIndex(t.Name, index))
+	}
+	if t.PrimaryKeyChanged() && len(t.FromPrimaryKey) > 0 {
+		switch g.dbmsType {
+		case config.MySQL:
+			stmts = append(stmts, "ALTER TABLE "+table+" DROP PRIMARY KEY")
+		case config.SQLite3:
+			stmts = append(stmts, fmt.Sprintf("-- SQLite3 cannot change the primary key of %s: rebuild the table", t.Name))
+		default:
+			if t.FromPrimaryKeyName == "" {
+				stmts = append(stmts, "-- drop the primary key constraint of "+t.Name+" before adding the new one")
+				break
+			}
+			stmts = append(stmts, "ALTER TABLE "+table+" DROP CONSTRAINT "+g.dialect.QuoteIdentifier(t.FromPrimaryKeyName))
+		}
+	}
+
+	for _, c := range t.AddedColumns {
+		if g.dbmsType == config.SQLServer || g.dbmsType == config.Oracle {
+			stmts = append(stmts, "ALTER TABLE "+table+" ADD "+g.columnDefinition(c))
+			continue
+		}
+		stmts = append(stmts, "ALTER TABLE "+table+" ADD COLUMN "+g.columnDefinition(c))
+	}
+	for _, c := range t.ChangedColumns {
+		stmts = append(stmts, g.alterColumn(t.Name, c)...)
+	}
+	for _, c := range t.DroppedColumns {
+		stmts = append(stmts, "ALTER TABLE "+table+" DROP COLUMN "+g.dialect.QuoteIdentifier(c.Name))
+	}
+
+	if t.PrimaryKeyChanged() && len(t.ToPrimaryKey) > 0 && g.dbmsType != config.SQLite3 {
+		stmts = append(stmts, "ALTER TABLE "+table+" ADD PRIMARY KEY ("+g.dialect.QuoteIdentifiers(t.ToPrimaryKey)+")")
+	}
+	for _, index := range t.AddedIndexes {
+		stmts = append(stmts, g.createIndex(t.Name, index))
+	}
+	return stmts
+}
+
+// alterColumn returns the statements that change the type, nullability and default of the column.
+// MySQL rewrites the whole column, so the attributes, the collation and the comment are also written.
+// If the statement needs the type and the full type is unknown, a comment is written instead,
+// because the precision and the other parts of the type would be lost.
+func (g *alterStatementsGenerator) alterColumn(tableName string, c *model.ColumnChange) []string {
+	table := g.dialect.QuoteIdentifier(tableName)
+	column := g.dialect.QuoteIdentifier(c.To.Name)
+	writesType := c.TypeChanged() || g.dbmsType == config.MySQL || (g.dbmsType == config.SQLServer && c.NullableChanged())
+	if writesType && c.To.TypeIncomplete && g.dbmsType != config.SQLite3 {
+		return []string{fmt.Sprintf("-- the full type of column %s of %s is unknown: change it to %s by hand",
+			c.To.Name, tableName, c.To.String())}
+	}
+	switch g.dbmsType {
+	case config.MySQL:
+		if isGeneratedColumn(c.To) {
+			return []string{fmt.Sprintf("-- column %s of %s is a generated column: change its expression by hand", c.To.Name, tableName)}
+		}
+		return []string{"ALTER TABLE " + table + " MODIFY COLUMN " + g.columnDefinition(c.To)}
+	case config.PostgreSQL:
+		stmts := []string{}
+		if c.TypeChanged() {
+			stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s TYPE %s", table, column, c.To.Type))
+		}
+		if c.NullableChanged() {
+			action := "SET NOT NULL"
+			if c.To.Nullable {
+				action = "DROP NOT NULL"
+			}
+			stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s %s", table, column, action))
+		}
+		if c.DefaultChanged() {
+			action := "DROP DEFAULT"
+			if c.To.Default != "" {
+				action = "SET DEFAULT " + c.To.Default
+			}
+			stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s %s", table, column, action))
+		}
+		return stmts
+	case config.SQLServer:
+		stmts := []string{}
+		if c.TypeChanged() || c.NullableChanged() {
+			stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s %s %s",
+				table, column, c.To.Type, nullability(c.To.Nullable)))
+		}
+		if c.DefaultChanged() {
+			if c.From.Default != "" {
+				stmts = append(stmts, fmt.Sprintf("-- drop the default constraint of %s.%s before changing the default", tableName, c.To.Name))
+			}
+			if c.To.Default != "" {
+				stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s ADD DEFAULT %s FOR %s", table, c.To.Default, column))
+			}
+		}
+		return stmts
+	case config.Oracle:
+		// MODIFY fails if the column already has the nullability, so only the changed attributes are written.
+		definition := column
+		if c.TypeChanged() {
+			definition += " " + c.To.Type
+		}
+		if c.DefaultChanged() {
+			value := c.To.Default
+			if value == "" {
+				value = "NULL"
+			}
+			definition += " DEFAULT " + value
+		}
+		if c.NullableChanged() {
+			definition += " " + nullability(c.To.Nullable)
+		}
+		return []string{fmt.Sprintf("ALTER TABLE %s MODIFY (%s)", table, definition)}
+	default:
+		return []string{fmt.Sprintf("-- SQLite3 cannot change column %s of %s to %s: rebuild the table",
+			c.To.Name, tableName, c.To.String())}
+	}
+}
+
+// nullability returns "NULL" or "NOT NULL". SQL Server makes the column nullable if ALTER COLUMN omits it,
+// so it is always written for SQL Server.
+func nullability(nullable bool) string {
+	if nullable {
+		return "NULL"
+	}
+	return "NOT NULL"
+}
+
+// columnDefinition returns the column definition for CREATE TABLE and ADD COLUMN.
+func (g *alterStatementsGenerator) columnDefinition(c *model.ColumnSchema) string {
+	s := g.dialect.QuoteIdentifier(c.Name) + " " + c.Type
+	if g.dbmsType == config.Oracle {
+		// Oracle Database requires DEFAULT before the constraints.
+		if c.Default != "" {
+			s += " DEFAULT " + c.Default
+		}
+		if !c.Nullable {
+			s += " NOT NULL"
+		}
+		return s
+	}
+	if g.dbmsType == config.MySQL && c.Collation != "" {
+		s += " COLLATE " + c.Collation
+	}
+	if !c.Nullable {
+		s += " NOT NULL"
+	}
+	if c.Default != "" {
+		s += " DEFAULT " + c.Default
+	}
+	if g.dbmsType == config.MySQL {
+		if c.Extra != "" && !isGeneratedColumn(c) {
+			s += " " + c.Extra
+		}
+		if c.Comment != "" {
+			s += " COMMENT " + g.dialect.QuoteLiteral(c.Comment)
+		}
+	}
+	return s
+}
+
+// isGeneratedColumn returns true if the MySQL column is a generated column, whose expression is not read.
+func isGeneratedColumn(c *model.ColumnSchema) bool {
+	return strings.Contains(strings.ToUpper(c.Extra), "GENERATED")
+}
+
+// createIndex returns CREATE INDEX statement.
+func (g *alterStatementsGenerator) createIndex(tableName string, index *model.Index) string {
+	unique := ""
+	if index.Unique {
+		unique = "UNIQUE "
+	}
+	return fmt.Sprintf("CREATE %sINDEX %s ON %s (%s)",
+		unique, g.dialect.QuoteIdentifier(index.Name), g.dialect.QuoteIdentifier(tableName), g.dialect.QuoteIdentifiers(index.Columns))
+}
+
+// dropIndex returns DROP INDEX statement.
+func (g *alterStatementsGenerator) dropIndex(tableName string, index *model.Index) string {
+	switch g.dbmsType {
+	case config.MySQL, config.SQLServer:
+		return "DROP INDEX " + g.dialect.QuoteIdentifier(index.Name) + " ON " + g.dialect.QuoteIdentifier(tableName)
+	default:
+		return "DROP INDEX " + g.dialect.QuoteIdentifier(index.Name)
+	}
+}
@@ -0,0 +1,230 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/nao1215/sqluv/config"
+	"github.com/nao1215/sqluv/domain/model"
+)
+
+func TestSchemaDiffSQLite3(t *testing.T) {
+	t.Parallel()
+
+	fromPath := newTestSQLite3DB(t,
+		"CREATE TABLE user (id INTEGER PRIMARY KEY, name TEXT, age INTEGER, email TEXT UNIQUE)",
+		"CREATE INDEX idx_user_age ON user (age)",
+		"CREATE TABLE old (id INTEGER)",
+	)
+	toPath := newTestSQLite3DB(t,
+		"CREATE TABLE user (id INTEGER PRIMARY KEY, name TEXT NOT NULL DEFAULT 'none', email TEXT UNIQUE, created_at TEXT)",
+		"CREATE INDEX idx_user_name ON user (name)",
+		"CREATE TABLE item (id INTEGER NOT NULL, code VARCHAR(10), PRIMARY KEY (id))",
+		"CREATE UNIQUE INDEX idx_item_code ON item (code)",
+	)
+	ctx := context.Background()
+	getSchemas := func(t *testing.T, path string) []*model.TableSchema {
+		t.Helper()
+		db, closeDB, err := config.NewSQLite3DB(config.NewSQLite3Config(path, false))
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(closeDB)
+		schemas, err := NewTableSchemasGetter(db, &config.DBConnection{Type: config.SQLite3, Database: path}).GetTableSchemas(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return schemas
+	}
+
+	from := getSchemas(t, fromPath)
+	wantUser := &model.TableSchema{
+		Name: "user",
+		Columns: []*model.ColumnSchema{
+			{Name: "id", Type: "INTEGER", Nullable: true},
+			{Name: "name", Type: "TEXT", Nullable: true},
+			{Name: "age", Type: "INTEGER", Nullable: true},
+			{Name: "email", Type: "TEXT", Nullable: true},
+		},
+		PrimaryKey:  []string{"id"},
+		Indexes:     []*model.Index{{Name: "idx_user_age", Columns: []string{"age"}}},
+		ForeignKeys: []*model.ForeignKey{},
+	}
+	if diff := cmp.Diff(wantUser, from[0]); diff != "" {
+		t.Errorf("GetTableSchemas() mismatch (-want +got):\n%s", diff)
+	}
+
+	diff := model.DiffSchemas(from, getSchemas(t, toPath))
+	got := NewAlterStatementsGenerator(&config.DBConnection{Type: config.SQLite3}).GenerateAlterStatements(diff)
+	want := `CREATE TABLE "item" (
+    "id" INTEGER NOT NULL,
+    "code" VARCHAR(10),
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX "idx_item_code" ON "item" ("code");
+DROP INDEX "idx_user_age";
+ALTER TABLE "user" ADD COLUMN "created_at" TEXT;
+-- SQLite3 cannot change column name of user to name TEXT NOT NULL DEFAULT 'none': rebuild the table
+ALTER TABLE "user" DROP COLUMN "age";
+CREATE INDEX "idx_user_name" ON "user" ("name");
+DROP TABLE "old";`
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("GenerateAlterStatements() mismatch (-want +got):\n%s", d)
+	}
+
+	// The statements can be applied to the "from" database.
+	db, closeDB, err := config.NewSQLite3DB(config.NewSQLite3Config(fromPath, false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(closeDB)
+	if _, err := (*sql.DB)(db).Exec(got); err != nil {
+		t.Fatal(err)
+	}
+	if remains := model.DiffSchemas(getSchemas(t, fromPath), getSchemas(t, toPath)); len(remains.ChangedTables) != 1 ||
+		len(remains.AddedTables) != 0 || len(remains.DroppedTables) != 0 {
+		t.Errorf("only the column that SQLite3 cannot change should remain:\n%s", remains.String())
+	}
+}
+
+func TestAlterStatementsGenerator(t *testing.T) {
+	t.Parallel()
+
+	diff := &model.SchemaDiff{
+		ChangedTables: []*model.TableDiff{
+			{
+				Name: "user",
+				ChangedColumns: []*model.ColumnChange{
+					{
+						From: &model.ColumnSchema{Name: "name", Type: "varchar(10)", Nullable: true},
+						To:   &model.ColumnSchema{Name: "name", Type: "varchar(20)", Default: "'none'"},
+					},
+				},
+				FromPrimaryKey:     []string{"id"},
+				ToPrimaryKey:       []string{"id", "name"},
+				FromPrimaryKeyName: "user_pkey",
+				DroppedIndexes:     []*model.Index{{Name: "idx_user_name", Columns: []string{"name"}}},
+			},
+		},
+	}
+	tests := []struct {
+		dbmsType config.DBMSType
+		want     string
+	}{
+		{
+			dbmsType: config.PostgreSQL,
+			want: `DROP INDEX "idx_user_name";
+ALTER TABLE "user" DROP CONSTRAINT "user_pkey";
+ALTER TABLE "user" ALTER COLUMN "name" TYPE varchar(20);
+ALTER TABLE "user" ALTER COLUMN "name" SET NOT NULL;
+ALTER TABLE "user" ALTER COLUMN "name" SET DEFAULT 'none';
+ALTER TABLE "user" ADD PRIMARY KEY ("id", "name");`,
+		},
+		{
+			dbmsType: config.MySQL,
+			want: "DROP INDEX `idx_user_name` ON `user`;\n" +
+				"ALTER TABLE `user` DROP PRIMARY KEY;\n" +
+				"ALTER TABLE `user` MODIFY COLUMN `name` varchar(20) NOT NULL DEFAULT 'none';\n" +
+				"ALTER TABLE `user` ADD PRIMARY KEY (`id`, `name`);",
+		},
+		{
+			dbmsType: config.SQLServer,
+			want: `DROP INDEX [idx_user_name] ON [user];
+ALTER TABLE [user] DROP CONSTRAINT [user_pkey];
+ALTER TABLE [user] ALTER COLUMN [name] varchar(20) NOT NULL;
+ALTER TABLE [user] ADD DEFAULT 'none' FOR [name];
+ALTER TABLE [user] ADD PRIMARY KEY ([id], [name]);`,
+		},
+		{
+			dbmsType: config.Oracle,
+			want: `DROP INDEX "idx_user_name";
+ALTER TABLE "user" DROP CONSTRAINT "user_pkey";
+ALTER TABLE "user" MODIFY ("name" varchar(20) DEFAULT 'none' NOT NULL);
+ALTER TABLE "user" ADD PRIMARY KEY ("id", "name");`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.dbmsType), func(t *testing.T) {
+			t.Parallel()
+
+			got := NewAlterStatementsGenerator(&config.DBConnection{Type: tt.dbmsType}).GenerateAlterStatements(diff)
+			if d := cmp.Diff(tt.want, got); d != "" {
+				t.Errorf("GenerateAlterStatements() mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
+
+func TestAlterStatementsGeneratorColumnType(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		dbmsType config.DBMSType
+		change   *model.ColumnChange
+		want     string
+	}{
+		{
+			name:     "MySQL keeps the attributes, the collation and the comment",
+			dbmsType: config.MySQL,
+			change: &model.ColumnChange{
+				From: &model.ColumnSchema{Name: "id", Type: "int unsigned", Extra: "auto_increment"},
+				To: &model.ColumnSchema{
+					Name: "id", Type: "bigint unsigned", Extra: "auto_increment", Collation: "utf8mb4_bin", Comment: "user's id",
+				},
+			},
+			want: "ALTER TABLE `user` MODIFY COLUMN `id` bigint unsigned COLLATE utf8mb4_bin NOT NULL auto_increment COMMENT 'user''s id';",
+		},
+		{
+			name:     "MySQL does not modify a column whose full type is unknown",
+			dbmsType: config.MySQL,
+			change: &model.ColumnChange{
+				From: &model.ColumnSchema{Name: "price", Type: "decimal(10,2)", Nullable: true},
+				To:   &model.ColumnSchema{Name: "price", Type: "decimal", TypeIncomplete: true},
+			},
+			want: "-- the full type of column price of user is unknown: change it to price decimal NOT NULL by hand",
+		},
+		{
+			name:     "MySQL does not modify a generated column",
+			dbmsType: config.MySQL,
+			change: &model.ColumnChange{
+				From: &model.ColumnSchema{Name: "total", Type: "int", Extra: "VIRTUAL GENERATED"},
+				To:   &model.ColumnSchema{Name: "total", Type: "bigint", Extra: "VIRTUAL GENERATED"},
+			},
+			want: "-- column total of user is a generated column: change its expression by hand",
+		},
+		{
+			name:     "PostgreSQL changes the precision",
+			dbmsType: config.PostgreSQL,
+			change: &model.ColumnChange{
+				From: &model.ColumnSchema{Name: "price", Type: "numeric(10,2)"},
+				To:   &model.ColumnSchema{Name: "price", Type: "numeric(12,4)"},
+			},
+			want: `ALTER TABLE "user" ALTER COLUMN "price" TYPE numeric(12,4);`,
+		},
+		{
+			name:     "PostgreSQL changes the nullability of a column whose full type is unknown",
+			dbmsType: config.PostgreSQL,
+			change: &model.ColumnChange{
+				From: &model.ColumnSchema{Name: "price", Type: "numeric", Nullable: true, TypeIncomplete: true},
+				To:   &model.ColumnSchema{Name: "price", Type: "numeric", TypeIncomplete: true},
+			},
+			want: `ALTER TABLE "user" ALTER COLUMN "price" SET NOT NULL;`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			diff := &model.SchemaDiff{
+				ChangedTables: []*model.TableDiff{{Name: "user", ChangedColumns: []*model.ColumnChange{tt.change}}},
+			}
+			got := NewAlterStatementsGenerator(&config.DBConnection{Type: tt.dbmsType}).GenerateAlterStatements(diff)
+			if d := cmp.Diff(tt.want, got); d != "" {
+				t.Errorf("GenerateAlterStatements() mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
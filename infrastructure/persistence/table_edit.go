@@ -0,0 +1,163 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/nao1215/sqluv/config"
+	"github.com/nao1215/sqluv/domain/model"
+	"github.com/nao1215/sqluv/domain/repository"
+	"github.com/nao1215/sqluv/infrastructure"
+)
+
+// _ interface implementation check
+var _ repository.RemoteTableEditor = (*remoteTableEditor)(nil)
+
+// remoteTableEditor writes the rows edited in the result grid with UPDATE, INSERT and DELETE statements.
+type remoteTableEditor struct {
+	db       *sql.DB
+	dbmsType config.DBMSType
+	dialect  config.Dialect
+	readOnly bool
+}
+
+// NewRemoteTableEditor returns RemoteTableEditor. db must be opened by config.NewTableEditDBMS.
+func NewRemoteTableEditor(db config.DBMS, conf *config.DBConnection) repository.RemoteTableEditor {
+	return &remoteTableEditor{
+		db:       db,
+		dbmsType: conf.Type,
+		dialect:  config.NewDialect(conf.Type),
+		readOnly: conf.ReadOnly,
+	}
+}
+
+// EditStatements returns the statements that write the edits. The values are bind parameters, and the
+// rows are updated and deleted by the primary key. The table is written as it is in the query.
+func (e *remoteTableEditor) EditStatements(edit *model.TableEdit) ([]*model.EditStatement, error) {
+	if e.readOnly {
+		return nil, infrastructure.ErrReadOnlyConnection
+	}
+	changes, err := edit.Changes()
+	if err != nil {
+		return nil, err
+	}
+
+	stmts := make([]*model.EditStatement, 0, len(changes))
+	for _, c := range changes {
+		b := &editStatementBuilder{editor: e}
+		switch c.Kind {
+		case model.RowChangeUpdate:
+			b.sql("UPDATE " + edit.Name() + " SET ")
+			for i, column := range c.Columns {
+				if i > 0 {
+					b.sql(", ")
+				}
+				b.sql(e.dialect.QuoteIdentifier(column) + " = ")
+				b.value(c.Values[i])
+			}
+			b.keyCondition(c)
+			// A MySQL database is opened with clientFoundRows, so the row is counted
+			// even if the new values are the same as the current ones.
+			b.stmt.OneRow = true
+		case model.RowChangeDelete:
+			b.sql("DELETE FROM " + edit.Name())
+			b.keyCondition(c)
+			b.stmt.OneRow = true
+		case model.RowChangeInsert:
+			columns := make([]string, 0, len(c.Columns))
+			for _, column := range c.Columns {
+				columns = append(columns, e.dialect.QuoteIdentifier(column))
+			}
+			b.sql("INSERT INTO " + edit.Name() + " (" + strings.Join(columns, ", ") + ") VALUES (")
+			for i, v := range c.Values {
+				if i > 0 {
+					b.sql(", ")
+				}
+				b.value(v)
+			}
+			b.sql(")")
+		}
+		stmts = append(stmts, b.build())
+	}
+	return stmts, nil
+}
+
+// ApplyEditStatements executes the statements in a transaction. If an UPDATE or DELETE does not change
+// exactly one row, e.g. the row has been deleted by another session, nothing is written.
+func (e *remoteTableEditor) ApplyEditStatements(ctx context.Context, stmts []*model.EditStatement) (int64, error) {
+	if e.readOnly {
+		return 0, infrastructure.ErrReadOnlyConnection
+	}
+
+	tx, err := e.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var total int64
+	for _, stmt := range stmts {
+		result, err := tx.ExecContext(ctx, stmt.Query, stmt.Args...)
+		if err != nil {
+			return 0, fmt.Errorf("%w: sql='%s'", err, stmt.Preview)
+		}
+		n, err := result.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+		if stmt.OneRow && n != 1 {
+			return 0, fmt.Errorf("%d rows were changed instead of 1, so nothing was written: sql='%s'", n, stmt.Preview)
+		}
+		total += n
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+// editStatementBuilder builds a statement with bind parameters and its preview with literals at the same time.
+type editStatementBuilder struct {
+	editor  *remoteTableEditor
+	stmt    model.EditStatement
+	query   strings.Builder
+	preview strings.Builder
+}
+
+// sql writes the SQL text to the statement and the preview.
+func (b *editStatementBuilder) sql(s string) {
+	b.query.WriteString(s)
+	b.preview.WriteString(s)
+}
+
+// value writes the value as a bind parameter, and as a literal to the preview. NULL is written as is.
+func (b *editStatementBuilder) value(v model.CellValue) {
+	if v.Null {
+		b.sql("NULL")
+		return
+	}
+	b.stmt.Args = append(b.stmt.Args, v.Value)
+	b.query.WriteString(b.editor.dialect.Placeholder(len(b.stmt.Args)))
+	b.preview.WriteString(b.editor.dialect.QuoteLiteral(v.Value))
+}
+
+// keyCondition writes the WHERE clause that matches the primary key of the row.
+func (b *editStatementBuilder) keyCondition(c *model.RowChange) {
+	b.sql(" WHERE ")
+	for i, column := range c.KeyColumns {
+		if i > 0 {
+			b.sql(" AND ")
+		}
+		b.sql(b.editor.dialect.QuoteIdentifier(column) + " = ")
+		b.value(model.CellValue{Value: c.KeyValues[i]})
+	}
+}
+
+// build returns the statement.
+func (b *editStatementBuilder) build() *model.EditStatement {
+	b.stmt.Query = b.query.String()
+	b.stmt.Preview = b.preview.String()
+	return &b.stmt
+}
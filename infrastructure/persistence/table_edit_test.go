@@ -0,0 +1,171 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/nao1215/sqluv/config"
+	"github.com/nao1215/sqluv/domain/model"
+	"github.com/nao1215/sqluv/infrastructure"
+)
+
+func TestRemoteTableEditorSQLite3(t *testing.T) {
+	t.Parallel()
+
+	path := newTestSQLite3DB(t,
+		"CREATE TABLE user (id INTEGER PRIMARY KEY, name TEXT, note TEXT DEFAULT 'new')",
+		"INSERT INTO user VALUES (1, 'gina', 'a'), (2, 'o''brien', 'b'), (3, 'hana', 'c')",
+	)
+	db, closeDB, err := config.NewSQLite3DB(config.NewSQLite3Config(path, false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(closeDB)
+	editor := NewRemoteTableEditor(db, &config.DBConnection{Type: config.SQLite3, Database: path})
+
+	result := model.NewTable("user",
+		model.Header{"id", "name", "note"},
+		[]model.Record{{"1", "gina", "a"}, {"2", "o'brien", "b"}, {"3", "hana", "c"}})
+	edit, err := model.NewTableEdit("user", result, []string{"id"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := edit.SetCell(1, 1, model.CellValue{Value: "it's"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := edit.SetCell(1, 2, model.CellValue{Null: true}); err != nil {
+		t.Fatal(err)
+	}
+	edit.ToggleDelete(2)
+	row := edit.AddRow()
+	if err := edit.SetCell(row, 1, model.CellValue{Value: "ivy"}); err != nil {
+		t.Fatal(err)
+	}
+
+	stmts, err := editor.EditStatements(edit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	previews := []string{}
+	for _, s := range stmts {
+		previews = append(previews, s.Preview)
+	}
+	wantPreviews := []string{
+		`DELETE FROM user WHERE "id" = '3'`,
+		`UPDATE user SET "name" = 'it''s', "note" = NULL WHERE "id" = '2'`,
+		`INSERT INTO user ("name") VALUES ('ivy')`,
+	}
+	if diff := cmp.Diff(wantPreviews, previews); diff != "" {
+		t.Errorf("EditStatements() previews mismatch (-want +got):\n%s", diff)
+	}
+	if want := `UPDATE user SET "name" = ?, "note" = NULL WHERE "id" = ?`; stmts[1].Query != want {
+		t.Errorf("EditStatements() query = %q, want %q", stmts[1].Query, want)
+	}
+
+	n, err := editor.ApplyEditStatements(context.Background(), stmts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 3 {
+		t.Errorf("ApplyEditStatements() = %d, want 3", n)
+	}
+	want := [][]string{{"1", "gina", "a"}, {"2", "it's", "<nil>"}, {"3", "ivy", "new"}}
+	if diff := cmp.Diff(want, selectUsers(t, db)); diff != "" {
+		t.Errorf("rows mismatch after ApplyEditStatements() (-want +got):\n%s", diff)
+	}
+
+	// The row 9 does not exist, so nothing is written, including the update before it.
+	stale, err := model.NewTableEdit("user",
+		model.NewTable("user", model.Header{"id", "name"}, []model.Record{{"1", "gina"}, {"9", "jun"}}),
+		[]string{"id"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := stale.SetCell(0, 1, model.CellValue{Value: "gin"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := stale.SetCell(1, 1, model.CellValue{Value: "june"}); err != nil {
+		t.Fatal(err)
+	}
+	if stmts, err = editor.EditStatements(stale); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := editor.ApplyEditStatements(context.Background(), stmts); err == nil {
+		t.Error("ApplyEditStatements() returns no error for a missing row")
+	}
+	if diff := cmp.Diff(want, selectUsers(t, db)); diff != "" {
+		t.Errorf("rows are changed by the failed ApplyEditStatements() (-want +got):\n%s", diff)
+	}
+
+	readOnly := NewRemoteTableEditor(db, &config.DBConnection{Type: config.SQLite3, Database: path, ReadOnly: true})
+	if _, err := readOnly.EditStatements(edit); err != infrastructure.ErrReadOnlyConnection {
+		t.Errorf("EditStatements() error = %v, want %v", err, infrastructure.ErrReadOnlyConnection)
+	}
+}
+
+func TestRemoteTableEditorPlaceholders(t *testing.T) {
+	t.Parallel()
+
+	result := model.NewTable("t", model.Header{"id", "name"}, []model.Record{{"1", `a\b`}})
+	edit, err := model.NewTableEdit("t", result, []string{"id"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := edit.SetCell(0, 1, model.CellValue{Value: `c\d`}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		dbmsType    config.DBMSType
+		wantQuery   string
+		wantPreview string
+	}{
+		{config.PostgreSQL, `UPDATE t SET "name" = $1 WHERE "id" = $2`, `UPDATE t SET "name" = 'c\d' WHERE "id" = '1'`},
+		{config.MySQL, "UPDATE t SET `name` = ? WHERE `id` = ?", "UPDATE t SET `name` = 'c\\\\d' WHERE `id` = '1'"},
+		{config.SQLServer, `UPDATE t SET [name] = @p1 WHERE [id] = @p2`, `UPDATE t SET [name] = N'c\d' WHERE [id] = N'1'`},
+		{config.Oracle, `UPDATE t SET "name" = :1 WHERE "id" = :2`, `UPDATE t SET "name" = 'c\d' WHERE "id" = '1'`},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.dbmsType), func(t *testing.T) {
+			t.Parallel()
+			editor := NewRemoteTableEditor(nil, &config.DBConnection{Type: tt.dbmsType})
+			stmts, err := editor.EditStatements(edit)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if stmts[0].Query != tt.wantQuery || stmts[0].Preview != tt.wantPreview {
+				t.Errorf("EditStatements() = (%q, %q), want (%q, %q)", stmts[0].Query, stmts[0].Preview, tt.wantQuery, tt.wantPreview)
+			}
+		})
+	}
+}
+
+// selectUsers returns all rows of the user table.
+func selectUsers(t *testing.T, db config.DBMS) [][]string {
+	t.Helper()
+
+	rows, err := (*sql.DB)(db).Query("SELECT id, name, note FROM user ORDER BY id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	got := [][]string{}
+	for rows.Next() {
+		var id, name string
+		var note sql.NullString
+		if err := rows.Scan(&id, &name, &note); err != nil {
+			t.Fatal(err)
+		}
+		n := "<nil>"
+		if note.Valid {
+			n = note.String
+		}
+		got = append(got, []string{id, name, n})
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return got
+}
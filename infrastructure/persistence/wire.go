@@ -13,6 +13,7 @@ var Set = wire.NewSet(
 	NewTSVWriter,
 	NewLTSVReader,
 	NewLTSVWriter,
+	NewJSONWriter,
 	NewHistoryTableCreator,
 	NewHistoryCreator,
 	NewHistoryLister,
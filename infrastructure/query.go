@@ -52,14 +52,17 @@ func Query(ctx context.Context, tx *sql.Tx, query *model.SQL) (*model.Table, err
 	return model.NewTable(ExtractTableName(query), header, records), nil
 }
 
+// identifierQuotes removes the quotes of identifiers in MySQL, SQL Server and the other DBMS.
+var identifierQuotes = strings.NewReplacer("`", "", `"`, "", "[", "", "]", "")
+
 // ExtractTableName extract table name from query.
-// The query must be "SELECT" or "EXPLAIN" statement.
+// The query must be "SELECT" or "EXPLAIN" statement. The quotes of the name are removed.
 func ExtractTableName(sql *model.SQL) string {
 	if sql.IsWith() {
 		return ""
 	}
 
-	query := strings.ReplaceAll(sql.String(), "`", "")
+	query := identifierQuotes.Replace(sql.String())
 	words := strings.Fields(query)
 	for i, v := range words {
 		if strings.EqualFold(v, "FROM") || strings.EqualFold(v, "from") {
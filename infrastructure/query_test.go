@@ -0,0 +1,36 @@
+package infrastructure
+
+import (
+	"testing"
+
+	"github.com/nao1215/sqluv/domain/model"
+)
+
+func TestExtractTableName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{name: "plain name", query: "SELECT * FROM users LIMIT 100", want: "users"},
+		{name: "MySQL", query: "SELECT * FROM `shop`.`users` LIMIT 100", want: "shop.users"},
+		{name: "PostgreSQL", query: `SELECT * FROM "public"."Users" LIMIT 100`, want: "public.Users"},
+		{name: "SQL Server", query: "SELECT TOP 100 * FROM [dbo].[users]", want: "dbo.users"},
+		{name: "WITH", query: "WITH u AS (SELECT * FROM users) SELECT * FROM u", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			sql, err := model.NewSQL(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := ExtractTableName(sql); got != tt.want {
+				t.Errorf("ExtractTableName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
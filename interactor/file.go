@@ -51,6 +51,7 @@ type fileWriter struct {
 	repository.CSVWriter
 	repository.TSVWriter
 	repository.LTSVWriter
+	repository.JSONWriter
 }
 
 // NewFileWriter create new FileWriter.
@@ -58,11 +59,13 @@ func NewFileWriter(
 	csvWriter repository.CSVWriter,
 	tsvWriter repository.TSVWriter,
 	ltsvWriter repository.LTSVWriter,
+	jsonWriter repository.JSONWriter,
 ) usecase.FileWriter {
 	return &fileWriter{
 		CSVWriter:  csvWriter,
 		TSVWriter:  tsvWriter,
 		LTSVWriter: ltsvWriter,
+		JSONWriter: jsonWriter,
 	}
 }
 
@@ -75,6 +78,8 @@ func (w fileWriter) WriteFile(ctx context.Context, file *model.File, table *mode
 		return w.TSVWriter.WriteTSV(ctx, file, table)
 	case file.IsLTSV():
 		return w.LTSVWriter.WriteLTSV(ctx, file, table)
+	case file.IsJSON():
+		return w.JSONWriter.WriteJSON(ctx, file, table)
 	default:
 		return usecase.ErrNotSupportedFileFormat
 	}
@@ -126,3 +126,24 @@ func NewTableDDLGetter(
 func (r *tableDDLGetter) GetTableDDL(ctx context.Context, tableName string) ([]*model.Table, error) {
 	return r.TableDDLGetter.GetTableDDL(ctx, tableName)
 }
+
+// _ interface implementation check
+var _ usecase.CreateTableStatementGetter = (*createTableStatementGetter)(nil)
+
+type createTableStatementGetter struct {
+	repository.CreateTableStatementGetter
+}
+
+// NewCreateTableStatementGetter create new CreateTableStatementGetter.
+func NewCreateTableStatementGetter(
+	g repository.CreateTableStatementGetter,
+) usecase.CreateTableStatementGetter {
+	return &createTableStatementGetter{
+		CreateTableStatementGetter: g,
+	}
+}
+
+// GetCreateTableStatement gets the CREATE statement of a table.
+func (r *createTableStatementGetter) GetCreateTableStatement(ctx context.Context, tableName string) (string, error) {
+	return r.CreateTableStatementGetter.GetCreateTableStatement(ctx, tableName)
+}
@@ -267,3 +267,66 @@ func (c *MockTableDDLGetterGetTableDDLCall) DoAndReturn(f func(context.Context,
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
+
+// MockCreateTableStatementGetter is a mock of CreateTableStatementGetter interface.
+type MockCreateTableStatementGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockCreateTableStatementGetterMockRecorder
+	isgomock struct{}
+}
+
+// MockCreateTableStatementGetterMockRecorder is the mock recorder for MockCreateTableStatementGetter.
+type MockCreateTableStatementGetterMockRecorder struct {
+	mock *MockCreateTableStatementGetter
+}
+
+// NewMockCreateTableStatementGetter creates a new mock instance.
+func NewMockCreateTableStatementGetter(ctrl *gomock.Controller) *MockCreateTableStatementGetter {
+	mock := &MockCreateTableStatementGetter{ctrl: ctrl}
+	mock.recorder = &MockCreateTableStatementGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCreateTableStatementGetter) EXPECT() *MockCreateTableStatementGetterMockRecorder {
+	return m.recorder
+}
+
+// GetCreateTableStatement mocks base method.
+func (m *MockCreateTableStatementGetter) GetCreateTableStatement(ctx context.Context, tableName string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCreateTableStatement", ctx, tableName)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCreateTableStatement indicates an expected call of GetCreateTableStatement.
+func (mr *MockCreateTableStatementGetterMockRecorder) GetCreateTableStatement(ctx, tableName any) *MockCreateTableStatementGetterGetCreateTableStatementCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCreateTableStatement", reflect.TypeOf((*MockCreateTableStatementGetter)(nil).GetCreateTableStatement), ctx, tableName)
+	return &MockCreateTableStatementGetterGetCreateTableStatementCall{Call: call}
+}
+
+// MockCreateTableStatementGetterGetCreateTableStatementCall wrap *gomock.Call
+type MockCreateTableStatementGetterGetCreateTableStatementCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockCreateTableStatementGetterGetCreateTableStatementCall) Return(arg0 string, arg1 error) *MockCreateTableStatementGetterGetCreateTableStatementCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockCreateTableStatementGetterGetCreateTableStatementCall) Do(f func(context.Context, string) (string, error)) *MockCreateTableStatementGetterGetCreateTableStatementCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockCreateTableStatementGetterGetCreateTableStatementCall) DoAndReturn(f func(context.Context, string) (string, error)) *MockCreateTableStatementGetterGetCreateTableStatementCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -206,3 +206,876 @@ func (c *MockTableDDLInRemoteGetterGetTableDDLCall) DoAndReturn(f func(context.C
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
+
+// MockCreateTableStatementInRemoteGetter is a mock of CreateTableStatementInRemoteGetter interface.
+type MockCreateTableStatementInRemoteGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockCreateTableStatementInRemoteGetterMockRecorder
+	isgomock struct{}
+}
+
+// MockCreateTableStatementInRemoteGetterMockRecorder is the mock recorder for MockCreateTableStatementInRemoteGetter.
+type MockCreateTableStatementInRemoteGetterMockRecorder struct {
+	mock *MockCreateTableStatementInRemoteGetter
+}
+
+// NewMockCreateTableStatementInRemoteGetter creates a new mock instance.
+func NewMockCreateTableStatementInRemoteGetter(ctrl *gomock.Controller) *MockCreateTableStatementInRemoteGetter {
+	mock := &MockCreateTableStatementInRemoteGetter{ctrl: ctrl}
+	mock.recorder = &MockCreateTableStatementInRemoteGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCreateTableStatementInRemoteGetter) EXPECT() *MockCreateTableStatementInRemoteGetterMockRecorder {
+	return m.recorder
+}
+
+// GetCreateTableStatement mocks base method.
+func (m *MockCreateTableStatementInRemoteGetter) GetCreateTableStatement(ctx context.Context, tableName string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCreateTableStatement", ctx, tableName)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCreateTableStatement indicates an expected call of GetCreateTableStatement.
+func (mr *MockCreateTableStatementInRemoteGetterMockRecorder) GetCreateTableStatement(ctx, tableName any) *MockCreateTableStatementInRemoteGetterGetCreateTableStatementCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCreateTableStatement", reflect.TypeOf((*MockCreateTableStatementInRemoteGetter)(nil).GetCreateTableStatement), ctx, tableName)
+	return &MockCreateTableStatementInRemoteGetterGetCreateTableStatementCall{Call: call}
+}
+
+// MockCreateTableStatementInRemoteGetterGetCreateTableStatementCall wrap *gomock.Call
+type MockCreateTableStatementInRemoteGetterGetCreateTableStatementCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockCreateTableStatementInRemoteGetterGetCreateTableStatementCall) Return(arg0 string, arg1 error) *MockCreateTableStatementInRemoteGetterGetCreateTableStatementCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockCreateTableStatementInRemoteGetterGetCreateTableStatementCall) Do(f func(context.Context, string) (string, error)) *MockCreateTableStatementInRemoteGetterGetCreateTableStatementCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockCreateTableStatementInRemoteGetterGetCreateTableStatementCall) DoAndReturn(f func(context.Context, string) (string, error)) *MockCreateTableStatementInRemoteGetterGetCreateTableStatementCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockObjectsGetter is a mock of ObjectsGetter interface.
+type MockObjectsGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockObjectsGetterMockRecorder
+	isgomock struct{}
+}
+
+// MockObjectsGetterMockRecorder is the mock recorder for MockObjectsGetter.
+type MockObjectsGetterMockRecorder struct {
+	mock *MockObjectsGetter
+}
+
+// NewMockObjectsGetter creates a new mock instance.
+func NewMockObjectsGetter(ctrl *gomock.Controller) *MockObjectsGetter {
+	mock := &MockObjectsGetter{ctrl: ctrl}
+	mock.recorder = &MockObjectsGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockObjectsGetter) EXPECT() *MockObjectsGetterMockRecorder {
+	return m.recorder
+}
+
+// GetObjects mocks base method.
+func (m *MockObjectsGetter) GetObjects(ctx context.Context, objectType model.ObjectType) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetObjects", ctx, objectType)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetObjects indicates an expected call of GetObjects.
+func (mr *MockObjectsGetterMockRecorder) GetObjects(ctx, objectType any) *MockObjectsGetterGetObjectsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObjects", reflect.TypeOf((*MockObjectsGetter)(nil).GetObjects), ctx, objectType)
+	return &MockObjectsGetterGetObjectsCall{Call: call}
+}
+
+// MockObjectsGetterGetObjectsCall wrap *gomock.Call
+type MockObjectsGetterGetObjectsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockObjectsGetterGetObjectsCall) Return(arg0 []string, arg1 error) *MockObjectsGetterGetObjectsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockObjectsGetterGetObjectsCall) Do(f func(context.Context, model.ObjectType) ([]string, error)) *MockObjectsGetterGetObjectsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockObjectsGetterGetObjectsCall) DoAndReturn(f func(context.Context, model.ObjectType) ([]string, error)) *MockObjectsGetterGetObjectsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockIndexesGetter is a mock of IndexesGetter interface.
+type MockIndexesGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockIndexesGetterMockRecorder
+	isgomock struct{}
+}
+
+// MockIndexesGetterMockRecorder is the mock recorder for MockIndexesGetter.
+type MockIndexesGetterMockRecorder struct {
+	mock *MockIndexesGetter
+}
+
+// NewMockIndexesGetter creates a new mock instance.
+func NewMockIndexesGetter(ctrl *gomock.Controller) *MockIndexesGetter {
+	mock := &MockIndexesGetter{ctrl: ctrl}
+	mock.recorder = &MockIndexesGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIndexesGetter) EXPECT() *MockIndexesGetterMockRecorder {
+	return m.recorder
+}
+
+// GetIndexes mocks base method.
+func (m *MockIndexesGetter) GetIndexes(ctx context.Context, tableName string) ([]*model.Index, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIndexes", ctx, tableName)
+	ret0, _ := ret[0].([]*model.Index)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIndexes indicates an expected call of GetIndexes.
+func (mr *MockIndexesGetterMockRecorder) GetIndexes(ctx, tableName any) *MockIndexesGetterGetIndexesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIndexes", reflect.TypeOf((*MockIndexesGetter)(nil).GetIndexes), ctx, tableName)
+	return &MockIndexesGetterGetIndexesCall{Call: call}
+}
+
+// MockIndexesGetterGetIndexesCall wrap *gomock.Call
+type MockIndexesGetterGetIndexesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockIndexesGetterGetIndexesCall) Return(arg0 []*model.Index, arg1 error) *MockIndexesGetterGetIndexesCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockIndexesGetterGetIndexesCall) Do(f func(context.Context, string) ([]*model.Index, error)) *MockIndexesGetterGetIndexesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockIndexesGetterGetIndexesCall) DoAndReturn(f func(context.Context, string) ([]*model.Index, error)) *MockIndexesGetterGetIndexesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockForeignKeysGetter is a mock of ForeignKeysGetter interface.
+type MockForeignKeysGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockForeignKeysGetterMockRecorder
+	isgomock struct{}
+}
+
+// MockForeignKeysGetterMockRecorder is the mock recorder for MockForeignKeysGetter.
+type MockForeignKeysGetterMockRecorder struct {
+	mock *MockForeignKeysGetter
+}
+
+// NewMockForeignKeysGetter creates a new mock instance.
+func NewMockForeignKeysGetter(ctrl *gomock.Controller) *MockForeignKeysGetter {
+	mock := &MockForeignKeysGetter{ctrl: ctrl}
+	mock.recorder = &MockForeignKeysGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockForeignKeysGetter) EXPECT() *MockForeignKeysGetterMockRecorder {
+	return m.recorder
+}
+
+// GetForeignKeys mocks base method.
+func (m *MockForeignKeysGetter) GetForeignKeys(ctx context.Context, tableName string) ([]*model.ForeignKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetForeignKeys", ctx, tableName)
+	ret0, _ := ret[0].([]*model.ForeignKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetForeignKeys indicates an expected call of GetForeignKeys.
+func (mr *MockForeignKeysGetterMockRecorder) GetForeignKeys(ctx, tableName any) *MockForeignKeysGetterGetForeignKeysCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetForeignKeys", reflect.TypeOf((*MockForeignKeysGetter)(nil).GetForeignKeys), ctx, tableName)
+	return &MockForeignKeysGetterGetForeignKeysCall{Call: call}
+}
+
+// MockForeignKeysGetterGetForeignKeysCall wrap *gomock.Call
+type MockForeignKeysGetterGetForeignKeysCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockForeignKeysGetterGetForeignKeysCall) Return(arg0 []*model.ForeignKey, arg1 error) *MockForeignKeysGetterGetForeignKeysCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockForeignKeysGetterGetForeignKeysCall) Do(f func(context.Context, string) ([]*model.ForeignKey, error)) *MockForeignKeysGetterGetForeignKeysCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockForeignKeysGetterGetForeignKeysCall) DoAndReturn(f func(context.Context, string) ([]*model.ForeignKey, error)) *MockForeignKeysGetterGetForeignKeysCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockTriggersGetter is a mock of TriggersGetter interface.
+type MockTriggersGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockTriggersGetterMockRecorder
+	isgomock struct{}
+}
+
+// MockTriggersGetterMockRecorder is the mock recorder for MockTriggersGetter.
+type MockTriggersGetterMockRecorder struct {
+	mock *MockTriggersGetter
+}
+
+// NewMockTriggersGetter creates a new mock instance.
+func NewMockTriggersGetter(ctrl *gomock.Controller) *MockTriggersGetter {
+	mock := &MockTriggersGetter{ctrl: ctrl}
+	mock.recorder = &MockTriggersGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTriggersGetter) EXPECT() *MockTriggersGetterMockRecorder {
+	return m.recorder
+}
+
+// GetTriggers mocks base method.
+func (m *MockTriggersGetter) GetTriggers(ctx context.Context, tableName string) ([]*model.Trigger, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTriggers", ctx, tableName)
+	ret0, _ := ret[0].([]*model.Trigger)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTriggers indicates an expected call of GetTriggers.
+func (mr *MockTriggersGetterMockRecorder) GetTriggers(ctx, tableName any) *MockTriggersGetterGetTriggersCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTriggers", reflect.TypeOf((*MockTriggersGetter)(nil).GetTriggers), ctx, tableName)
+	return &MockTriggersGetterGetTriggersCall{Call: call}
+}
+
+// MockTriggersGetterGetTriggersCall wrap *gomock.Call
+type MockTriggersGetterGetTriggersCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTriggersGetterGetTriggersCall) Return(arg0 []*model.Trigger, arg1 error) *MockTriggersGetterGetTriggersCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTriggersGetterGetTriggersCall) Do(f func(context.Context, string) ([]*model.Trigger, error)) *MockTriggersGetterGetTriggersCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTriggersGetterGetTriggersCall) DoAndReturn(f func(context.Context, string) ([]*model.Trigger, error)) *MockTriggersGetterGetTriggersCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockRemoteTableImporter is a mock of RemoteTableImporter interface.
+type MockRemoteTableImporter struct {
+	ctrl     *gomock.Controller
+	recorder *MockRemoteTableImporterMockRecorder
+	isgomock struct{}
+}
+
+// MockRemoteTableImporterMockRecorder is the mock recorder for MockRemoteTableImporter.
+type MockRemoteTableImporterMockRecorder struct {
+	mock *MockRemoteTableImporter
+}
+
+// NewMockRemoteTableImporter creates a new mock instance.
+func NewMockRemoteTableImporter(ctrl *gomock.Controller) *MockRemoteTableImporter {
+	mock := &MockRemoteTableImporter{ctrl: ctrl}
+	mock.recorder = &MockRemoteTableImporterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRemoteTableImporter) EXPECT() *MockRemoteTableImporterMockRecorder {
+	return m.recorder
+}
+
+// Import mocks base method.
+func (m *MockRemoteTableImporter) Import(ctx context.Context, plan *model.ImportPlan) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", ctx, plan)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockRemoteTableImporterMockRecorder) Import(ctx, plan any) *MockRemoteTableImporterImportCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockRemoteTableImporter)(nil).Import), ctx, plan)
+	return &MockRemoteTableImporterImportCall{Call: call}
+}
+
+// MockRemoteTableImporterImportCall wrap *gomock.Call
+type MockRemoteTableImporterImportCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRemoteTableImporterImportCall) Return(arg0 int64, arg1 error) *MockRemoteTableImporterImportCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRemoteTableImporterImportCall) Do(f func(context.Context, *model.ImportPlan) (int64, error)) *MockRemoteTableImporterImportCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRemoteTableImporterImportCall) DoAndReturn(f func(context.Context, *model.ImportPlan) (int64, error)) *MockRemoteTableImporterImportCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// PlanImport mocks base method.
+func (m *MockRemoteTableImporter) PlanImport(ctx context.Context, table *model.Table, tableName string, types []model.ColumnType) (*model.ImportPlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PlanImport", ctx, table, tableName, types)
+	ret0, _ := ret[0].(*model.ImportPlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PlanImport indicates an expected call of PlanImport.
+func (mr *MockRemoteTableImporterMockRecorder) PlanImport(ctx, table, tableName, types any) *MockRemoteTableImporterPlanImportCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlanImport", reflect.TypeOf((*MockRemoteTableImporter)(nil).PlanImport), ctx, table, tableName, types)
+	return &MockRemoteTableImporterPlanImportCall{Call: call}
+}
+
+// MockRemoteTableImporterPlanImportCall wrap *gomock.Call
+type MockRemoteTableImporterPlanImportCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRemoteTableImporterPlanImportCall) Return(arg0 *model.ImportPlan, arg1 error) *MockRemoteTableImporterPlanImportCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRemoteTableImporterPlanImportCall) Do(f func(context.Context, *model.Table, string, []model.ColumnType) (*model.ImportPlan, error)) *MockRemoteTableImporterPlanImportCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRemoteTableImporterPlanImportCall) DoAndReturn(f func(context.Context, *model.Table, string, []model.ColumnType) (*model.ImportPlan, error)) *MockRemoteTableImporterPlanImportCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockRemoteQueryStreamer is a mock of RemoteQueryStreamer interface.
+type MockRemoteQueryStreamer struct {
+	ctrl     *gomock.Controller
+	recorder *MockRemoteQueryStreamerMockRecorder
+	isgomock struct{}
+}
+
+// MockRemoteQueryStreamerMockRecorder is the mock recorder for MockRemoteQueryStreamer.
+type MockRemoteQueryStreamerMockRecorder struct {
+	mock *MockRemoteQueryStreamer
+}
+
+// NewMockRemoteQueryStreamer creates a new mock instance.
+func NewMockRemoteQueryStreamer(ctrl *gomock.Controller) *MockRemoteQueryStreamer {
+	mock := &MockRemoteQueryStreamer{ctrl: ctrl}
+	mock.recorder = &MockRemoteQueryStreamerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRemoteQueryStreamer) EXPECT() *MockRemoteQueryStreamerMockRecorder {
+	return m.recorder
+}
+
+// StreamQuery mocks base method.
+func (m *MockRemoteQueryStreamer) StreamQuery(ctx context.Context, sql *model.SQL, batchSize int, fn func(*model.Table, []model.ColumnType) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamQuery", ctx, sql, batchSize, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamQuery indicates an expected call of StreamQuery.
+func (mr *MockRemoteQueryStreamerMockRecorder) StreamQuery(ctx, sql, batchSize, fn any) *MockRemoteQueryStreamerStreamQueryCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamQuery", reflect.TypeOf((*MockRemoteQueryStreamer)(nil).StreamQuery), ctx, sql, batchSize, fn)
+	return &MockRemoteQueryStreamerStreamQueryCall{Call: call}
+}
+
+// MockRemoteQueryStreamerStreamQueryCall wrap *gomock.Call
+type MockRemoteQueryStreamerStreamQueryCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRemoteQueryStreamerStreamQueryCall) Return(arg0 error) *MockRemoteQueryStreamerStreamQueryCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRemoteQueryStreamerStreamQueryCall) Do(f func(context.Context, *model.SQL, int, func(*model.Table, []model.ColumnType) error) error) *MockRemoteQueryStreamerStreamQueryCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRemoteQueryStreamerStreamQueryCall) DoAndReturn(f func(context.Context, *model.SQL, int, func(*model.Table, []model.ColumnType) error) error) *MockRemoteQueryStreamerStreamQueryCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockSchemaComparer is a mock of SchemaComparer interface.
+type MockSchemaComparer struct {
+	ctrl     *gomock.Controller
+	recorder *MockSchemaComparerMockRecorder
+	isgomock struct{}
+}
+
+// MockSchemaComparerMockRecorder is the mock recorder for MockSchemaComparer.
+type MockSchemaComparerMockRecorder struct {
+	mock *MockSchemaComparer
+}
+
+// NewMockSchemaComparer creates a new mock instance.
+func NewMockSchemaComparer(ctrl *gomock.Controller) *MockSchemaComparer {
+	mock := &MockSchemaComparer{ctrl: ctrl}
+	mock.recorder = &MockSchemaComparerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSchemaComparer) EXPECT() *MockSchemaComparerMockRecorder {
+	return m.recorder
+}
+
+// CompareSchemas mocks base method.
+func (m *MockSchemaComparer) CompareSchemas(ctx context.Context) (*model.SchemaDiff, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompareSchemas", ctx)
+	ret0, _ := ret[0].(*model.SchemaDiff)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CompareSchemas indicates an expected call of CompareSchemas.
+func (mr *MockSchemaComparerMockRecorder) CompareSchemas(ctx any) *MockSchemaComparerCompareSchemasCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompareSchemas", reflect.TypeOf((*MockSchemaComparer)(nil).CompareSchemas), ctx)
+	return &MockSchemaComparerCompareSchemasCall{Call: call}
+}
+
+// MockSchemaComparerCompareSchemasCall wrap *gomock.Call
+type MockSchemaComparerCompareSchemasCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSchemaComparerCompareSchemasCall) Return(arg0 *model.SchemaDiff, arg1 string, arg2 error) *MockSchemaComparerCompareSchemasCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSchemaComparerCompareSchemasCall) Do(f func(context.Context) (*model.SchemaDiff, string, error)) *MockSchemaComparerCompareSchemasCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSchemaComparerCompareSchemasCall) DoAndReturn(f func(context.Context) (*model.SchemaDiff, string, error)) *MockSchemaComparerCompareSchemasCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockQueryPlanGetter is a mock of QueryPlanGetter interface.
+type MockQueryPlanGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockQueryPlanGetterMockRecorder
+	isgomock struct{}
+}
+
+// MockQueryPlanGetterMockRecorder is the mock recorder for MockQueryPlanGetter.
+type MockQueryPlanGetterMockRecorder struct {
+	mock *MockQueryPlanGetter
+}
+
+// NewMockQueryPlanGetter creates a new mock instance.
+func NewMockQueryPlanGetter(ctrl *gomock.Controller) *MockQueryPlanGetter {
+	mock := &MockQueryPlanGetter{ctrl: ctrl}
+	mock.recorder = &MockQueryPlanGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockQueryPlanGetter) EXPECT() *MockQueryPlanGetterMockRecorder {
+	return m.recorder
+}
+
+// GetQueryPlan mocks base method.
+func (m *MockQueryPlanGetter) GetQueryPlan(ctx context.Context, sql *model.SQL, analyze bool) (*model.QueryPlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQueryPlan", ctx, sql, analyze)
+	ret0, _ := ret[0].(*model.QueryPlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQueryPlan indicates an expected call of GetQueryPlan.
+func (mr *MockQueryPlanGetterMockRecorder) GetQueryPlan(ctx, sql, analyze any) *MockQueryPlanGetterGetQueryPlanCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQueryPlan", reflect.TypeOf((*MockQueryPlanGetter)(nil).GetQueryPlan), ctx, sql, analyze)
+	return &MockQueryPlanGetterGetQueryPlanCall{Call: call}
+}
+
+// MockQueryPlanGetterGetQueryPlanCall wrap *gomock.Call
+type MockQueryPlanGetterGetQueryPlanCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockQueryPlanGetterGetQueryPlanCall) Return(arg0 *model.QueryPlan, arg1 error) *MockQueryPlanGetterGetQueryPlanCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockQueryPlanGetterGetQueryPlanCall) Do(f func(context.Context, *model.SQL, bool) (*model.QueryPlan, error)) *MockQueryPlanGetterGetQueryPlanCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockQueryPlanGetterGetQueryPlanCall) DoAndReturn(f func(context.Context, *model.SQL, bool) (*model.QueryPlan, error)) *MockQueryPlanGetterGetQueryPlanCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockRemoteTableEditor is a mock of RemoteTableEditor interface.
+type MockRemoteTableEditor struct {
+	ctrl     *gomock.Controller
+	recorder *MockRemoteTableEditorMockRecorder
+	isgomock struct{}
+}
+
+// MockRemoteTableEditorMockRecorder is the mock recorder for MockRemoteTableEditor.
+type MockRemoteTableEditorMockRecorder struct {
+	mock *MockRemoteTableEditor
+}
+
+// NewMockRemoteTableEditor creates a new mock instance.
+func NewMockRemoteTableEditor(ctrl *gomock.Controller) *MockRemoteTableEditor {
+	mock := &MockRemoteTableEditor{ctrl: ctrl}
+	mock.recorder = &MockRemoteTableEditorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRemoteTableEditor) EXPECT() *MockRemoteTableEditorMockRecorder {
+	return m.recorder
+}
+
+// ApplyEditStatements mocks base method.
+func (m *MockRemoteTableEditor) ApplyEditStatements(ctx context.Context, stmts []*model.EditStatement) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyEditStatements", ctx, stmts)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyEditStatements indicates an expected call of ApplyEditStatements.
+func (mr *MockRemoteTableEditorMockRecorder) ApplyEditStatements(ctx, stmts any) *MockRemoteTableEditorApplyEditStatementsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyEditStatements", reflect.TypeOf((*MockRemoteTableEditor)(nil).ApplyEditStatements), ctx, stmts)
+	return &MockRemoteTableEditorApplyEditStatementsCall{Call: call}
+}
+
+// MockRemoteTableEditorApplyEditStatementsCall wrap *gomock.Call
+type MockRemoteTableEditorApplyEditStatementsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRemoteTableEditorApplyEditStatementsCall) Return(arg0 int64, arg1 error) *MockRemoteTableEditorApplyEditStatementsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRemoteTableEditorApplyEditStatementsCall) Do(f func(context.Context, []*model.EditStatement) (int64, error)) *MockRemoteTableEditorApplyEditStatementsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRemoteTableEditorApplyEditStatementsCall) DoAndReturn(f func(context.Context, []*model.EditStatement) (int64, error)) *MockRemoteTableEditorApplyEditStatementsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// EditStatements mocks base method.
+func (m *MockRemoteTableEditor) EditStatements(edit *model.TableEdit) ([]*model.EditStatement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EditStatements", edit)
+	ret0, _ := ret[0].([]*model.EditStatement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EditStatements indicates an expected call of EditStatements.
+func (mr *MockRemoteTableEditorMockRecorder) EditStatements(edit any) *MockRemoteTableEditorEditStatementsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditStatements", reflect.TypeOf((*MockRemoteTableEditor)(nil).EditStatements), edit)
+	return &MockRemoteTableEditorEditStatementsCall{Call: call}
+}
+
+// MockRemoteTableEditorEditStatementsCall wrap *gomock.Call
+type MockRemoteTableEditorEditStatementsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRemoteTableEditorEditStatementsCall) Return(arg0 []*model.EditStatement, arg1 error) *MockRemoteTableEditorEditStatementsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRemoteTableEditorEditStatementsCall) Do(f func(*model.TableEdit) ([]*model.EditStatement, error)) *MockRemoteTableEditorEditStatementsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRemoteTableEditorEditStatementsCall) DoAndReturn(f func(*model.TableEdit) ([]*model.EditStatement, error)) *MockRemoteTableEditorEditStatementsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockERDiagramGenerator is a mock of ERDiagramGenerator interface.
+type MockERDiagramGenerator struct {
+	ctrl     *gomock.Controller
+	recorder *MockERDiagramGeneratorMockRecorder
+	isgomock struct{}
+}
+
+// MockERDiagramGeneratorMockRecorder is the mock recorder for MockERDiagramGenerator.
+type MockERDiagramGeneratorMockRecorder struct {
+	mock *MockERDiagramGenerator
+}
+
+// NewMockERDiagramGenerator creates a new mock instance.
+func NewMockERDiagramGenerator(ctrl *gomock.Controller) *MockERDiagramGenerator {
+	mock := &MockERDiagramGenerator{ctrl: ctrl}
+	mock.recorder = &MockERDiagramGeneratorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockERDiagramGenerator) EXPECT() *MockERDiagramGeneratorMockRecorder {
+	return m.recorder
+}
+
+// GenerateERDiagram mocks base method.
+func (m *MockERDiagramGenerator) GenerateERDiagram(ctx context.Context, opts model.ERDiagramOptions) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateERDiagram", ctx, opts)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateERDiagram indicates an expected call of GenerateERDiagram.
+func (mr *MockERDiagramGeneratorMockRecorder) GenerateERDiagram(ctx, opts any) *MockERDiagramGeneratorGenerateERDiagramCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateERDiagram", reflect.TypeOf((*MockERDiagramGenerator)(nil).GenerateERDiagram), ctx, opts)
+	return &MockERDiagramGeneratorGenerateERDiagramCall{Call: call}
+}
+
+// MockERDiagramGeneratorGenerateERDiagramCall wrap *gomock.Call
+type MockERDiagramGeneratorGenerateERDiagramCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockERDiagramGeneratorGenerateERDiagramCall) Return(arg0 string, arg1 error) *MockERDiagramGeneratorGenerateERDiagramCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockERDiagramGeneratorGenerateERDiagramCall) Do(f func(context.Context, model.ERDiagramOptions) (string, error)) *MockERDiagramGeneratorGenerateERDiagramCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockERDiagramGeneratorGenerateERDiagramCall) DoAndReturn(f func(context.Context, model.ERDiagramOptions) (string, error)) *MockERDiagramGeneratorGenerateERDiagramCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockSchemasGetter is a mock of SchemasGetter interface.
+type MockSchemasGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockSchemasGetterMockRecorder
+	isgomock struct{}
+}
+
+// MockSchemasGetterMockRecorder is the mock recorder for MockSchemasGetter.
+type MockSchemasGetterMockRecorder struct {
+	mock *MockSchemasGetter
+}
+
+// NewMockSchemasGetter creates a new mock instance.
+func NewMockSchemasGetter(ctrl *gomock.Controller) *MockSchemasGetter {
+	mock := &MockSchemasGetter{ctrl: ctrl}
+	mock.recorder = &MockSchemasGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSchemasGetter) EXPECT() *MockSchemasGetterMockRecorder {
+	return m.recorder
+}
+
+// GetCurrentSchema mocks base method.
+func (m *MockSchemasGetter) GetCurrentSchema(ctx context.Context) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrentSchema", ctx)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrentSchema indicates an expected call of GetCurrentSchema.
+func (mr *MockSchemasGetterMockRecorder) GetCurrentSchema(ctx any) *MockSchemasGetterGetCurrentSchemaCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentSchema", reflect.TypeOf((*MockSchemasGetter)(nil).GetCurrentSchema), ctx)
+	return &MockSchemasGetterGetCurrentSchemaCall{Call: call}
+}
+
+// MockSchemasGetterGetCurrentSchemaCall wrap *gomock.Call
+type MockSchemasGetterGetCurrentSchemaCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSchemasGetterGetCurrentSchemaCall) Return(arg0 string, arg1 error) *MockSchemasGetterGetCurrentSchemaCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSchemasGetterGetCurrentSchemaCall) Do(f func(context.Context) (string, error)) *MockSchemasGetterGetCurrentSchemaCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSchemasGetterGetCurrentSchemaCall) DoAndReturn(f func(context.Context) (string, error)) *MockSchemasGetterGetCurrentSchemaCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetSchemas mocks base method.
+func (m *MockSchemasGetter) GetSchemas(ctx context.Context) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSchemas", ctx)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSchemas indicates an expected call of GetSchemas.
+func (mr *MockSchemasGetterMockRecorder) GetSchemas(ctx any) *MockSchemasGetterGetSchemasCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchemas", reflect.TypeOf((*MockSchemasGetter)(nil).GetSchemas), ctx)
+	return &MockSchemasGetterGetSchemasCall{Call: call}
+}
+
+// MockSchemasGetterGetSchemasCall wrap *gomock.Call
+type MockSchemasGetterGetSchemasCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSchemasGetterGetSchemasCall) Return(arg0 []string, arg1 error) *MockSchemasGetterGetSchemasCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSchemasGetterGetSchemasCall) Do(f func(context.Context) ([]string, error)) *MockSchemasGetterGetSchemasCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSchemasGetterGetSchemasCall) DoAndReturn(f func(context.Context) ([]string, error)) *MockSchemasGetterGetSchemasCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
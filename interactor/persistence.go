@@ -84,3 +84,283 @@ func NewTableDDLInRemoteGetter(
 func (m *tableDDLInRemoteGetter) GetTableDDL(ctx context.Context, tableName string) ([]*model.Table, error) {
 	return m.TableDDLInRemoteGetter.GetTableDDL(ctx, tableName)
 }
+
+// _ interface implementation check
+var _ usecase.CreateTableStatementInRemoteGetter = (*createTableStatementInRemoteGetter)(nil)
+
+type createTableStatementInRemoteGetter struct {
+	repository.CreateTableStatementInRemoteGetter
+}
+
+// NewCreateTableStatementInRemoteGetter creates a new CreateTableStatementInRemoteGetter.
+func NewCreateTableStatementInRemoteGetter(
+	g repository.CreateTableStatementInRemoteGetter,
+) usecase.CreateTableStatementInRemoteGetter {
+	return &createTableStatementInRemoteGetter{
+		CreateTableStatementInRemoteGetter: g,
+	}
+}
+
+// GetCreateTableStatement gets the CREATE statement of a table in database.
+func (c *createTableStatementInRemoteGetter) GetCreateTableStatement(ctx context.Context, tableName string) (string, error) {
+	return c.CreateTableStatementInRemoteGetter.GetCreateTableStatement(ctx, tableName)
+}
+
+// _ interface implementation check
+var _ usecase.ObjectsGetter = (*objectsGetter)(nil)
+
+type objectsGetter struct {
+	repository.ObjectsInRemoteGetter
+}
+
+// NewObjectsGetter creates a new ObjectsGetter.
+func NewObjectsGetter(
+	og repository.ObjectsInRemoteGetter,
+) usecase.ObjectsGetter {
+	return &objectsGetter{
+		ObjectsInRemoteGetter: og,
+	}
+}
+
+// GetObjects gets the names of database objects of the given type.
+func (o *objectsGetter) GetObjects(ctx context.Context, objectType model.ObjectType) ([]string, error) {
+	return o.ObjectsInRemoteGetter.GetObjects(ctx, objectType)
+}
+
+// _ interface implementation check
+var _ usecase.IndexesGetter = (*indexesGetter)(nil)
+
+type indexesGetter struct {
+	repository.IndexesInRemoteGetter
+}
+
+// NewIndexesGetter creates a new IndexesGetter.
+func NewIndexesGetter(
+	ig repository.IndexesInRemoteGetter,
+) usecase.IndexesGetter {
+	return &indexesGetter{
+		IndexesInRemoteGetter: ig,
+	}
+}
+
+// GetIndexes gets the indexes of a table.
+func (i *indexesGetter) GetIndexes(ctx context.Context, tableName string) ([]*model.Index, error) {
+	return i.IndexesInRemoteGetter.GetIndexes(ctx, tableName)
+}
+
+// _ interface implementation check
+var _ usecase.ForeignKeysGetter = (*foreignKeysGetter)(nil)
+
+type foreignKeysGetter struct {
+	repository.ForeignKeysInRemoteGetter
+}
+
+// NewForeignKeysGetter creates a new ForeignKeysGetter.
+func NewForeignKeysGetter(
+	fg repository.ForeignKeysInRemoteGetter,
+) usecase.ForeignKeysGetter {
+	return &foreignKeysGetter{
+		ForeignKeysInRemoteGetter: fg,
+	}
+}
+
+// GetForeignKeys gets the foreign keys of a table.
+func (f *foreignKeysGetter) GetForeignKeys(ctx context.Context, tableName string) ([]*model.ForeignKey, error) {
+	return f.ForeignKeysInRemoteGetter.GetForeignKeys(ctx, tableName)
+}
+
+// _ interface implementation check
+var _ usecase.TriggersGetter = (*triggersGetter)(nil)
+
+type triggersGetter struct {
+	repository.TriggersInRemoteGetter
+}
+
+// NewTriggersGetter creates a new TriggersGetter.
+func NewTriggersGetter(
+	tg repository.TriggersInRemoteGetter,
+) usecase.TriggersGetter {
+	return &triggersGetter{
+		TriggersInRemoteGetter: tg,
+	}
+}
+
+// GetTriggers gets the triggers of a table.
+func (t *triggersGetter) GetTriggers(ctx context.Context, tableName string) ([]*model.Trigger, error) {
+	return t.TriggersInRemoteGetter.GetTriggers(ctx, tableName)
+}
+
+// _ interface implementation check
+var _ usecase.SchemasGetter = (*schemasGetter)(nil)
+
+type schemasGetter struct {
+	repository.SchemasInRemoteGetter
+}
+
+// NewSchemasGetter creates a new SchemasGetter.
+func NewSchemasGetter(
+	sg repository.SchemasInRemoteGetter,
+) usecase.SchemasGetter {
+	return &schemasGetter{
+		SchemasInRemoteGetter: sg,
+	}
+}
+
+// GetSchemas gets the schemas on the server.
+func (s *schemasGetter) GetSchemas(ctx context.Context) ([]string, error) {
+	return s.SchemasInRemoteGetter.GetSchemas(ctx)
+}
+
+// GetCurrentSchema gets the schema that the connection uses.
+func (s *schemasGetter) GetCurrentSchema(ctx context.Context) (string, error) {
+	return s.SchemasInRemoteGetter.GetCurrentSchema(ctx)
+}
+
+// _ interface implementation check
+var _ usecase.RemoteTableImporter = (*remoteTableImporter)(nil)
+
+type remoteTableImporter struct {
+	repository.RemoteTableImporter
+}
+
+// NewRemoteTableImporter creates a new RemoteTableImporter.
+func NewRemoteTableImporter(
+	ri repository.RemoteTableImporter,
+) usecase.RemoteTableImporter {
+	return &remoteTableImporter{
+		RemoteTableImporter: ri,
+	}
+}
+
+// PlanImport returns how the records are imported into the table without changing the database.
+func (r *remoteTableImporter) PlanImport(ctx context.Context, table *model.Table, tableName string, types []model.ColumnType) (*model.ImportPlan, error) {
+	return r.RemoteTableImporter.PlanImport(ctx, table, tableName, types)
+}
+
+// Import imports the records into the table according to the plan.
+func (r *remoteTableImporter) Import(ctx context.Context, plan *model.ImportPlan) (int64, error) {
+	return r.RemoteTableImporter.Import(ctx, plan)
+}
+
+// _ interface implementation check
+var _ usecase.RemoteQueryStreamer = (*remoteQueryStreamer)(nil)
+
+type remoteQueryStreamer struct {
+	repository.RemoteQueryStreamer
+}
+
+// NewRemoteQueryStreamer creates a new RemoteQueryStreamer.
+func NewRemoteQueryStreamer(
+	rs repository.RemoteQueryStreamer,
+) usecase.RemoteQueryStreamer {
+	return &remoteQueryStreamer{
+		RemoteQueryStreamer: rs,
+	}
+}
+
+// StreamQuery executes the query and passes the result to fn in batches.
+func (r *remoteQueryStreamer) StreamQuery(ctx context.Context, sql *model.SQL, batchSize int, fn func(batch *model.Table, types []model.ColumnType) error) error {
+	return r.RemoteQueryStreamer.StreamQuery(ctx, sql, batchSize, fn)
+}
+
+// _ interface implementation check
+var _ usecase.SchemaComparer = (*schemaComparer)(nil)
+
+type schemaComparer struct {
+	from      repository.TableSchemasInRemoteGetter
+	to        repository.TableSchemasInRemoteGetter
+	generator repository.AlterStatementsGenerator
+}
+
+// NewSchemaComparer creates a new SchemaComparer. The generator must be for the DBMS of the "from" database.
+func NewSchemaComparer(
+	from repository.TableSchemasInRemoteGetter,
+	to repository.TableSchemasInRemoteGetter,
+	generator repository.AlterStatementsGenerator,
+) usecase.SchemaComparer {
+	return &schemaComparer{
+		from:      from,
+		to:        to,
+		generator: generator,
+	}
+}
+
+// CompareSchemas compares the schemas and returns the diff and the statements that apply it to the "from" database.
+func (s *schemaComparer) CompareSchemas(ctx context.Context) (*model.SchemaDiff, string, error) {
+	from, err := s.from.GetTableSchemas(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	to, err := s.to.GetTableSchemas(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	diff := model.DiffSchemas(from, to)
+	return diff, s.generator.GenerateAlterStatements(diff), nil
+}
+
+// _ interface implementation check
+var _ usecase.ERDiagramGenerator = (*erDiagramGenerator)(nil)
+
+type erDiagramGenerator struct {
+	repository.TableSchemasInRemoteGetter
+}
+
+// NewERDiagramGenerator creates a new ERDiagramGenerator.
+func NewERDiagramGenerator(getter repository.TableSchemasInRemoteGetter) usecase.ERDiagramGenerator {
+	return &erDiagramGenerator{
+		TableSchemasInRemoteGetter: getter,
+	}
+}
+
+// GenerateERDiagram reads the table definitions and writes them as an ER diagram.
+func (e *erDiagramGenerator) GenerateERDiagram(ctx context.Context, opts model.ERDiagramOptions) (string, error) {
+	tables, err := e.TableSchemasInRemoteGetter.GetTableSchemas(ctx)
+	if err != nil {
+		return "", err
+	}
+	return model.GenerateERDiagram(tables, opts)
+}
+
+// _ interface implementation check
+var _ usecase.QueryPlanGetter = (*queryPlanGetter)(nil)
+
+type queryPlanGetter struct {
+	repository.QueryPlanInRemoteGetter
+}
+
+// NewQueryPlanGetter creates a new QueryPlanGetter.
+func NewQueryPlanGetter(getter repository.QueryPlanInRemoteGetter) usecase.QueryPlanGetter {
+	return &queryPlanGetter{
+		QueryPlanInRemoteGetter: getter,
+	}
+}
+
+// GetQueryPlan gets the plan of the query.
+func (q *queryPlanGetter) GetQueryPlan(ctx context.Context, sql *model.SQL, analyze bool) (*model.QueryPlan, error) {
+	return q.QueryPlanInRemoteGetter.GetQueryPlan(ctx, sql, analyze)
+}
+
+// _ interface implementation check
+var _ usecase.RemoteTableEditor = (*remoteTableEditor)(nil)
+
+type remoteTableEditor struct {
+	repository.RemoteTableEditor
+}
+
+// NewRemoteTableEditor creates a new RemoteTableEditor.
+func NewRemoteTableEditor(editor repository.RemoteTableEditor) usecase.RemoteTableEditor {
+	return &remoteTableEditor{
+		RemoteTableEditor: editor,
+	}
+}
+
+// EditStatements returns the statements that write the edits to the table.
+func (r *remoteTableEditor) EditStatements(edit *model.TableEdit) ([]*model.EditStatement, error) {
+	return r.RemoteTableEditor.EditStatements(edit)
+}
+
+// ApplyEditStatements executes the statements in a transaction.
+func (r *remoteTableEditor) ApplyEditStatements(ctx context.Context, stmts []*model.EditStatement) (int64, error) {
+	return r.RemoteTableEditor.ApplyEditStatements(ctx, stmts)
+}
@@ -0,0 +1,97 @@
+package interactor
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/nao1215/sqluv/domain/model"
+	infrastructure "github.com/nao1215/sqluv/infrastructure/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSchemaComparerCompareSchemas(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success to compare schemas", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		from := infrastructure.NewMockTableSchemasInRemoteGetter(ctrl)
+		to := infrastructure.NewMockTableSchemasInRemoteGetter(ctrl)
+		generator := infrastructure.NewMockAlterStatementsGenerator(ctrl)
+
+		user := &model.TableSchema{Name: "user", Columns: []*model.ColumnSchema{{Name: "id", Type: "integer"}}}
+		from.EXPECT().GetTableSchemas(gomock.Any()).Return([]*model.TableSchema{}, nil)
+		to.EXPECT().GetTableSchemas(gomock.Any()).Return([]*model.TableSchema{user}, nil)
+		wantDiff := &model.SchemaDiff{AddedTables: []*model.TableSchema{user}}
+		generator.EXPECT().GenerateAlterStatements(wantDiff).Return("CREATE TABLE user (id integer);")
+
+		diff, stmts, err := NewSchemaComparer(from, to, generator).CompareSchemas(t.Context())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if d := cmp.Diff(wantDiff, diff); d != "" {
+			t.Errorf("differs: (-want +got)\n%s", d)
+		}
+		if stmts != "CREATE TABLE user (id integer);" {
+			t.Errorf("got %q", stmts)
+		}
+	})
+
+	t.Run("fail to get the schema", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		from := infrastructure.NewMockTableSchemasInRemoteGetter(ctrl)
+		to := infrastructure.NewMockTableSchemasInRemoteGetter(ctrl)
+		generator := infrastructure.NewMockAlterStatementsGenerator(ctrl)
+
+		wantErr := errors.New("connection refused")
+		from.EXPECT().GetTableSchemas(gomock.Any()).Return(nil, wantErr)
+
+		if _, _, err := NewSchemaComparer(from, to, generator).CompareSchemas(t.Context()); !errors.Is(err, wantErr) {
+			t.Errorf("got %v, want %v", err, wantErr)
+		}
+	})
+}
+
+func TestERDiagramGeneratorGenerateERDiagram(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success to generate ER diagram", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		getter := infrastructure.NewMockTableSchemasInRemoteGetter(ctrl)
+		getter.EXPECT().GetTableSchemas(gomock.Any()).Return([]*model.TableSchema{
+			{Name: "user", Columns: []*model.ColumnSchema{{Name: "id", Type: "integer"}}, PrimaryKey: []string{"id"}},
+		}, nil)
+
+		got, err := NewERDiagramGenerator(getter).GenerateERDiagram(t.Context(), model.ERDiagramOptions{
+			Format:    model.ERDiagramMermaid,
+			ShowTypes: true,
+			ShowKeys:  true,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := "erDiagram\n    user {\n        integer id PK\n    }\n"
+		if d := cmp.Diff(want, got); d != "" {
+			t.Errorf("differs: (-want +got)\n%s", d)
+		}
+	})
+
+	t.Run("fail to get the schema", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		getter := infrastructure.NewMockTableSchemasInRemoteGetter(ctrl)
+		wantErr := errors.New("connection refused")
+		getter.EXPECT().GetTableSchemas(gomock.Any()).Return(nil, wantErr)
+
+		if _, err := NewERDiagramGenerator(getter).GenerateERDiagram(t.Context(), model.ERDiagramOptions{}); !errors.Is(err, wantErr) {
+			t.Errorf("got %v, want %v", err, wantErr)
+		}
+	})
+}
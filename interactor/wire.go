@@ -16,4 +16,5 @@ var Set = wire.NewSet(
 	NewHistoryLister,
 	NewTableDDLInRemoteGetter,
 	NewTableDDLGetter,
+	NewCreateTableStatementGetter,
 )
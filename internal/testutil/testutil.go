@@ -0,0 +1,17 @@
+// Package testutil has the test helpers shared by several packages.
+package testutil
+
+import (
+	_ "embed"
+	"strings"
+)
+
+//go:embed testdata/hostile_table_names.txt
+var hostileTableNames string
+
+// HostileTableNames returns the table names that break SQL built by concatenation, e.g. names with
+// quotes, semicolons and comments. They come from file names. The catalog tests of the local workspace
+// and of the DBMS use them.
+func HostileTableNames() []string {
+	return strings.Split(strings.TrimSuffix(hostileTableNames, "\n"), "\n")
+}
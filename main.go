@@ -9,6 +9,7 @@ import (
 
 	"github.com/nao1215/sqluv/config"
 	"github.com/nao1215/sqluv/di"
+	"golang.org/x/term"
 )
 
 // main is the entry point of the sqluv command.
@@ -32,6 +33,13 @@ func run(stdout, stderr io.Writer, args []string) int {
 		return 0
 	}
 
+	if arg.IsDiff() {
+		return runDiff(stdout, stderr, arg)
+	}
+	if arg.IsER() {
+		return runER(stdout, stderr, arg)
+	}
+
 	sqluv, cleanup, err := di.NewSqluv(context.Background(), arg)
 	if err != nil {
 		fmt.Fprintf(stderr, "failed to initialize TUI: %v\n", err)
@@ -45,3 +53,101 @@ func run(stdout, stderr io.Writer, args []string) int {
 	}
 	return 0
 }
+
+// runDiff executes the diff subcommand. It prints the schema differences as SQL comments,
+// followed by the statements that change FROM to the schema of TO.
+func runDiff(stdout, stderr io.Writer, arg *config.Argument) int {
+	fromName, toName := arg.DiffTargets()
+	dbConfig, err := config.NewDBConfig()
+	if err != nil {
+		fmt.Fprintf(stderr, "failed to load the config: %v\n", err)
+		return 1
+	}
+	if err := unlockConfig(stderr, dbConfig); err != nil {
+		fmt.Fprintf(stderr, "failed to unlock the saved passwords: %v\n", err)
+		return 1
+	}
+	from, err := dbConfig.ResolveConnection(fromName)
+	if err != nil {
+		fmt.Fprintf(stderr, "failed to resolve %s: %v\n", fromName, err)
+		return 1
+	}
+	to, err := dbConfig.ResolveConnection(toName)
+	if err != nil {
+		fmt.Fprintf(stderr, "failed to resolve %s: %v\n", toName, err)
+		return 1
+	}
+
+	comparer, cleanup, err := di.NewSchemaComparer(&from, &to)
+	if err != nil {
+		fmt.Fprintf(stderr, "failed to connect to database: %v\n", err)
+		return 1
+	}
+	defer cleanup()
+
+	diff, stmts, err := comparer.CompareSchemas(context.Background())
+	if err != nil {
+		fmt.Fprintf(stderr, "failed to compare schemas: %v\n", err)
+		return 1
+	}
+
+	fmt.Fprintf(stdout, "-- Schema diff: %s -> %s\n", fromName, toName)
+	fmt.Fprint(stdout, diff.Comment())
+	if stmts != "" {
+		fmt.Fprintf(stdout, "\n%s\n", stmts)
+	}
+	return 0
+}
+
+// runER executes the er subcommand. It prints the ER diagram of the connection.
+func runER(stdout, stderr io.Writer, arg *config.Argument) int {
+	target, opts := arg.ERTarget()
+	dbConfig, err := config.NewDBConfig()
+	if err != nil {
+		fmt.Fprintf(stderr, "failed to load the config: %v\n", err)
+		return 1
+	}
+	if err := unlockConfig(stderr, dbConfig); err != nil {
+		fmt.Fprintf(stderr, "failed to unlock the saved passwords: %v\n", err)
+		return 1
+	}
+	conn, err := dbConfig.ResolveConnection(target)
+	if err != nil {
+		fmt.Fprintf(stderr, "failed to resolve %s: %v\n", target, err)
+		return 1
+	}
+
+	generator, cleanup, err := di.NewERDiagramGenerator(&conn)
+	if err != nil {
+		fmt.Fprintf(stderr, "failed to connect to database: %v\n", err)
+		return 1
+	}
+	defer cleanup()
+
+	diagram, err := generator.GenerateERDiagram(context.Background(), opts)
+	if err != nil {
+		fmt.Fprintf(stderr, "failed to generate ER diagram: %v\n", err)
+		return 1
+	}
+	fmt.Fprint(stdout, diagram)
+	return 0
+}
+
+// unlockConfig asks for the master passphrase on the terminal if the saved passwords are locked.
+// If stdin is not a terminal, the passphrase must be set in the SQLUV_PASSPHRASE environment variable.
+func unlockConfig(stderr io.Writer, dbConfig *config.DBConfig) error {
+	if !dbConfig.IsLocked() {
+		return nil
+	}
+	fd := int(os.Stdin.Fd()) //nolint:gosec // The file descriptor of stdin fits in int.
+	if !term.IsTerminal(fd) {
+		return fmt.Errorf("%w: set the passphrase in %s", config.ErrLocked, config.PassphraseEnv)
+	}
+	fmt.Fprint(stderr, "Master passphrase: ")
+	passphrase, err := term.ReadPassword(fd)
+	fmt.Fprintln(stderr)
+	if err != nil {
+		return err
+	}
+	return dbConfig.Unlock(string(passphrase))
+}
@@ -0,0 +1,137 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/nao1215/sqluv/config"
+	"github.com/nao1215/sqluv/domain/model"
+	"github.com/rivo/tview"
+)
+
+// completionPopupRows is the number of candidates shown at once in the completion popup.
+const completionPopupRows = 10
+
+// isCompletionKey returns true if the key shows the completion popup in the query text area.
+// Ctrl-Space always does, and TAB does after a word or a dot, so that TAB still changes the focus
+// after a space.
+func (t *TUI) isCompletionKey(event *tcell.EventKey) bool {
+	if event.Key() == tcell.KeyCtrlSpace {
+		return true
+	}
+	if event.Key() != tcell.KeyTAB {
+		return false
+	}
+	_, cursor, end := t.home.queryTextArea.GetSelection()
+	if cursor != end || cursor == 0 {
+		return false
+	}
+	r, _ := utf8.DecodeLastRuneInString(t.home.queryTextArea.GetText()[:cursor])
+	return r == '_' || r == '.' || r == '"' || r == '`' || r == '[' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// completer returns the completer of the tables in the sidebar and the SQL dialect of the connection.
+// The local workspace is SQLite3.
+func (t *TUI) completer() *model.Completer {
+	dialect := config.NewDialect(config.SQLite3)
+	tables := t.home.sidebar.allTables
+	if t.dbmsUsecases.isDBConnected {
+		dialect = config.NewDialect(t.dbmsUsecases.conn.Type)
+		tables = slices.Concat(tables, t.home.sidebar.localTables)
+	}
+	return model.NewCompleter(tables, dialect.SQLDialect(), dialect.Keywords(), dialect.Functions(), dialect.QuoteIdentifierIfNeeded)
+}
+
+// showCompletion shows the candidates for the word before the cursor of the query text area in a popup
+// below the cursor. It returns false if there is no candidate. In the popup, Enter or TAB replaces the word
+// with the selected candidate, ESC closes the popup, and typing filters the candidates.
+func (t *TUI) showCompletion() bool {
+	textArea := t.home.queryTextArea
+	_, cursor, end := textArea.GetSelection()
+	if cursor != end {
+		return false
+	}
+	start, candidates := t.completer().Complete(textArea.GetText(), cursor)
+	if len(candidates) == 0 {
+		return false
+	}
+
+	colors := t.theme.GetColors()
+	list := tview.NewList().ShowSecondaryText(false).SetHighlightFullLine(true)
+	list.SetBorder(true).
+		SetBorderColor(colors.BorderFocus).
+		SetBackgroundColor(colors.Background)
+	list.SetMainTextStyle(tcell.StyleDefault.
+		Background(colors.Background).
+		Foreground(colors.Foreground))
+	list.SetSelectedStyle(tcell.StyleDefault.
+		Background(colors.Selection).
+		Foreground(colors.SelectionText))
+
+	textWidth := 0
+	for _, c := range candidates {
+		textWidth = max(textWidth, tview.TaggedStringWidth(tview.Escape(c.Text)))
+	}
+	width := 0
+	for _, c := range candidates {
+		detail := c.Kind.String()
+		if c.Detail != "" {
+			detail = fmt.Sprintf("%s %s", c.Kind, c.Detail)
+		}
+		line := fmt.Sprintf("%-*s  %s", textWidth, c.Text, detail)
+		width = max(width, tview.TaggedStringWidth(tview.Escape(line)))
+		list.AddItem(tview.Escape(line), "", 0, nil)
+	}
+
+	closePopup := func() {
+		t.app.SetRoot(t.home.flex, true).SetFocus(textArea)
+	}
+	accept := func() {
+		closePopup()
+		textArea.Replace(start, cursor, candidates[list.GetCurrentItem()].Text)
+	}
+	list.SetSelectedFunc(func(_ int, _, _ string, _ rune) {
+		accept()
+	})
+	list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyUp, tcell.KeyDown, tcell.KeyPgUp, tcell.KeyPgDn, tcell.KeyHome, tcell.KeyEnd, tcell.KeyEnter:
+			return event
+		case tcell.KeyTAB:
+			accept()
+		case tcell.KeyEscape:
+			closePopup()
+		case tcell.KeyRune, tcell.KeyBackspace, tcell.KeyBackspace2:
+			// Type into the query text area and show the candidates of the new word.
+			closePopup()
+			textArea.InputHandler()(event, func(p tview.Primitive) { t.app.SetFocus(p) })
+			t.showCompletion()
+		default:
+			closePopup()
+		}
+		return nil
+	})
+
+	// The popup is shown below the cursor, or above it if there is no space below.
+	x, y, areaWidth, _ := textArea.GetInnerRect()
+	row, column, _, _ := textArea.GetCursor()
+	rowOffset, columnOffset := textArea.GetOffset()
+	_, _, screenWidth, screenHeight := t.home.flex.GetRect()
+	width = min(width+2, screenWidth)
+	height := min(len(candidates), completionPopupRows) + 2
+	x = max(0, min(x+column-columnOffset, x+areaWidth-width, screenWidth-width))
+	y += row - rowOffset + 1
+	if y+height > screenHeight {
+		y = max(0, y-height-1)
+	}
+	list.SetRect(x, y, width, height)
+
+	pages := tview.NewPages().
+		AddPage("home", t.home.flex, true, true).
+		AddPage("completion", list, false, true)
+	t.app.SetRoot(pages, true).SetFocus(list)
+	return true
+}
@@ -0,0 +1,255 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/nao1215/sqluv/config"
+	"github.com/rivo/tview"
+)
+
+const (
+	// connectionListTitle is the title of the connection list, which shows the keys of the actions.
+	connectionListTitle = " Connections | Enter: Connect | e: Edit | c: Duplicate | t: Test | x: Delete | ESC: Back "
+	// importedFolderName is the folder of the connections imported from the psql and mysql clients.
+	importedFolderName = "Imported from psql and mysql"
+	// pingTimeout is the timeout of the ping of "test connection".
+	pingTimeout = 10 * time.Second
+)
+
+// showConnectionsList displays the saved connections in their groups, followed by the connections
+// imported from the psql and mysql clients.
+func (cm *connectionModal) showConnectionsList() {
+	// Load connections from config file
+	connections, err := cm.configMgr.LoadConnections()
+	if errors.Is(err, config.ErrLocked) {
+		cm.showUnlockForm(cm.showConnectionsList, func() {
+			cm.app.SetRoot(cm.Modal, true)
+		})
+		return
+	}
+	if err != nil {
+		cm.showError(fmt.Sprintf("Failed to load connections: %v", err))
+		return
+	}
+
+	// The connections of the psql and mysql clients are listed after the saved connections.
+	imported, importErr := config.ImportClientConnections()
+	imported = slices.DeleteFunc(imported, func(conn config.DBConnection) bool {
+		return slices.ContainsFunc(connections, func(c config.DBConnection) bool { return c.Name == conn.Name })
+	})
+
+	if len(connections) == 0 && len(imported) == 0 {
+		if importErr != nil {
+			cm.showError(fmt.Sprintf("No saved connections found, and failed to import connections: %v", importErr))
+			return
+		}
+		cm.showError("No saved connections found")
+		return
+	}
+
+	names := make([]string, 0, len(connections))
+	for _, conn := range connections {
+		names = append(names, conn.Name)
+	}
+
+	root := tview.NewTreeNode("Connections").SetSelectable(false)
+	folders := map[string]*tview.TreeNode{}
+	for _, conn := range connections {
+		parent := root
+		if conn.Group != "" {
+			if folders[conn.Group] == nil {
+				folders[conn.Group] = newFolderNode(conn.Group)
+				root.AddChild(folders[conn.Group])
+			}
+			parent = folders[conn.Group]
+		}
+		parent.AddChild(cm.newConnectionNode(conn))
+	}
+	if len(imported) > 0 || importErr != nil {
+		folder := newFolderNode(importedFolderName)
+		for _, conn := range imported {
+			folder.AddChild(cm.newConnectionNode(conn))
+		}
+		if importErr != nil {
+			folder.AddChild(tview.NewTreeNode("! Import errors").SetSelectedFunc(func() {
+				cm.showMessage(fmt.Sprintf("Failed to import connections: %v", importErr), []string{"OK"}, func(_ string) {
+					cm.showConnectionsList()
+				})
+			}))
+		}
+		root.AddChild(folder)
+	}
+	back := func() {
+		cm.app.SetRoot(cm.Modal, true)
+	}
+	root.AddChild(tview.NewTreeNode("Back").SetSelectedFunc(back))
+
+	tree := tview.NewTreeView().SetRoot(root).SetTopLevel(1)
+	tree.SetCurrentNode(root.GetChildren()[0])
+	tree.SetBorder(true).SetTitle(connectionListTitle)
+	tree.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyESC {
+			back()
+			return nil
+		}
+		conn, ok := tree.GetCurrentNode().GetReference().(config.DBConnection)
+		if !ok {
+			return event
+		}
+		switch {
+		case event.Rune() == 'e':
+			cm.editConnection(conn)
+		case event.Rune() == 'c':
+			cm.duplicateConnection(conn, names)
+		case event.Rune() == 't':
+			cm.testConnection(conn)
+		case event.Rune() == 'x', event.Key() == tcell.KeyDelete:
+			cm.deleteConnection(conn)
+		default:
+			return event
+		}
+		return nil
+	})
+
+	colors := cm.theme.GetColors()
+	tree.SetBackgroundColor(colors.Background)
+	tree.SetTitleColor(colors.Header)
+	tree.SetBorderColor(colors.BorderFocus)
+	tree.SetGraphicsColor(colors.Foreground)
+	applyThemeToTreeNodes(root, colors)
+	// The connections are colored by their environment tags after the theme is applied.
+	root.Walk(func(node, _ *tview.TreeNode) bool {
+		if conn, ok := node.GetReference().(config.DBConnection); ok {
+			if color, ok := environmentColor(conn.Environment); ok {
+				node.SetTextStyle(tcell.StyleDefault.
+					Background(colors.Background).
+					Foreground(color))
+			}
+		}
+		return true
+	})
+
+	// Show the list
+	cm.app.SetRoot(tree, true)
+}
+
+// newFolderNode returns the node of a group, which is expanded and collapsed by Enter.
+func newFolderNode(name string) *tview.TreeNode {
+	folder := tview.NewTreeNode(tview.Escape("▼ " + name))
+	folder.SetSelectedFunc(func() {
+		folder.SetExpanded(!folder.IsExpanded())
+		if folder.IsExpanded() {
+			folder.SetText(tview.Escape("▼ " + name))
+		} else {
+			folder.SetText(tview.Escape("▶ " + name))
+		}
+	})
+	return folder
+}
+
+// newConnectionNode returns the node of the connection, which connects to the database by Enter.
+func (cm *connectionModal) newConnectionNode(conn config.DBConnection) *tview.TreeNode {
+	text := conn.Name
+	if conn.Environment != "" {
+		text = fmt.Sprintf("[%s] %s", conn.Environment, text)
+	}
+	if conn.ReadOnly {
+		text += " (read-only)"
+	}
+	if conn.Type == config.SQLite3 {
+		text += fmt.Sprintf("  %s database=%s", conn.Type, conn.Database)
+	} else {
+		text += fmt.Sprintf("  %s %s:%d database=%s", conn.Type, conn.Host, conn.Port, conn.Database)
+	}
+	if conn.Source != "" {
+		text += " (from " + conn.Source + ")"
+	}
+	return tview.NewTreeNode(tview.Escape(text)).
+		SetReference(conn).
+		SetSelectedFunc(func() {
+			cm.connectToDatabase(conn)
+		})
+}
+
+// editConnection displays the form of the connection, and saves the changes. A connection imported
+// from the psql and mysql clients is saved as a new connection, because its file is not changed.
+func (cm *connectionModal) editConnection(conn config.DBConnection) {
+	backToList := func(_ config.DBConnection) { cm.showConnectionsList() }
+	if conn.Source != "" {
+		conn.Source = ""
+		cm.showConnectionForm("Save Imported Connection", &conn, "", backToList, cm.showConnectionsList)
+		return
+	}
+	cm.showConnectionForm("Edit Connection: "+conn.Name, &conn, conn.Name, backToList, cm.showConnectionsList)
+}
+
+// duplicateConnection displays the form of a copy of the connection, which is saved as a new connection.
+// names is the names of the saved connections.
+func (cm *connectionModal) duplicateConnection(conn config.DBConnection, names []string) {
+	conn.Name = copyName(conn.Name, names)
+	conn.Source = ""
+	cm.showConnectionForm("Duplicate Connection", &conn, "", func(_ config.DBConnection) {
+		cm.showConnectionsList()
+	}, cm.showConnectionsList)
+}
+
+// copyName returns "name (copy)", or "name (copy N)" if the name is used.
+func copyName(name string, names []string) string {
+	candidate := name + " (copy)"
+	for i := 2; slices.Contains(names, candidate); i++ {
+		candidate = fmt.Sprintf("%s (copy %d)", name, i)
+	}
+	return candidate
+}
+
+// testConnection connects to the database and pings it, and shows the latency.
+func (cm *connectionModal) testConnection(conn config.DBConnection) {
+	progress := tview.NewModal().SetText(fmt.Sprintf("Testing connection '%s'...", conn.Name))
+	colors := cm.theme.GetColors()
+	progress.SetBackgroundColor(colors.Background)
+	progress.SetTextColor(colors.Foreground)
+	cm.app.SetRoot(progress, true)
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), pingTimeout)
+		defer cancel()
+		latency, err := config.PingConnection(ctx, &conn)
+
+		message := fmt.Sprintf("Connected to '%s'.\nPing: %.1f ms", conn.Name, float64(latency.Microseconds())/1000)
+		if err != nil {
+			message = fmt.Sprintf("Failed to connect to '%s': %v", conn.Name, err)
+		}
+		cm.app.QueueUpdateDraw(func() {
+			cm.showMessage(message, []string{"OK"}, func(_ string) {
+				cm.showConnectionsList()
+			})
+		})
+	}()
+}
+
+// deleteConnection removes the connection from the config file after confirmation.
+func (cm *connectionModal) deleteConnection(conn config.DBConnection) {
+	if conn.Source != "" {
+		cm.showMessage(fmt.Sprintf("'%s' is imported from %s. Remove it from there.", conn.Name, conn.Source),
+			[]string{"OK"}, func(_ string) {
+				cm.showConnectionsList()
+			})
+		return
+	}
+	cm.showMessage(fmt.Sprintf("Delete connection '%s'?", conn.Name), []string{"Delete", "Cancel"}, func(buttonLabel string) {
+		if buttonLabel != "Delete" {
+			cm.showConnectionsList()
+			return
+		}
+		if err := cm.configMgr.RemoveConnection(conn.Name); err != nil {
+			cm.showError(err.Error())
+			return
+		}
+		cm.showConnectionsList()
+	})
+}
@@ -1,8 +1,10 @@
 package tui
 
 import (
-	"fmt"
+	"errors"
+	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/nao1215/sqluv/config"
@@ -17,20 +19,15 @@ type connectionModal struct {
 	configMgr    *config.DBConfig
 	selectedConn *config.DBConnection
 	theme        *Theme
+	onCancel     func() // if nil, canceling the modal stops the application
 }
 
-// newConnectionModal creates a new connection modal
-func newConnectionModal(app *tview.Application, theme *Theme, onClose func(conn *config.DBConnection)) *connectionModal {
+// newConnectionModal creates a new connection modal. The config manager is shared with TUI,
+// so that the master passphrase is entered once while sqluv runs.
+func newConnectionModal(app *tview.Application, theme *Theme, configMgr *config.DBConfig, onClose func(conn *config.DBConnection)) *connectionModal {
 	modal := tview.NewModal().
 		SetText("You does not specify files. Would you like to connect to a database?").
-		AddButtons([]string{"New", "List", "Cancel"})
-
-	// Create config manager
-	configMgr, err := config.NewDBConfig()
-	if err != nil {
-		// Handle error gracefully - could show an error modal
-		modal.SetText("Error: Could not initialize configuration manager\n" + err.Error())
-	}
+		AddButtons([]string{"New", "List", "Passphrase", "Cancel"})
 
 	cm := &connectionModal{
 		Modal:     modal,
@@ -46,10 +43,17 @@ func newConnectionModal(app *tview.Application, theme *Theme, onClose func(conn
 			cm.showNewConnectionForm()
 		case "List":
 			cm.showConnectionsList()
+		case "Passphrase":
+			back := func() { cm.app.SetRoot(cm.Modal, true) }
+			cm.showPassphraseForm(back, back)
 		case "Cancel":
 			if onClose != nil {
 				onClose(nil)
 			}
+			if cm.onCancel != nil {
+				cm.onCancel()
+				return
+			}
 			cm.app.Stop()
 		}
 	})
@@ -57,12 +61,28 @@ func newConnectionModal(app *tview.Application, theme *Theme, onClose func(conn
 	return cm
 }
 
+// setCancelFunc sets the function called when the modal is canceled instead of stopping the application.
+func (cm *connectionModal) setCancelFunc(onCancel func()) {
+	cm.onCancel = onCancel
+}
+
 // showNewConnectionForm displays the form for adding a new database connection
 func (cm *connectionModal) showNewConnectionForm() {
+	cm.showConnectionForm("New Database Connection", nil, "", cm.connectToDatabase, func() {
+		cm.app.SetRoot(cm.Modal, true)
+	})
+}
+
+// showConnectionForm displays the form of the connection settings. The fields are filled with initial
+// if it is not nil, and its settings that are not in the form (e.g. Schema) are kept. originalName is
+// the name of the saved connection that is edited, and it is empty for a new connection. onSaved is
+// called after the connection is saved, and onCancel is called when the form is canceled.
+func (cm *connectionModal) showConnectionForm(title string, initial *config.DBConnection, originalName string,
+	onSaved func(conn config.DBConnection), onCancel func()) {
 	form := tview.NewForm()
 
 	form.AddInputField("Connection Name", "", 0, nil, nil)
-	dbmsTypes := []string{string(config.MySQL), string(config.PostgreSQL), string(config.SQLite3), string(config.SQLServer)}
+	dbmsTypes := []string{string(config.MySQL), string(config.PostgreSQL), string(config.SQLite3), string(config.SQLServer), string(config.Oracle)}
 
 	// First add all form fields without the callback
 	form.AddDropDown("DBMS Type", dbmsTypes, 0, nil)
@@ -74,6 +94,44 @@ func (cm *connectionModal) showNewConnectionForm() {
 	form.AddPasswordField("Password", "", 0, '*', nil)
 	form.AddInputField("Database Name", "", 0, nil, nil)
 	form.AddInputField("Database File Path (SQLite3 only)", "", 0, nil, nil)
+	form.AddCheckbox("Read Only", false, nil)
+	form.AddInputField("Connection URL (overrides the fields above)", "", 0, nil, nil)
+	form.AddInputField("Params (key=value, ...)", "", 0, nil, nil)
+	tlsModes := make([]string, 0, len(config.TLSModes))
+	for _, mode := range config.TLSModes {
+		if mode == "" {
+			tlsModes = append(tlsModes, "default")
+			continue
+		}
+		tlsModes = append(tlsModes, string(mode))
+	}
+	form.AddDropDown("TLS Mode", tlsModes, 0, nil)
+	form.AddInputField("TLS CA File", "", 0, nil, nil)
+	form.AddInputField("TLS Client Certificate File", "", 0, nil, nil)
+	form.AddInputField("TLS Client Key File", "", 0, nil, nil)
+	form.AddInputField("TLS Server Name", "", 0, nil, nil)
+	form.AddInputField("SSH Host (bastion)", "", 0, nil, nil)
+	form.AddInputField("SSH Port", "22", 0, func(_ string, lastChar rune) bool {
+		return '0' <= lastChar && lastChar <= '9'
+	}, nil)
+	form.AddInputField("SSH User", "", 0, nil, nil)
+	form.AddInputField("SSH Private Key File", "", 0, nil, nil)
+	form.AddCheckbox("SSH Use ssh-agent", false, nil)
+	form.AddInputField("SSH known_hosts File", "", 0, nil, nil)
+	form.AddInputField("Password Command (instead of the password)", "", 0, nil, nil)
+	form.AddInputField("Group (folder in the list)", "", 0, nil, nil)
+	environments := make([]string, 0, len(config.Environments))
+	for _, env := range config.Environments {
+		if env == "" {
+			environments = append(environments, "none")
+			continue
+		}
+		environments = append(environments, string(env))
+	}
+	form.AddDropDown("Environment", environments, 0, nil)
+	form.AddInputField("SID (Oracle only, instead of the service name)", "", 0, nil, nil)
+	// The form has many fields, so they are not separated by blank lines.
+	form.SetItemPadding(0)
 
 	// Now that all fields exist, we can set up the callback for the dropdown
 	dbmsDropdown := form.GetFormItem(1).(*tview.DropDown)
@@ -83,9 +141,11 @@ func (cm *connectionModal) showNewConnectionForm() {
 	passwordField := form.GetFormItem(5).(*tview.InputField)
 	databaseField := form.GetFormItem(6).(*tview.InputField)
 	filePathField := form.GetFormItem(7).(*tview.InputField)
+	sidField := form.GetFormItem(25).(*tview.InputField)
 
-	// Initially disable the SQLite3 file path field
+	// Initially disable the SQLite3 file path field and the Oracle SID field
 	filePathField.SetDisabled(true)
+	sidField.SetDisabled(true)
 
 	// Set the change handler for the dropdown
 	dbmsDropdown.SetSelectedFunc(func(option string, _ int) {
@@ -127,6 +187,8 @@ func (cm *connectionModal) showNewConnectionForm() {
 				portField.SetText("5432")
 			} else if option == string(config.SQLServer) {
 				portField.SetText("1433")
+			} else if option == string(config.Oracle) {
+				portField.SetText("1521")
 			}
 
 			usernameField.SetLabel("Username")
@@ -140,13 +202,22 @@ func (cm *connectionModal) showNewConnectionForm() {
 			databaseField.SetLabel("Database Name")
 			databaseField.SetDisabled(false)
 			databaseField.SetText("")
+			if option == string(config.Oracle) {
+				databaseField.SetLabel("Service Name")
+			}
 
 			filePathField.SetLabel("Database File Path (SQLite3 only)")
 			filePathField.SetDisabled(true)
 			filePathField.SetText("")
 		}
+		sidField.SetDisabled(option != string(config.Oracle))
+		sidField.SetText("")
 	})
 
+	if initial != nil {
+		fillConnectionForm(form, dbmsTypes, *initial)
+	}
+
 	form.AddButton("Save", func() {
 		name := form.GetFormItem(0).(*tview.InputField).GetText()
 		dbmsTypeIndex, _ := form.GetFormItem(1).(*tview.DropDown).GetCurrentOption()
@@ -157,16 +228,79 @@ func (cm *connectionModal) showNewConnectionForm() {
 		password := form.GetFormItem(5).(*tview.InputField).GetText()
 		database := form.GetFormItem(6).(*tview.InputField).GetText()
 		filePath := form.GetFormItem(7).(*tview.InputField).GetText()
+		readOnly := form.GetFormItem(8).(*tview.Checkbox).IsChecked()
+		dsn := strings.TrimSpace(form.GetFormItem(9).(*tview.InputField).GetText())
+		params, err := config.ParseParams(form.GetFormItem(10).(*tview.InputField).GetText())
+		if err != nil {
+			cm.showError(err.Error())
+			return
+		}
+
+		tlsModeIndex, _ := form.GetFormItem(11).(*tview.DropDown).GetCurrentOption()
+		tlsConfig := config.TLSConfig{
+			Mode:       config.TLSModes[tlsModeIndex],
+			CAFile:     strings.TrimSpace(form.GetFormItem(12).(*tview.InputField).GetText()),
+			CertFile:   strings.TrimSpace(form.GetFormItem(13).(*tview.InputField).GetText()),
+			KeyFile:    strings.TrimSpace(form.GetFormItem(14).(*tview.InputField).GetText()),
+			ServerName: strings.TrimSpace(form.GetFormItem(15).(*tview.InputField).GetText()),
+		}
+		if err := tlsConfig.Validate(); err != nil {
+			cm.showError(err.Error())
+			return
+		}
+		sshPort, _ := strconv.Atoi(form.GetFormItem(17).(*tview.InputField).GetText()) //nolint:errcheck // Only digits are accepted
+		sshConfig := config.SSHTunnelConfig{
+			Host:           strings.TrimSpace(form.GetFormItem(16).(*tview.InputField).GetText()),
+			Port:           sshPort,
+			User:           strings.TrimSpace(form.GetFormItem(18).(*tview.InputField).GetText()),
+			KeyFile:        strings.TrimSpace(form.GetFormItem(19).(*tview.InputField).GetText()),
+			UseAgent:       form.GetFormItem(20).(*tview.Checkbox).IsChecked(),
+			KnownHostsFile: strings.TrimSpace(form.GetFormItem(21).(*tview.InputField).GetText()),
+		}
+		if initial != nil {
+			sshConfig.KeepAlive = initial.SSH.KeepAlive
+		}
+		if !sshConfig.Enabled() {
+			sshConfig = config.SSHTunnelConfig{}
+		}
+		if err := sshConfig.Validate(); err != nil {
+			cm.showError(err.Error())
+			return
+		}
 
 		port, _ := strconv.Atoi(portStr) //nolint:errcheck // Error is handled by the form validation
 
+		environmentIndex, _ := form.GetFormItem(24).(*tview.DropDown).GetCurrentOption()
 		conn := config.DBConnection{
-			Name: name,
-			Type: config.DBMSType(dbmsType),
+			Name:            name,
+			Type:            config.DBMSType(dbmsType),
+			ReadOnly:        readOnly,
+			Params:          params,
+			TLS:             tlsConfig,
+			SSH:             sshConfig,
+			PasswordCommand: strings.TrimSpace(form.GetFormItem(22).(*tview.InputField).GetText()),
+			Group:           strings.TrimSpace(form.GetFormItem(23).(*tview.InputField).GetText()),
+			Environment:     config.Environments[environmentIndex],
+		}
+		if initial != nil {
+			conn.Schema = initial.Schema
+			conn.Pool = initial.Pool
 		}
 
 		// Set appropriate fields based on DBMS type
-		if conn.Type == config.SQLite3 {
+		if dsn != "" {
+			// The URL is saved as the fields, so that the password is encrypted in the configuration file.
+			conn.DSN = dsn
+			conn.Type = ""
+			if conn, err = conn.ResolveDSN(); err != nil {
+				cm.showError(err.Error())
+				return
+			}
+			conn.DSN = ""
+			if conn.Name == "" {
+				conn.Name = conn.Database
+			}
+		} else if conn.Type == config.SQLite3 {
 			// For SQLite3, only the file path is relevant
 			conn.Database = filePath
 			// Leave other fields with empty/default values
@@ -181,27 +315,92 @@ func (cm *connectionModal) showNewConnectionForm() {
 			conn.User = username
 			conn.Password = password
 			conn.Database = database
+			if conn.Type == config.Oracle {
+				conn.SID = strings.TrimSpace(sidField.GetText())
+			}
 		}
 
-		// Save connection in configuration
-		if err := cm.configMgr.SaveConnection(conn); err != nil {
-			cm.showError(err.Error())
-			return
-		}
-
-		// Set selected connection and call onClose with it
-		cm.selectedConn = &conn
-		if cm.onClose != nil {
-			cm.onClose(cm.selectedConn)
-		}
+		cm.saveConnection(conn, originalName, func() {
+			cm.app.SetRoot(form, true)
+		}, onSaved)
 	})
 
-	form.AddButton("Cancel", func() {
-		cm.app.SetRoot(cm.Modal, true)
-	})
+	form.AddButton("Cancel", onCancel)
 
-	form.SetBorder(true).SetTitle("New Database Connection")
+	form.SetBorder(true).SetTitle(title)
+	cm.applyFormTheme(form)
+	cm.app.SetRoot(form, true)
+}
+
+// saveConnection saves the connection, replacing the saved connection named originalName if it is not empty.
+// If the password needs the master passphrase, it asks for the passphrase and saves again. back returns
+// to the form when the passphrase is canceled, and onSaved is called after the connection is saved.
+func (cm *connectionModal) saveConnection(conn config.DBConnection, originalName string, back func(), onSaved func(conn config.DBConnection)) {
+	var err error
+	if originalName != "" {
+		err = cm.configMgr.UpdateConnection(originalName, conn)
+	} else {
+		err = cm.configMgr.SaveConnection(conn)
+	}
+	retry := func() { cm.saveConnection(conn, originalName, back, onSaved) }
+	switch {
+	case errors.Is(err, config.ErrNoPassphrase):
+		cm.showPassphraseForm(retry, back)
+		return
+	case errors.Is(err, config.ErrLocked):
+		cm.showUnlockForm(retry, back)
+		return
+	case err != nil:
+		cm.showError(err.Error())
+		return
+	}
+	onSaved(conn)
+}
 
+// fillConnectionForm fills the fields of the connection form with the connection.
+func fillConnectionForm(form *tview.Form, dbmsTypes []string, conn config.DBConnection) {
+	form.GetFormItem(0).(*tview.InputField).SetText(conn.Name)
+	if i := slices.Index(dbmsTypes, string(conn.Type)); i >= 0 {
+		// Selecting the DBMS type resets the fields, so it is selected first.
+		form.GetFormItem(1).(*tview.DropDown).SetCurrentOption(i)
+	}
+	if conn.Type == config.SQLite3 {
+		form.GetFormItem(7).(*tview.InputField).SetText(conn.Database)
+	} else {
+		form.GetFormItem(2).(*tview.InputField).SetText(conn.Host)
+		form.GetFormItem(3).(*tview.InputField).SetText(strconv.Itoa(conn.Port))
+		form.GetFormItem(4).(*tview.InputField).SetText(conn.User)
+		form.GetFormItem(5).(*tview.InputField).SetText(conn.Password)
+		form.GetFormItem(6).(*tview.InputField).SetText(conn.Database)
+	}
+	form.GetFormItem(8).(*tview.Checkbox).SetChecked(conn.ReadOnly)
+	form.GetFormItem(9).(*tview.InputField).SetText(conn.DSN)
+	form.GetFormItem(10).(*tview.InputField).SetText(config.FormatParams(conn.Params))
+	if i := slices.Index(config.TLSModes, conn.TLS.Mode); i >= 0 {
+		form.GetFormItem(11).(*tview.DropDown).SetCurrentOption(i)
+	}
+	form.GetFormItem(12).(*tview.InputField).SetText(conn.TLS.CAFile)
+	form.GetFormItem(13).(*tview.InputField).SetText(conn.TLS.CertFile)
+	form.GetFormItem(14).(*tview.InputField).SetText(conn.TLS.KeyFile)
+	form.GetFormItem(15).(*tview.InputField).SetText(conn.TLS.ServerName)
+	form.GetFormItem(16).(*tview.InputField).SetText(conn.SSH.Host)
+	if conn.SSH.Port != 0 {
+		form.GetFormItem(17).(*tview.InputField).SetText(strconv.Itoa(conn.SSH.Port))
+	}
+	form.GetFormItem(18).(*tview.InputField).SetText(conn.SSH.User)
+	form.GetFormItem(19).(*tview.InputField).SetText(conn.SSH.KeyFile)
+	form.GetFormItem(20).(*tview.Checkbox).SetChecked(conn.SSH.UseAgent)
+	form.GetFormItem(21).(*tview.InputField).SetText(conn.SSH.KnownHostsFile)
+	form.GetFormItem(22).(*tview.InputField).SetText(conn.PasswordCommand)
+	form.GetFormItem(23).(*tview.InputField).SetText(conn.Group)
+	if i := slices.Index(config.Environments, conn.Environment); i >= 0 {
+		form.GetFormItem(24).(*tview.DropDown).SetCurrentOption(i)
+	}
+	form.GetFormItem(25).(*tview.InputField).SetText(conn.SID)
+}
+
+// applyFormTheme applies the current theme to the form.
+func (cm *connectionModal) applyFormTheme(form *tview.Form) {
 	// Retrieve colors from the current theme (via config.ColorConfig)
 	colors := cm.theme.GetColors()
 	form.SetBackgroundColor(colors.Background)
@@ -222,73 +421,6 @@ func (cm *connectionModal) showNewConnectionForm() {
 	form.SetFieldStyle(tcell.StyleDefault.
 		Background(colors.Background).
 		Foreground(colors.Foreground))
-
-	cm.app.SetRoot(form, true)
-}
-
-// showConnectionsList displays the list of saved connections
-func (cm *connectionModal) showConnectionsList() {
-	// Load connections from config file
-	connections, err := cm.configMgr.LoadConnections()
-	if err != nil {
-		cm.showError(fmt.Sprintf("Failed to load connections: %v", err))
-		return
-	}
-
-	if len(connections) == 0 {
-		cm.showError("No saved connections found")
-		return
-	}
-	// Create a list to display connections
-	colors := cm.theme.GetColors()
-	list := tview.NewList()
-	list.SetTitle("Saved Connections").SetBackgroundColor(colors.Background)
-
-	// Add connections to the list
-	for i, conn := range connections {
-		if conn.Type == config.SQLite3 {
-			list.AddItem(
-				conn.Name,
-				fmt.Sprintf("%s database=%s", conn.Type, conn.Database),
-				rune('1'+i),
-				func() {
-					cm.connectToDatabase(conn)
-				})
-			continue
-		}
-		list.AddItem(
-			conn.Name,
-			fmt.Sprintf("%s %s:%d database=%s", conn.Type, conn.Host, conn.Port, conn.Database),
-			rune('1'+i),
-			func() {
-				cm.connectToDatabase(conn)
-			})
-	}
-
-	// Add a "Back" button at the bottom
-	list.AddItem("Back", "Return to connection options", '*', func() {
-		cm.app.SetRoot(cm.Modal, true)
-	})
-
-	list.SetBorder(true)
-	list.SetTitleColor(colors.Header)
-	list.SetBorderStyle(tcell.StyleDefault.
-		Background(colors.Border).
-		Foreground(colors.BorderFocus))
-	list.SetBackgroundColor(colors.Background)
-	list.SetMainTextStyle(tcell.StyleDefault.
-		Background(colors.Background).
-		Foreground(colors.Foreground))
-	list.SetSecondaryTextStyle(tcell.StyleDefault.
-		Background(colors.Background).
-		Foreground(colors.ButtonText))
-	list.SetSelectedBackgroundColor(colors.Selection)
-	list.SetShortcutStyle(tcell.StyleDefault.
-		Background(colors.Background).
-		Foreground(colors.Foreground))
-
-	// Show the list
-	cm.app.SetRoot(list, true)
 }
 
 // connectToDatabase connects to the selected database
@@ -302,29 +434,36 @@ func (cm *connectionModal) connectToDatabase(conn config.DBConnection) {
 	}
 }
 
-// showError displays an error message
+// showError displays an error message, and returns to the connection options.
 func (cm *connectionModal) showError(message string) {
-	errorModal := tview.NewModal().
+	cm.showMessage(message, []string{"OK"}, func(_ string) {
+		cm.app.SetRoot(cm.Modal, true)
+	})
+}
+
+// showMessage displays a message with the buttons. onDone is called with the label of the pressed button.
+func (cm *connectionModal) showMessage(message string, buttons []string, onDone func(buttonLabel string)) {
+	messageModal := tview.NewModal().
 		SetText(message).
-		AddButtons([]string{"OK"}).
-		SetDoneFunc(func(_ int, _ string) {
-			cm.app.SetRoot(cm.Modal, true)
+		AddButtons(buttons).
+		SetDoneFunc(func(_ int, buttonLabel string) {
+			onDone(buttonLabel)
 		})
 
 	colors := cm.theme.GetColors()
-	errorModal.SetBackgroundColor(colors.Background)
-	errorModal.SetBorderStyle(tcell.StyleDefault.
+	messageModal.SetBackgroundColor(colors.Background)
+	messageModal.SetBorderStyle(tcell.StyleDefault.
 		Background(colors.Background).
 		Foreground(colors.BorderFocus))
-	errorModal.SetButtonActivatedStyle(tcell.StyleDefault.
+	messageModal.SetButtonActivatedStyle(tcell.StyleDefault.
 		Background(colors.ButtonFocus).
 		Foreground(colors.ButtonTextFocus))
-	errorModal.SetButtonStyle(tcell.StyleDefault.
+	messageModal.SetButtonStyle(tcell.StyleDefault.
 		Background(colors.Button).
 		Foreground(colors.ButtonText))
-	errorModal.SetFocus(0)
+	messageModal.SetFocus(0)
 
-	cm.app.SetRoot(errorModal, true)
+	cm.app.SetRoot(messageModal, true)
 }
 
 func (cm *connectionModal) applyTheme(theme *Theme) {
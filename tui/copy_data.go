@@ -0,0 +1,188 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/nao1215/sqluv/domain/model"
+	"github.com/rivo/tview"
+)
+
+// copyBatchSize is the number of records committed at once when data is copied between connections.
+const copyBatchSize = 1000
+
+// errNoRecordsToCopy is returned when the query returns no records. The target table is not created,
+// because the column types are taken from the records.
+var errNoRecordsToCopy = errors.New("the query returned no records, so nothing was copied")
+
+// connectedSessions returns the tabs connected to a DBMS.
+func (t *TUI) connectedSessions() []*session {
+	t.saveSession()
+	sessions := []*session{}
+	for _, s := range t.sessions {
+		if s.dbmsUsecases.isDBConnected {
+			sessions = append(sessions, s)
+		}
+	}
+	return sessions
+}
+
+// showCopyDataDialog asks for a query on one connection and a table on another connection, and copies
+// the query result into the table. The records are committed every copyBatchSize records, so the records
+// copied before an error or a cancel remain in the target table.
+func (t *TUI) showCopyDataDialog(table *model.Table) {
+	sessions := t.connectedSessions()
+	if len(sessions) == 0 {
+		t.showError(errors.New("copying data needs a database connection"))
+		return
+	}
+	titles := make([]string, 0, len(sessions))
+	current := 0
+	for i, s := range sessions {
+		titles = append(titles, s.title)
+		if s == t.currentSession() {
+			current = i
+		}
+	}
+	query := t.home.queryTextArea.GetText()
+	tableName := ""
+	if table != nil {
+		query = t.selectTableQuery(table, 0)
+		tableName = table.Name()
+	}
+	colors := t.theme.GetColors()
+
+	progress := tview.NewTextView().SetDynamicColors(false).SetWrap(true)
+	progress.SetTitle(" Progress ").
+		SetTitleAlign(tview.AlignLeft).
+		SetBorder(true).
+		SetBorderColor(colors.Border).
+		SetBackgroundColor(colors.Background)
+	progress.SetTextColor(colors.Foreground)
+
+	form := tview.NewForm()
+	form.AddDropDown("From", titles, current, nil).
+		AddInputField("Query", query, 0, nil, nil).
+		AddDropDown("To", titles, current, nil).
+		AddInputField("Target Table", tableName, 0, nil, nil)
+
+	var cancel context.CancelFunc // not nil while copying
+	form.AddButton("Copy", func() {
+		if cancel != nil {
+			return
+		}
+		from, _ := form.GetFormItem(0).(*tview.DropDown).GetCurrentOption()
+		to, _ := form.GetFormItem(2).(*tview.DropDown).GetCurrentOption()
+		target := strings.TrimSpace(form.GetFormItem(3).(*tview.InputField).GetText())
+		sql, err := model.NewSQL(form.GetFormItem(1).(*tview.InputField).GetText())
+		if err != nil {
+			progress.SetText(err.Error())
+			return
+		}
+		if target == "" {
+			progress.SetText("target table is empty")
+			return
+		}
+
+		var ctx context.Context
+		ctx, cancel = context.WithCancel(context.Background())
+		progress.SetText("Copying...")
+		go func() {
+			copied, created, err := t.copyData(ctx, sessions[from], sessions[to], sql, target, func(copied int64) {
+				t.app.QueueUpdateDraw(func() {
+					progress.SetText(fmt.Sprintf("Copying... %d records committed", copied))
+				})
+			})
+			t.app.QueueUpdateDraw(func() {
+				cancel()
+				cancel = nil
+				if errors.Is(err, errNoRecordsToCopy) {
+					progress.SetText(err.Error())
+					return
+				}
+				if err != nil {
+					progress.SetText(fmt.Sprintf("Failed after %d records were committed: %s", copied, err.Error()))
+					return
+				}
+				progress.SetText(fmt.Sprintf("Copied %d records into %s on %s", copied, target, sessions[to].title))
+				if created && sessions[to] == t.currentSession() {
+					t.loadDatabaseTables(context.Background(), t.dbmsUsecases.databaseName)
+				}
+			})
+		}()
+	}).
+		AddButton("Cancel", func() {
+			if cancel != nil {
+				cancel()
+				return
+			}
+			t.app.SetRoot(t.home.flex, true)
+			t.app.SetFocus(t.home.sidebar)
+		})
+
+	form.SetBorder(true).
+		SetTitle("Copy Data between Connections").
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderStyle(tcell.StyleDefault.
+			Background(colors.Background).
+			Foreground(colors.BorderFocus))
+	form.SetButtonActivatedStyle(tcell.StyleDefault.
+		Background(colors.ButtonFocus).
+		Foreground(colors.ButtonTextFocus)).
+		SetButtonStyle(tcell.StyleDefault.
+			Background(colors.Button).
+			Foreground(colors.ButtonText)).
+		SetFieldStyle(tcell.StyleDefault.
+			Background(colors.Background).
+			Foreground(colors.Foreground)).
+		SetBackgroundColor(colors.Background)
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(form, 13, 0, true).
+		AddItem(progress, 0, 1, false)
+	t.app.SetRoot(layout, true)
+	t.app.SetFocus(form)
+}
+
+// copyData streams the query result of the source tab into the table of the target tab.
+// The target table is created with the column types of the query result if it does not exist.
+// onProgress is called with the number of committed records after each batch.
+// It returns the number of committed records and whether the table was created,
+// or errNoRecordsToCopy if the query returns no records.
+func (t *TUI) copyData(
+	ctx context.Context,
+	from, to *session,
+	sql *model.SQL,
+	target string,
+	onProgress func(copied int64),
+) (int64, bool, error) {
+	var plan *model.ImportPlan
+	var copied int64
+	created := false
+	err := from.dbmsUsecases.streamer.StreamQuery(ctx, sql, copyBatchSize, func(batch *model.Table, types []model.ColumnType) error {
+		if plan == nil {
+			p, err := to.dbmsUsecases.importer.PlanImport(ctx, batch, target, types)
+			if err != nil {
+				return err
+			}
+			plan = p
+			created = p.CreatesTable()
+		} else {
+			plan = plan.NextBatch(batch)
+		}
+		n, err := to.dbmsUsecases.importer.Import(ctx, plan)
+		if err != nil {
+			return err
+		}
+		copied += n
+		onProgress(copied)
+		return nil
+	})
+	if err == nil && plan == nil {
+		return 0, false, errNoRecordsToCopy
+	}
+	return copied, created, err
+}
@@ -0,0 +1,192 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/nao1215/sqluv/config"
+	"github.com/nao1215/sqluv/domain/model"
+	"github.com/rivo/tview"
+)
+
+// localWorkspaceSource is the source name of the files attached to the local workspace.
+const localWorkspaceSource = "Local workspace"
+
+// showDataDiffDialog asks for two queries, each on the local workspace or a connected tab, and the key
+// columns, and shows the row-level differences of the query results in the result table.
+// The differences can be saved with Ctrl-s as CSV/TSV/LTSV/JSON.
+func (t *TUI) showDataDiffDialog(table *model.Table) {
+	sessions := t.connectedSessions()
+	sources := []string{localWorkspaceSource}
+	current := 0
+	for i, s := range sessions {
+		sources = append(sources, s.title)
+		if s == t.currentSession() {
+			current = i + 1
+		}
+	}
+	query := ""
+	if table != nil {
+		query = t.selectTableQuery(table, 0)
+		if t.home.sidebar.isLocalTable(table) {
+			current = 0
+		}
+	}
+	// sessionOf returns the tab of the source, or nil for the local workspace.
+	sessionOf := func(index int) *session {
+		if index == 0 {
+			return nil
+		}
+		return sessions[index-1]
+	}
+	colors := t.theme.GetColors()
+
+	status := tview.NewTextView().SetDynamicColors(false).SetWrap(true)
+	status.SetTitle(" Status ").
+		SetTitleAlign(tview.AlignLeft).
+		SetBorder(true).
+		SetBorderColor(colors.Border).
+		SetBackgroundColor(colors.Background)
+	status.SetTextColor(colors.Foreground)
+
+	form := tview.NewForm()
+	form.AddDropDown("From", sources, current, nil).
+		AddInputField("From Query", query, 0, nil, nil).
+		AddDropDown("To", sources, current, nil).
+		AddInputField("To Query", query, 0, nil, nil).
+		AddInputField("Key Columns", "", 0, nil, nil)
+
+	comparing := false
+	form.AddButton("Compare", func() {
+		if comparing {
+			return
+		}
+		fromIndex, _ := form.GetFormItem(0).(*tview.DropDown).GetCurrentOption()
+		toIndex, _ := form.GetFormItem(2).(*tview.DropDown).GetCurrentOption()
+		fromSQL, err := model.NewSQL(form.GetFormItem(1).(*tview.InputField).GetText())
+		if err != nil {
+			status.SetText("From Query: " + err.Error())
+			return
+		}
+		toSQL, err := model.NewSQL(form.GetFormItem(3).(*tview.InputField).GetText())
+		if err != nil {
+			status.SetText("To Query: " + err.Error())
+			return
+		}
+		keys := []string{}
+		for _, key := range strings.Split(form.GetFormItem(4).(*tview.InputField).GetText(), ",") {
+			if key = strings.TrimSpace(key); key != "" {
+				keys = append(keys, key)
+			}
+		}
+
+		comparing = true
+		status.SetText("Comparing...")
+		go func() {
+			startTime := time.Now()
+			diff, err := t.diffQueryResults(context.Background(),
+				sessionOf(fromIndex), fromSQL, sessionOf(toIndex), toSQL, keys)
+			t.app.QueueUpdateDraw(func() {
+				comparing = false
+				if err != nil {
+					status.SetText("Failed to compare: " + err.Error())
+					return
+				}
+				t.lastExecutionTime = time.Since(startTime).Seconds()
+				t.home.resultTable.updateDiff(diff, t.home.rowStatistics, t.lastExecutionTime)
+				t.latestTable = diff.Table()
+				t.updateRowStatistics(t.latestTable, startTime)
+
+				msg := diff.Summary()
+				if len(diff.FromOnlyColumns) > 0 || len(diff.ToOnlyColumns) > 0 {
+					msg += fmt.Sprintf("\nNot compared: %s (From only), %s (To only)",
+						strings.Join(diff.FromOnlyColumns, ", "), strings.Join(diff.ToOnlyColumns, ", "))
+				}
+				t.app.SetRoot(t.home.flex, true)
+				t.app.SetFocus(t.home.resultTable)
+				t.home.dialog.Show(t.home.flex, " Data Diff ", msg)
+			})
+		}()
+	}).
+		AddButton("Cancel", func() {
+			t.app.SetRoot(t.home.flex, true)
+			t.app.SetFocus(t.home.sidebar)
+		})
+
+	form.SetBorder(true).
+		SetTitle("Compare Data").
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderStyle(tcell.StyleDefault.
+			Background(colors.Background).
+			Foreground(colors.BorderFocus))
+	form.SetButtonActivatedStyle(tcell.StyleDefault.
+		Background(colors.ButtonFocus).
+		Foreground(colors.ButtonTextFocus)).
+		SetButtonStyle(tcell.StyleDefault.
+			Background(colors.Button).
+			Foreground(colors.ButtonText)).
+		SetFieldStyle(tcell.StyleDefault.
+			Background(colors.Background).
+			Foreground(colors.Foreground)).
+		SetBackgroundColor(colors.Background)
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(form, 15, 0, true).
+		AddItem(status, 0, 1, false)
+	t.app.SetRoot(layout, true)
+	t.app.SetFocus(form)
+}
+
+// diffQueryResults runs the queries and compares the results by the key columns.
+// A nil session means the local workspace.
+func (t *TUI) diffQueryResults(
+	ctx context.Context,
+	from *session,
+	fromSQL *model.SQL,
+	to *session,
+	toSQL *model.SQL,
+	keys []string,
+) (*model.DataDiff, error) {
+	fromTable, err := t.queryTable(ctx, from, fromSQL)
+	if err != nil {
+		return nil, fmt.Errorf("From Query: %w", err)
+	}
+	toTable, err := t.queryTable(ctx, to, toSQL)
+	if err != nil {
+		return nil, fmt.Errorf("To Query: %w", err)
+	}
+	return model.DiffTableData(fromTable, toTable, keys)
+}
+
+// queryTable runs the read-only query on the tab, or on the local workspace if s is nil.
+func (t *TUI) queryTable(ctx context.Context, s *session, sql *model.SQL) (*model.Table, error) {
+	dialect := config.NewDialect(config.SQLite3)
+	if s != nil {
+		dialect = config.NewDialect(s.dbmsUsecases.conn.Type)
+	}
+	if !sql.IsReadOnly(dialect.SQLDialect()) {
+		return nil, errors.New("only a query that does not modify data can be compared")
+	}
+	var table *model.Table
+	if s == nil {
+		output, err := t.localUsecases.sqlExecutor.ExecuteSQL(ctx, sql)
+		if err != nil {
+			return nil, err
+		}
+		table = output.Table()
+	} else {
+		output, err := s.dbmsUsecases.queryExecutor.ExecuteQuery(ctx, sql)
+		if err != nil {
+			return nil, err
+		}
+		table = output.Table()
+	}
+	if table == nil {
+		return nil, errors.New("the query returns no result set")
+	}
+	return table, nil
+}
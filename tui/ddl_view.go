@@ -0,0 +1,86 @@
+package tui
+
+import (
+	"context"
+
+	"github.com/atotto/clipboard"
+	"github.com/gdamore/tcell/v2"
+	"github.com/nao1215/sqluv/domain/model"
+	"github.com/rivo/tview"
+)
+
+// ddlView shows the CREATE statement of a table, or other SQL text such as a schema diff. The text can be scrolled and copied to the clipboard.
+type ddlView struct {
+	*tview.Flex
+	text   *tview.TextView
+	status *tview.TextView
+}
+
+// newDDLView creates a new ddlView with the title. onClose is called when the view is closed by ESC or "q".
+func newDDLView(theme *Theme, title, ddl string, onClose func()) *ddlView {
+	colors := theme.GetColors()
+
+	text := tview.NewTextView().
+		SetDynamicColors(false).
+		SetScrollable(true).
+		SetWrap(false).
+		SetText(ddl)
+	text.SetTitle(" " + title + " ").
+		SetTitleAlign(tview.AlignLeft).
+		SetTitleColor(colors.Header).
+		SetBorder(true).
+		SetBorderColor(colors.BorderFocus).
+		SetBackgroundColor(colors.Background)
+	text.SetTextColor(colors.Foreground)
+
+	status := tview.NewTextView().SetText("c: Copy | ESC, q: Close")
+	status.SetTextColor(colors.Foreground).
+		SetBackgroundColor(colors.Background)
+
+	v := &ddlView{
+		Flex: tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(text, 0, 1, true).
+			AddItem(status, 1, 0, false),
+		text:   text,
+		status: status,
+	}
+
+	text.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch {
+		case event.Key() == tcell.KeyEscape, event.Rune() == 'q':
+			onClose()
+			return nil
+		case event.Rune() == 'c':
+			if err := clipboard.WriteAll(ddl); err != nil {
+				v.status.SetText("Failed to copy: " + err.Error())
+				return nil
+			}
+			v.status.SetText("Copied to the clipboard | ESC, q: Close")
+			return nil
+		}
+		return event
+	})
+	return v
+}
+
+// showCreateTableStatement shows the CREATE statement of the table in the DDL view.
+func (t *TUI) showCreateTableStatement(ctx context.Context, table *model.Table) {
+	var ddl string
+	var err error
+	if t.dbmsUsecases.isDBConnected && !t.home.sidebar.isLocalTable(table) {
+		ddl, err = t.dbmsUsecases.stmtGetter.GetCreateTableStatement(ctx, catalogTableName(t.dbmsUsecases.conn.Type, table))
+	} else {
+		ddl, err = t.localUsecases.stmtGetter.GetCreateTableStatement(ctx, table.Name())
+	}
+	if err != nil {
+		t.showError(err)
+		return
+	}
+
+	view := newDDLView(t.theme, "DDL: "+table.QualifiedName(), ddl, func() {
+		t.app.SetRoot(t.home.flex, true)
+		t.app.SetFocus(t.home.sidebar)
+	})
+	t.app.SetRoot(view, true)
+	t.app.SetFocus(view.text)
+}
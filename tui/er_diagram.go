@@ -0,0 +1,143 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/nao1215/sqluv/domain/model"
+	"github.com/rivo/tview"
+)
+
+// showERDiagramDialog asks for the format and the tables, and shows the ER diagram of the connected
+// database or saves it to a file. tableName is the table selected in the sidebar, and may be empty.
+func (t *TUI) showERDiagramDialog(tableName string) {
+	if !t.dbmsUsecases.isDBConnected {
+		t.showError(errors.New("ER diagram needs a database connection"))
+		return
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		cwd = ""
+	}
+	formats := []model.ERDiagramFormat{model.ERDiagramMermaid, model.ERDiagramDOT}
+	colors := t.theme.GetColors()
+
+	status := tview.NewTextView().SetDynamicColors(false).SetWrap(true)
+	status.SetTitle(" Status ").
+		SetTitleAlign(tview.AlignLeft).
+		SetBorder(true).
+		SetBorderColor(colors.Border).
+		SetBackgroundColor(colors.Background)
+	status.SetTextColor(colors.Foreground)
+
+	form := tview.NewForm()
+	pathField := tview.NewInputField().
+		SetLabel("File Path").
+		SetText(filepath.Join(cwd, "er"+model.ERDiagramMermaid.Ext()))
+	form.AddDropDown("Format", []string{"Mermaid", "Graphviz DOT"}, 0, func(_ string, index int) {
+		// Keep the file extension in sync with the format.
+		path := pathField.GetText()
+		for _, f := range formats {
+			path = strings.TrimSuffix(path, f.Ext())
+		}
+		pathField.SetText(path + formats[index].Ext())
+	}).
+		AddInputField("Tables", tableName, 0, nil, nil).
+		AddCheckbox("Column Types", true, nil).
+		AddCheckbox("PK/FK Markers", true, nil).
+		AddFormItem(pathField)
+
+	// options returns the options entered in the form.
+	options := func() model.ERDiagramOptions {
+		index, _ := form.GetFormItem(0).(*tview.DropDown).GetCurrentOption()
+		opts := model.ERDiagramOptions{
+			Format:    formats[index],
+			ShowTypes: form.GetFormItem(2).(*tview.Checkbox).IsChecked(),
+			ShowKeys:  form.GetFormItem(3).(*tview.Checkbox).IsChecked(),
+		}
+		for _, table := range strings.Split(form.GetFormItem(1).(*tview.InputField).GetText(), ",") {
+			if table = strings.TrimSpace(table); table != "" {
+				opts.Tables = append(opts.Tables, table)
+			}
+		}
+		return opts
+	}
+
+	generating := false
+	// generate draws the diagram in the background, and calls onDone with it in the UI goroutine.
+	generate := func(onDone func(diagram string)) {
+		if generating {
+			return
+		}
+		generating = true
+		status.SetText("Generating...")
+		opts := options()
+		go func() {
+			diagram, err := t.dbmsUsecases.erGenerator.GenerateERDiagram(context.Background(), opts)
+			t.app.QueueUpdateDraw(func() {
+				generating = false
+				if err != nil {
+					status.SetText("Failed to generate ER diagram: " + err.Error())
+					return
+				}
+				onDone(diagram)
+			})
+		}()
+	}
+
+	form.AddButton("Show", func() {
+		generate(func(diagram string) {
+			view := newDDLView(t.theme, "ER Diagram", diagram, func() {
+				t.app.SetRoot(t.home.flex, true)
+				t.app.SetFocus(t.home.sidebar)
+			})
+			t.app.SetRoot(view, true)
+			t.app.SetFocus(view.text)
+		})
+	}).
+		AddButton("Save", func() {
+			path := strings.TrimSpace(pathField.GetText())
+			if path == "" {
+				status.SetText("file path is empty")
+				return
+			}
+			generate(func(diagram string) {
+				if err := os.WriteFile(path, []byte(diagram), 0o600); err != nil {
+					status.SetText("Failed to write file: " + err.Error())
+					return
+				}
+				status.SetText("Saved to " + path)
+			})
+		}).
+		AddButton("Cancel", func() {
+			t.app.SetRoot(t.home.flex, true)
+			t.app.SetFocus(t.home.sidebar)
+		})
+
+	form.SetBorder(true).
+		SetTitle("ER Diagram").
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderStyle(tcell.StyleDefault.
+			Background(colors.Background).
+			Foreground(colors.BorderFocus))
+	form.SetButtonActivatedStyle(tcell.StyleDefault.
+		Background(colors.ButtonFocus).
+		Foreground(colors.ButtonTextFocus)).
+		SetButtonStyle(tcell.StyleDefault.
+			Background(colors.Button).
+			Foreground(colors.ButtonText)).
+		SetFieldStyle(tcell.StyleDefault.
+			Background(colors.Background).
+			Foreground(colors.Foreground)).
+		SetBackgroundColor(colors.Background)
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(form, 15, 0, true).
+		AddItem(status, 0, 1, false)
+	t.app.SetRoot(layout, true)
+	t.app.SetFocus(form)
+}
@@ -0,0 +1,143 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/nao1215/sqluv/domain/model"
+	"github.com/rivo/tview"
+)
+
+// importPreviewRows is the number of records shown in the import preview.
+const importPreviewRows = 5
+
+// showImportFileDialog asks for a file and a table, and imports the file into the table in the
+// connected database. "Preview" shows the column mapping and the first records without changing
+// the database. If the table is empty, the file name is used as the table name.
+func (t *TUI) showImportFileDialog(tableName string) {
+	if !t.dbmsUsecases.isDBConnected {
+		t.showError(errors.New("importing a file needs a database connection"))
+		return
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		cwd = ""
+	}
+	colors := t.theme.GetColors()
+
+	preview := tview.NewTextView().
+		SetDynamicColors(false).
+		SetScrollable(true).
+		SetWrap(false)
+	preview.SetTitle(" Preview ").
+		SetTitleAlign(tview.AlignLeft).
+		SetBorder(true).
+		SetBorderColor(colors.Border).
+		SetBackgroundColor(colors.Background)
+	preview.SetTextColor(colors.Foreground)
+
+	form := tview.NewForm()
+	form.AddInputField("File Path", cwd, 0, nil, nil).
+		AddInputField("Table", tableName, 0, nil, nil)
+	plan := func(ctx context.Context) (*model.ImportPlan, error) {
+		f, err := model.NewFile(form.GetFormItem(0).(*tview.InputField).GetText())
+		if err != nil {
+			return nil, fmt.Errorf("failed to create file handle: %w", err)
+		}
+		table, err := t.localUsecases.fileReader.Read(ctx, f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file: %w", err)
+		}
+		name := strings.TrimSpace(form.GetFormItem(1).(*tview.InputField).GetText())
+		if name == "" {
+			name = table.Name()
+		}
+		return t.dbmsUsecases.importer.PlanImport(ctx, table, name, nil)
+	}
+
+	form.AddButton("Preview", func() {
+		p, err := plan(context.Background())
+		if err != nil {
+			preview.SetText(err.Error())
+			return
+		}
+		preview.SetText(importPreviewText(p)).ScrollToBeginning()
+	}).
+		AddButton("Import", func() {
+			ctx := context.Background()
+			p, err := plan(ctx)
+			if err != nil {
+				preview.SetText(err.Error())
+				return
+			}
+			n, err := t.dbmsUsecases.importer.Import(ctx, p)
+			if err != nil {
+				preview.SetText(fmt.Sprintf("failed to import into %s: %s", p.Target, err.Error()))
+				return
+			}
+			if p.CreatesTable() {
+				t.loadDatabaseTables(ctx, t.dbmsUsecases.databaseName)
+			}
+			t.showRowsAffectedInfo(n)
+		}).
+		AddButton("Cancel", func() {
+			t.app.SetRoot(t.home.flex, true)
+			t.app.SetFocus(t.home.sidebar)
+		})
+
+	form.SetBorder(true).
+		SetTitle("Import CSV/TSV/LTSV File into Table").
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderStyle(tcell.StyleDefault.
+			Background(colors.Background).
+			Foreground(colors.BorderFocus))
+	form.SetButtonActivatedStyle(tcell.StyleDefault.
+		Background(colors.ButtonFocus).
+		Foreground(colors.ButtonTextFocus)).
+		SetButtonStyle(tcell.StyleDefault.
+			Background(colors.Button).
+			Foreground(colors.ButtonText)).
+		SetFieldStyle(tcell.StyleDefault.
+			Background(colors.Background).
+			Foreground(colors.Foreground)).
+		SetBackgroundColor(colors.Background)
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(form, 9, 0, true).
+		AddItem(preview, 0, 1, false)
+	t.app.SetRoot(layout, true)
+	t.app.SetFocus(form)
+}
+
+// importPreviewText returns the dry-run result: the table to be created, the column mapping
+// and the first records.
+func importPreviewText(p *model.ImportPlan) string {
+	var b strings.Builder
+	if p.CreatesTable() {
+		fmt.Fprintf(&b, "Table %s does not exist and will be created:\n\n%s;\n\n", p.Target, p.CreateStatement)
+	} else {
+		fmt.Fprintf(&b, "Records will be added to the existing table %s.\n\n", p.Target)
+	}
+
+	b.WriteString("Column mapping:\n")
+	w := tabwriter.NewWriter(&b, 0, 4, 2, ' ', 0)
+	for _, c := range p.Columns {
+		fmt.Fprintf(w, "  %s\t->\t%s\t%s\n", c.Source, c.Target, c.DataType)
+	}
+	w.Flush()
+
+	rows := p.Preview(importPreviewRows)
+	fmt.Fprintf(&b, "\nFirst %d of %d records:\n", len(rows.Records()), len(p.Source.Records()))
+	w = tabwriter.NewWriter(&b, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(w, "  %s\n", strings.Join(rows.Header(), "\t"))
+	for _, record := range rows.Records() {
+		fmt.Fprintf(w, "  %s\n", strings.Join(record, "\t"))
+	}
+	w.Flush()
+	return b.String()
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/gdamore/tcell/v2"
+	"github.com/nao1215/sqluv/config"
 	"github.com/rivo/tview"
 )
 
@@ -15,6 +16,10 @@ type footer struct {
 	shortcuts    map[string]string // key=shortcut, value=description
 	theme        *Theme
 	searchActive bool
+	// environment is the environment tag of the connection. The footer has the background of its color.
+	environment config.Environment
+	// connectionStatus is the status of the connection to the database, which is updated by the health check.
+	connectionStatus config.ConnectionStatus
 }
 
 // newFooter creates a new footer with keyboard shortcuts
@@ -87,6 +92,15 @@ func (f *footer) update() {
 		// Use the header color from theme for shortcuts
 		text += key + ": " + f.shortcuts[key]
 	}
+	switch f.connectionStatus {
+	case config.ConnectionConnected:
+		text = "● " + f.connectionStatus.String() + " | " + text
+	case config.ConnectionDisconnected:
+		text = "✕ " + f.connectionStatus.String() + " (reconnecting) | " + text
+	}
+	if _, ok := environmentColor(f.environment); ok {
+		text = strings.ToUpper(string(f.environment)) + " | " + text
+	}
 	text += strings.Repeat(" ", 100) // workaround for the footer background color
 	f.SetLabel(text)
 }
@@ -99,6 +113,10 @@ func (f *footer) setDefaulShortcut() {
 	f.addShortcut("Ctrl-t", "Theme")
 	f.addShortcut("Ctrl-h", "History")
 	f.addShortcut("Ctrl-e", "Exec Query")
+	f.addShortcut("Ctrl-p", "Explain")
+	f.addShortcut("Ctrl-Space", "Complete")
+	f.addShortcut("Ctrl-n", "New Tab")
+	f.addShortcut("Ctrl-o", "Attach File")
 	f.update()
 }
 
@@ -106,12 +124,32 @@ func (f *footer) setDefaulShortcut() {
 func (f *footer) setSidebarShortcut() {
 	f.clearShortcuts()
 	f.addShortcut("/", "Search")
-	f.addShortcut("Enter", "Show DDL")
-	f.addShortcut("Space", "Show/Hide Columns")
+	f.addShortcut("Enter", "Columns")
+	f.addShortcut("d", "Show DDL")
+	f.addShortcut("i", "Import File")
+	f.addShortcut("c", "Copy Data")
+	f.addShortcut("s", "Schema Diff")
+	f.addShortcut("r", "Data Diff")
+	f.addShortcut("e", "ER Diagram")
+	f.addShortcut("Space", "Expand/Collapse")
 	f.addShortcut("ESC", "Clear search")
 	f.update()
 }
 
+// setResultTableShortcut changes the shortcuts to the result table. editing is true while the rows are edited.
+func (f *footer) setResultTableShortcut(editing bool) {
+	f.clearShortcuts()
+	f.addShortcut("TAB,F1-F3", "Change focus")
+	f.addShortcut("e", "Edit Cell")
+	f.addShortcut("a", "Add Row")
+	f.addShortcut("x", "Delete Row")
+	if editing {
+		f.addShortcut("w", "Review & Write")
+		f.addShortcut("ESC", "Discard Edits")
+	}
+	f.update()
+}
+
 func (f *footer) applyTheme(theme *Theme) {
 	f.theme = theme
 	colors := theme.GetColors()
@@ -121,6 +159,11 @@ func (f *footer) applyTheme(theme *Theme) {
 	f.SetLabelStyle(tcell.StyleDefault.
 		Background(theme.GetColors().Background).
 		Foreground(theme.GetColors().Foreground))
+	if color, ok := environmentColor(f.environment); ok {
+		f.SetLabelStyle(tcell.StyleDefault.
+			Background(color).
+			Foreground(tcell.ColorWhite))
+	}
 	f.SetPlaceholderStyle(tcell.StyleDefault.
 		Background(theme.GetColors().Background).
 		Foreground(theme.GetColors().Foreground))
@@ -133,6 +176,20 @@ func (f *footer) applyTheme(theme *Theme) {
 	}
 }
 
+// setEnvironment sets the environment tag of the connection, and colors the footer by it.
+func (f *footer) setEnvironment(env config.Environment) {
+	f.environment = env
+	f.applyTheme(f.theme)
+}
+
+// setConnectionStatus shows the status of the connection to the database.
+func (f *footer) setConnectionStatus(status config.ConnectionStatus) {
+	f.connectionStatus = status
+	if !f.searchActive {
+		f.update()
+	}
+}
+
 func (f *footer) isActiveSearch() bool {
 	return f.searchActive
 }
@@ -1,6 +1,12 @@
 package tui
 
-import "github.com/rivo/tview"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nao1215/sqluv/config"
+	"github.com/rivo/tview"
+)
 
 // home represents the home window.
 type home struct {
@@ -13,10 +19,12 @@ type home struct {
 	footer        *footer
 	dialog        *dialog
 	rowStatistics *rowStatistics
+	// environment is the environment tag of the connection. The window has a border of its color.
+	environment config.Environment
 }
 
-// newHome creates a new home window.
-func newHome(app *tview.Application, theme *Theme) *home {
+// newHome creates a new home window. The tab bar is shared by all tabs and shown at the top.
+func newHome(app *tview.Application, theme *Theme, tabBar *tabBar) *home {
 	sidebarComponent := newSidebar(theme)
 	textArea := newQueryTextArea(theme)
 	executeButton := newExecuteButton(theme)
@@ -45,6 +53,7 @@ func newHome(app *tview.Application, theme *Theme) *home {
 	// Create the main layout with content at top and footer at bottom
 	mainFlex := tview.NewFlex().
 		SetDirection(tview.FlexRow).
+		AddItem(tabBar, 1, 0, false).
 		AddItem(mainContent, 0, 1, false).
 		AddItem(footerComponent, 1, 0, false)
 
@@ -76,4 +85,25 @@ func (h *home) applyTheme(theme *Theme) {
 	h.footer.applyTheme(theme)
 	h.rowStatistics.applyTheme(theme)
 	h.dialog.applyTheme(theme)
+	h.applyEnvironment()
+}
+
+// setEnvironment sets the environment tag of the connection, and colors the border and the footer by it.
+func (h *home) setEnvironment(env config.Environment) {
+	h.environment = env
+	h.footer.setEnvironment(env)
+	h.applyEnvironment()
+}
+
+// applyEnvironment draws the border of the environment color around the window.
+func (h *home) applyEnvironment() {
+	color, ok := environmentColor(h.environment)
+	if !ok {
+		h.flex.SetBorder(false).SetTitle("")
+		return
+	}
+	h.flex.SetBorder(true).
+		SetBorderColor(color).
+		SetTitleColor(color).
+		SetTitle(fmt.Sprintf(" %s ", strings.ToUpper(string(h.environment))))
 }
@@ -0,0 +1,71 @@
+package tui
+
+import (
+	"errors"
+
+	"github.com/nao1215/sqluv/config"
+	"github.com/rivo/tview"
+)
+
+// showUnlockForm asks for the master passphrase that decrypts the saved passwords.
+// onUnlock is called after the passphrase is entered, and onCancel is called when the form is canceled.
+func (cm *connectionModal) showUnlockForm(onUnlock, onCancel func()) {
+	form := tview.NewForm()
+	form.AddPasswordField("Master Passphrase", "", 0, '*', nil)
+
+	form.AddButton("Unlock", func() {
+		passphrase := form.GetFormItem(0).(*tview.InputField).GetText()
+		if err := cm.configMgr.Unlock(passphrase); err != nil {
+			cm.showError(err.Error())
+			return
+		}
+		onUnlock()
+	})
+	form.AddButton("Cancel", onCancel)
+
+	form.SetBorder(true).SetTitle("Unlock Saved Passwords")
+	cm.applyFormTheme(form)
+	cm.app.SetRoot(form, true)
+}
+
+// showPassphraseForm sets or changes the master passphrase. The saved passwords are encrypted with
+// the new passphrase, so the current one is asked first if it is not entered yet.
+// onDone is called after the passphrase is set, and onCancel is called when the form is canceled.
+func (cm *connectionModal) showPassphraseForm(onDone, onCancel func()) {
+	if cm.configMgr.HasPassphrase() && cm.configMgr.IsLocked() {
+		cm.showUnlockForm(func() { cm.showPassphraseForm(onDone, onCancel) }, onCancel)
+		return
+	}
+
+	title := "Set Master Passphrase"
+	if cm.configMgr.HasPassphrase() {
+		title = "Change Master Passphrase"
+	}
+
+	form := tview.NewForm()
+	form.AddPasswordField("New Passphrase", "", 0, '*', nil)
+	form.AddPasswordField("Confirm Passphrase", "", 0, '*', nil)
+
+	form.AddButton("Save", func() {
+		passphrase := form.GetFormItem(0).(*tview.InputField).GetText()
+		confirm := form.GetFormItem(1).(*tview.InputField).GetText()
+		if passphrase != confirm {
+			cm.showError("The passphrases do not match")
+			return
+		}
+		if err := cm.configMgr.SetPassphrase(passphrase); err != nil {
+			if errors.Is(err, config.ErrLocked) {
+				cm.showUnlockForm(func() { cm.showPassphraseForm(onDone, onCancel) }, onCancel)
+				return
+			}
+			cm.showError(err.Error())
+			return
+		}
+		onDone()
+	})
+	form.AddButton("Cancel", onCancel)
+
+	form.SetBorder(true).SetTitle(title)
+	cm.applyFormTheme(form)
+	cm.app.SetRoot(form, true)
+}
@@ -0,0 +1,135 @@
+package tui
+
+import (
+	"context"
+	"errors"
+
+	"github.com/atotto/clipboard"
+	"github.com/gdamore/tcell/v2"
+	"github.com/nao1215/sqluv/config"
+	"github.com/nao1215/sqluv/domain/model"
+	"github.com/rivo/tview"
+)
+
+// planView shows a query plan as a tree. A node is expanded or collapsed by Enter.
+// Full table scans are red and the expensive nodes are orange.
+type planView struct {
+	*tview.Flex
+	tree   *tview.TreeView
+	status *tview.TextView
+}
+
+// planViewHelp is the key help of the plan view.
+const planViewHelp = "Enter: Expand/Collapse | c: Copy raw plan | a: Toggle ANALYZE (PostgreSQL) | ESC, q: Close"
+
+// newPlanView creates a new planView. onAnalyze is called when ANALYZE is toggled,
+// and onClose is called when the view is closed by ESC or "q".
+func newPlanView(theme *Theme, plan *model.QueryPlan, analyzed bool, onAnalyze, onClose func()) *planView {
+	colors := theme.GetColors()
+
+	root := newPlanTreeNode(plan.Root, colors)
+	tree := tview.NewTreeView().
+		SetRoot(root).
+		SetCurrentNode(root)
+	title := " Query Plan "
+	if analyzed {
+		title = " Query Plan (ANALYZE) "
+	}
+	tree.SetTitle(title).
+		SetTitleAlign(tview.AlignLeft).
+		SetTitleColor(colors.Header).
+		SetBorder(true).
+		SetBorderColor(colors.BorderFocus).
+		SetBackgroundColor(colors.Background)
+	tree.SetGraphicsColor(colors.Border)
+	tree.SetSelectedFunc(func(node *tview.TreeNode) {
+		node.SetExpanded(!node.IsExpanded())
+	})
+
+	status := tview.NewTextView().SetText(planViewHelp)
+	status.SetTextColor(colors.Foreground).
+		SetBackgroundColor(colors.Background)
+
+	v := &planView{
+		Flex: tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(tree, 0, 1, true).
+			AddItem(status, 1, 0, false),
+		tree:   tree,
+		status: status,
+	}
+
+	tree.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch {
+		case event.Key() == tcell.KeyEscape, event.Rune() == 'q':
+			onClose()
+			return nil
+		case event.Rune() == 'a':
+			onAnalyze()
+			return nil
+		case event.Rune() == 'c':
+			if err := clipboard.WriteAll(plan.Raw); err != nil {
+				v.status.SetText("Failed to copy: " + err.Error())
+				return nil
+			}
+			v.status.SetText("Copied the raw plan to the clipboard | " + planViewHelp)
+			return nil
+		}
+		return event
+	})
+	return v
+}
+
+// newPlanTreeNode creates the tree node of the plan node and its children.
+func newPlanTreeNode(n *model.PlanNode, colors ThemeColors) *tview.TreeNode {
+	text := n.String()
+	color := colors.Foreground
+	if n.Expensive {
+		text += " [expensive]"
+		color = tcell.ColorOrange
+	}
+	if n.FullScan {
+		text += " [full scan]"
+		color = tcell.ColorRed
+	}
+	node := tview.NewTreeNode(text).
+		SetReference(n).
+		SetColor(color).
+		SetSelectable(true).
+		SetExpanded(true)
+	for _, c := range n.Children {
+		node.AddChild(newPlanTreeNode(c, colors))
+	}
+	return node
+}
+
+// showQueryPlan shows the plan of the query in the query editor.
+func (t *TUI) showQueryPlan(ctx context.Context, analyze bool) {
+	if !t.dbmsUsecases.isDBConnected {
+		t.showError(errors.New("query plan needs a database connection"))
+		return
+	}
+	sql, err := model.NewSQL(t.home.queryTextArea.GetText())
+	if err != nil {
+		t.showError(err)
+		return
+	}
+	// Only PostgreSQL measures the actual rows and time.
+	analyze = analyze && t.dbmsUsecases.conn.Type == config.PostgreSQL
+
+	plan, err := t.dbmsUsecases.planGetter.GetQueryPlan(ctx, sql, analyze)
+	if err != nil {
+		t.showError(err)
+		return
+	}
+
+	view := newPlanView(t.theme, plan, analyze,
+		func() {
+			t.showQueryPlan(ctx, !analyze)
+		},
+		func() {
+			t.app.SetRoot(t.home.flex, true)
+			t.app.SetFocus(t.home.queryTextArea)
+		})
+	t.app.SetRoot(view, true)
+	t.app.SetFocus(view.tree)
+}
@@ -12,6 +12,16 @@ type queryResultTable struct {
 	theme        *Theme
 	columnOffset int // new field to track the starting column index
 	maxColumns   int // new field to define how many columns to display
+	// cellColor returns the text color of a data cell (record index, column index) if it is highlighted.
+	// It is set while a data diff or edited rows are shown.
+	cellColor func(row, col int) (tcell.Color, bool)
+	// result is the table shown by update, and query is its query if it is read from the connected database.
+	result *model.Table
+	query  *model.SQL
+	// edit holds the edits of the result. It is nil if the result is not being edited.
+	edit          *model.TableEdit
+	stats         *rowStatistics
+	executionTime float64
 }
 
 // newQueryResultTable creates a new query result table.
@@ -67,7 +77,104 @@ func (q *queryResultTable) clear() {
 
 // update updates the table with model.Table data
 func (q *queryResultTable) update(table *model.Table, stats *rowStatistics, executionTime float64) {
+	q.cellColor = nil
+	q.result = table
+	q.query = nil
+	q.edit = nil
+	q.render(table, stats, executionTime)
+}
+
+// startEdit shows the rows with the edits. Deleted rows, inserted rows and the entered values
+// have the removed, added and changed colors of the diff.
+func (q *queryResultTable) startEdit(edit *model.TableEdit) {
+	q.edit = edit
+	q.cellColor = func(row, col int) (tcell.Color, bool) {
+		colors := q.theme.GetColors()
+		switch {
+		case edit.IsDeleted(row):
+			return colors.DiffRemoved, true
+		case edit.IsInserted(row):
+			return colors.DiffAdded, true
+		case edit.IsChanged(row, col):
+			return colors.DiffChanged, true
+		default:
+			return 0, false
+		}
+	}
+	q.renderEdit()
+}
+
+// renderEdit draws the rows with the edits, keeping the selected cell.
+func (q *queryResultTable) renderEdit() {
+	row, col := q.GetSelection()
+	q.render(q.edit.Table(), q.stats, q.executionTime)
+	q.Select(row, col)
+}
+
+// stopEdit discards the edits and shows the result again.
+func (q *queryResultTable) stopEdit() {
+	q.edit = nil
+	q.cellColor = nil
+	q.render(q.result, q.stats, q.executionTime)
+}
+
+// selectedCell returns the record index and the column index of the selected cell.
+func (q *queryResultTable) selectedCell() (int, int, bool) {
+	row, col := q.GetSelection()
+	if row <= 0 {
+		return 0, 0, false
+	}
+	return row - 1, q.columnOffset + col, true
+}
+
+// updateDiff shows the data diff with the diff colors of the theme. For a changed row, the cells that
+// differ have the removed color in the "changed from" row and the added color in the "changed to" row.
+func (q *queryResultTable) updateDiff(diff *model.DataDiff, stats *rowStatistics, executionTime float64) {
+	type line struct {
+		row  *model.RowDiff
+		from bool
+	}
+	q.result = diff.Table()
+	q.query = nil
+	q.edit = nil
+	lines := make([]line, 0, len(diff.Rows))
+	for _, r := range diff.Rows {
+		if r.Status == model.RowChanged {
+			lines = append(lines, line{row: r, from: true}, line{row: r})
+			continue
+		}
+		lines = append(lines, line{row: r, from: r.Status == model.RowRemoved})
+	}
+
+	q.cellColor = func(row, col int) (tcell.Color, bool) {
+		if row >= len(lines) {
+			return 0, false
+		}
+		l := lines[row]
+		colors := q.theme.GetColors()
+		color := colors.DiffAdded
+		if l.from {
+			color = colors.DiffRemoved
+		}
+		switch {
+		case l.row.Status != model.RowChanged:
+			return color, true
+		case col == 0:
+			return colors.DiffChanged, true
+		case l.row.IsChangedColumn(col - 1):
+			return color, true
+		default:
+			return 0, false
+		}
+	}
+	q.render(q.result, stats, executionTime)
+}
+
+// render draws the table. The columns from columnOffset are shown.
+func (q *queryResultTable) render(table *model.Table, stats *rowStatistics, executionTime float64) {
 	q.Clear()
+	q.stats = stats
+	q.executionTime = executionTime
 	colors := q.theme.GetColors()
 	headers := table.Header()
 	totalCols := len(headers)
@@ -108,9 +215,15 @@ func (q *queryResultTable) update(table *model.Table, stats *rowStatistics, exec
 			rEnd = len(row)
 		}
 		for colIdx, cell := range row[q.columnOffset:rEnd] {
+			textColor := colors.Foreground
+			if q.cellColor != nil {
+				if c, ok := q.cellColor(rowIdx, q.columnOffset+colIdx); ok {
+					textColor = c
+				}
+			}
 			q.SetCell(rowIdx+1, colIdx,
 				tview.NewTableCell(cell).
-					SetTextColor(colors.Foreground).
+					SetTextColor(textColor).
 					SetAlign(tview.AlignLeft).
 					SetMaxWidth(q.calcMaxWidth(table)).
 					SetExpansion(1))
@@ -133,7 +246,7 @@ func (q *queryResultTable) setHorizontalScrollHandler(totalCols int, table *mode
 			// shift the columns only once.
 			if selCol == 0 && q.columnOffset > 0 {
 				q.columnOffset--
-				q.update(table, stats, executionTime)
+				q.render(table, stats, executionTime)
 				return nil
 			}
 		case tcell.KeyRight:
@@ -141,7 +254,7 @@ func (q *queryResultTable) setHorizontalScrollHandler(totalCols int, table *mode
 			// shift the viewport.
 			if selCol == q.maxColumns-1 && q.columnOffset+q.maxColumns < totalCols {
 				q.columnOffset++
-				q.update(table, stats, executionTime)
+				q.render(table, stats, executionTime)
 				return nil
 			}
 		}
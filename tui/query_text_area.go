@@ -3,6 +3,8 @@ package tui
 import (
 	"github.com/atotto/clipboard"
 	"github.com/gdamore/tcell/v2"
+	"github.com/nao1215/sqluv/config"
+	"github.com/nao1215/sqluv/domain/model"
 	"github.com/rivo/tview"
 )
 
@@ -10,6 +12,10 @@ import (
 type queryTextArea struct {
 	*tview.TextArea
 	theme *Theme
+	// keywords are the keywords of the SQL dialect that are highlighted.
+	keywords []string
+	// sqlDialect is the rules of the string literals of the SQL dialect.
+	sqlDialect model.SQLDialect
 }
 
 // newQueryTextArea creates a new query input field.
@@ -63,13 +69,22 @@ func newQueryTextArea(theme *Theme) *queryTextArea {
 	})
 
 	q := &queryTextArea{
-		TextArea: textArea,
-		theme:    theme,
+		TextArea:   textArea,
+		theme:      theme,
+		keywords:   config.NewDialect(config.SQLite3).Keywords(),
+		sqlDialect: config.NewDialect(config.SQLite3).SQLDialect(),
 	}
 	q.applyTheme(theme)
 	return q
 }
 
+// setDialect changes the SQL dialect of the syntax highlighting to the one of the connection.
+// The local workspace is SQLite3.
+func (q *queryTextArea) setDialect(dialect config.Dialect) {
+	q.keywords = dialect.Keywords()
+	q.sqlDialect = dialect.SQLDialect()
+}
+
 func (q *queryTextArea) applyTheme(theme *Theme) {
 	q.theme = theme
 	colors := theme.GetColors()
@@ -0,0 +1,108 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/nao1215/sqluv/infrastructure/persistence"
+	"github.com/nao1215/sqluv/interactor"
+	"github.com/rivo/tview"
+)
+
+// showSchemaDiffDialog asks for two connected tabs, and shows the schema differences and
+// the ALTER statements that change the schema of "From" to the schema of "To".
+func (t *TUI) showSchemaDiffDialog() {
+	sessions := t.connectedSessions()
+	if len(sessions) == 0 {
+		t.showError(errors.New("comparing schemas needs a database connection"))
+		return
+	}
+	titles := make([]string, 0, len(sessions))
+	current := 0
+	for i, s := range sessions {
+		titles = append(titles, s.title)
+		if s == t.currentSession() {
+			current = i
+		}
+	}
+	colors := t.theme.GetColors()
+
+	status := tview.NewTextView().SetDynamicColors(false).SetWrap(true)
+	status.SetTitle(" Status ").
+		SetTitleAlign(tview.AlignLeft).
+		SetBorder(true).
+		SetBorderColor(colors.Border).
+		SetBackgroundColor(colors.Background)
+	status.SetTextColor(colors.Foreground)
+
+	form := tview.NewForm()
+	form.AddDropDown("From", titles, current, nil).
+		AddDropDown("To", titles, current, nil)
+
+	comparing := false
+	form.AddButton("Compare", func() {
+		if comparing {
+			return
+		}
+		fromIndex, _ := form.GetFormItem(0).(*tview.DropDown).GetCurrentOption()
+		toIndex, _ := form.GetFormItem(1).(*tview.DropDown).GetCurrentOption()
+		from, to := sessions[fromIndex], sessions[toIndex]
+
+		comparing = true
+		status.SetText("Comparing...")
+		go func() {
+			comparer := interactor.NewSchemaComparer(
+				persistence.NewTableSchemasGetter(from.dbmsUsecases.db, &from.dbmsUsecases.conn),
+				persistence.NewTableSchemasGetter(to.dbmsUsecases.db, &to.dbmsUsecases.conn),
+				persistence.NewAlterStatementsGenerator(&from.dbmsUsecases.conn),
+			)
+			diff, stmts, err := comparer.CompareSchemas(context.Background())
+			t.app.QueueUpdateDraw(func() {
+				comparing = false
+				if err != nil {
+					status.SetText("Failed to compare schemas: " + err.Error())
+					return
+				}
+				text := fmt.Sprintf("-- Schema diff: %s -> %s\n%s", from.title, to.title, diff.Comment())
+				if stmts != "" {
+					text += "\n" + stmts + "\n"
+				}
+				view := newDDLView(t.theme, "Schema Diff: "+from.title+" -> "+to.title, text, func() {
+					t.app.SetRoot(t.home.flex, true)
+					t.app.SetFocus(t.home.sidebar)
+				})
+				t.app.SetRoot(view, true)
+				t.app.SetFocus(view.text)
+			})
+		}()
+	}).
+		AddButton("Cancel", func() {
+			t.app.SetRoot(t.home.flex, true)
+			t.app.SetFocus(t.home.sidebar)
+		})
+
+	form.SetBorder(true).
+		SetTitle("Compare Schemas").
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderStyle(tcell.StyleDefault.
+			Background(colors.Background).
+			Foreground(colors.BorderFocus))
+	form.SetButtonActivatedStyle(tcell.StyleDefault.
+		Background(colors.ButtonFocus).
+		Foreground(colors.ButtonTextFocus)).
+		SetButtonStyle(tcell.StyleDefault.
+			Background(colors.Button).
+			Foreground(colors.ButtonText)).
+		SetFieldStyle(tcell.StyleDefault.
+			Background(colors.Background).
+			Foreground(colors.Foreground)).
+		SetBackgroundColor(colors.Background)
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(form, 9, 0, true).
+		AddItem(status, 0, 1, false)
+	t.app.SetRoot(layout, true)
+	t.app.SetFocus(form)
+}
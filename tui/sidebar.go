@@ -1,20 +1,53 @@
 package tui
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/gdamore/tcell/v2"
 	"github.com/lithammer/fuzzysearch/fuzzy"
+	"github.com/nao1215/sqluv/config"
 	"github.com/nao1215/sqluv/domain/model"
+	"github.com/nao1215/sqluv/usecase"
 	"github.com/rivo/tview"
 )
 
 // The sidebar displays tree representing either database or local files information.
 // At the top of each tree, the database name or the fixed string "local" is displayed.
 // The trees show tables associated with the database or files read from the local system.
+// When connected to a database, the tree also shows views, materialized views, sequences
+// and functions, and each table shows its columns, indexes, foreign keys and triggers.
+// If the server has schemas (databases in MySQL and SQL Server, users in Oracle), they are listed under
+// the database node, and the objects are shown under the current schema.
 type sidebar struct {
 	*tview.TreeView
-	theme     *Theme
-	allTables []*model.Table
-	dbName    string
+	theme         *Theme
+	allTables     []*model.Table
+	dbName        string
+	loader        *schemaLoader // nil for local files
+	schemas       []string
+	currentSchema string
+	onSwitch      func(schema string)
+	localTables   []*model.Table // tables in the local workspace shown below the database tables
+}
+
+// schemaLoader holds the usecases that fetch database objects shown in the sidebar on demand.
+type schemaLoader struct {
+	dbmsType          config.DBMSType
+	objectsGetter     usecase.ObjectsGetter
+	indexesGetter     usecase.IndexesGetter
+	foreignKeysGetter usecase.ForeignKeysGetter
+	triggersGetter    usecase.TriggersGetter
+}
+
+// catalogTableName returns the name of the table that is passed to the getters of its indexes, foreign keys,
+// triggers and DDL. SQL Server lists the tables of all schemas, so the name is qualified with the schema
+// and quoted for OBJECT_ID. The other DBMS look up the table in the current schema by its name.
+func catalogTableName(dbmsType config.DBMSType, table *model.Table) string {
+	if dbmsType != config.SQLServer || table.Schema() == "" {
+		return table.Name()
+	}
+	return config.NewDialect(dbmsType).QualifiedName(table.Schema(), table.Name())
 }
 
 // newSidebar creates a new sidebar.
@@ -53,6 +86,34 @@ func (s *sidebar) update(tables []*model.Table, dbName string) {
 	s.updateTables(tables, dbName)
 }
 
+// setSchemaLoader sets the loader of database objects. If loader is nil, the sidebar shows only tables.
+func (s *sidebar) setSchemaLoader(loader *schemaLoader) {
+	s.loader = loader
+}
+
+// setLocalTables sets the tables in the local workspace that are shown below the database tables.
+func (s *sidebar) setLocalTables(tables []*model.Table) {
+	s.localTables = tables
+}
+
+// isLocalTable returns true if the table is in the local workspace shown below the database tables.
+func (s *sidebar) isLocalTable(table *model.Table) bool {
+	for _, local := range s.localTables {
+		if local == table {
+			return true
+		}
+	}
+	return false
+}
+
+// setSchemas sets the schemas listed under the database node.
+// onSwitch is called when a schema other than current is selected.
+func (s *sidebar) setSchemas(schemas []string, current string, onSwitch func(schema string)) {
+	s.schemas = schemas
+	s.currentSchema = current
+	s.onSwitch = onSwitch
+}
+
 func (s *sidebar) updateTables(tables []*model.Table, dbName string) {
 	root := s.GetRoot()
 	if root == nil {
@@ -74,6 +135,14 @@ func (s *sidebar) updateTables(tables []*model.Table, dbName string) {
 			Foreground(colors.Foreground))
 	root.AddChild(dbNode)
 
+	// Local files have only tables, so they are shown directly under the database node.
+	tablesNode := dbNode
+	objectsNode := s.addSchemaNodes(dbNode)
+	if s.loader != nil {
+		tablesNode = tview.NewTreeNode(fmt.Sprintf("Tables (%d)", len(tables))).SetSelectable(true)
+		objectsNode.AddChild(tablesNode)
+	}
+
 	seen := make(map[string]bool)
 	// Add tables under the database node.
 	for _, table := range tables {
@@ -83,38 +152,196 @@ func (s *sidebar) updateTables(tables []*model.Table, dbName string) {
 		}
 		seen[table.Name()] = true
 
-		// Capture table in local variable for closure.
-		tbl := table
-		tableNode := tview.NewTreeNode("▷ " + tbl.Name()).
-			SetSelectable(true).
-			SetReference(tbl).
-			SetTextStyle(tcell.StyleDefault.
-				Background(colors.Background).
-				Foreground(colors.Foreground))
-
-		// When a table node is selected, toggle its column names.
-		tableNode.SetSelectedFunc(func() {
-			if len(tableNode.GetChildren()) == 0 {
-				for _, col := range tbl.Header() {
-					colNode := tview.NewTreeNode(col).
-						SetSelectable(false).
-						SetTextStyle(tcell.StyleDefault.
-							Background(colors.Background).
-							Foreground(colors.Foreground))
-					tableNode.AddChild(colNode)
-				}
-			} else {
-				tableNode.ClearChildren()
+		if s.loader != nil {
+			tableNode := tview.NewTreeNode("▷ " + table.Name()).
+				SetSelectable(true).
+				SetReference(table)
+			s.addTableDetailNodes(tableNode, table)
+			tablesNode.AddChild(tableNode)
+			continue
+		}
+		dbNode.AddChild(s.newLocalTableNode(table))
+	}
+
+	if s.loader != nil {
+		for _, objectType := range model.ObjectTypes() {
+			objectsNode.AddChild(s.newObjectsNode(objectType))
+		}
+		if len(s.localTables) > 0 {
+			localNode := tview.NewTreeNode("local").SetSelectable(true)
+			for _, table := range s.localTables {
+				localNode.AddChild(s.newLocalTableNode(table))
 			}
-			applyThemeToTreeNodes(tableNode, colors)
-			s.SetCurrentNode(tableNode)
-		})
-		dbNode.AddChild(tableNode)
+			root.AddChild(localNode)
+		}
 	}
 	s.SetCurrentNode(root)
 	applyThemeToTreeNodes(root, colors)
 }
 
+// newLocalTableNode returns the node of a table in the local workspace.
+// When the node is selected, its column names are toggled.
+func (s *sidebar) newLocalTableNode(table *model.Table) *tview.TreeNode {
+	colors := s.theme.GetColors()
+	tableNode := tview.NewTreeNode("▷ " + table.Name()).
+		SetSelectable(true).
+		SetReference(table).
+		SetTextStyle(tcell.StyleDefault.
+			Background(colors.Background).
+			Foreground(colors.Foreground))
+
+	tableNode.SetSelectedFunc(func() {
+		if len(tableNode.GetChildren()) == 0 {
+			for _, col := range table.Header() {
+				colNode := tview.NewTreeNode(col).
+					SetSelectable(false).
+					SetTextStyle(tcell.StyleDefault.
+						Background(colors.Background).
+						Foreground(colors.Foreground))
+				tableNode.AddChild(colNode)
+			}
+		} else {
+			tableNode.ClearChildren()
+		}
+		applyThemeToTreeNodes(tableNode, colors)
+		s.SetCurrentNode(tableNode)
+	})
+	return tableNode
+}
+
+// addSchemaNodes adds the schema nodes to the database node and returns the node that holds
+// the objects of the current schema. Without schemas, it returns the database node itself.
+func (s *sidebar) addSchemaNodes(dbNode *tview.TreeNode) *tview.TreeNode {
+	if s.loader == nil || len(s.schemas) == 0 {
+		return dbNode
+	}
+
+	current := dbNode
+	for _, schema := range s.schemas {
+		if schema == s.currentSchema {
+			current = tview.NewTreeNode("● " + schema).SetSelectable(true)
+			current.SetSelectedFunc(func() {
+				current.SetExpanded(!current.IsExpanded())
+			})
+			dbNode.AddChild(current)
+			continue
+		}
+
+		name := schema
+		node := tview.NewTreeNode("○ " + name).SetSelectable(true)
+		node.SetSelectedFunc(func() {
+			if s.onSwitch != nil {
+				s.onSwitch(name)
+			}
+		})
+		dbNode.AddChild(node)
+	}
+	return current
+}
+
+// addTableDetailNodes adds Columns, Indexes, Foreign Keys and Triggers nodes to the collapsed table node.
+// Indexes, foreign keys and triggers are fetched when their node is selected for the first time.
+func (s *sidebar) addTableDetailNodes(tableNode *tview.TreeNode, table *model.Table) {
+	columnsNode := tview.NewTreeNode(fmt.Sprintf("Columns (%d)", len(table.Header()))).SetSelectable(true)
+	for _, col := range table.Header() {
+		columnsNode.AddChild(tview.NewTreeNode(col).SetSelectable(false))
+	}
+	columnsNode.SetSelectedFunc(func() {
+		columnsNode.SetExpanded(!columnsNode.IsExpanded())
+	})
+
+	indexesNode := s.newLazyNode("Indexes", func(ctx context.Context) ([]*tview.TreeNode, error) {
+		indexes, err := s.loader.indexesGetter.GetIndexes(ctx, catalogTableName(s.loader.dbmsType, table))
+		if err != nil {
+			return nil, err
+		}
+		nodes := make([]*tview.TreeNode, 0, len(indexes))
+		for _, index := range indexes {
+			nodes = append(nodes, tview.NewTreeNode(index.String()).SetReference(index).SetSelectable(true))
+		}
+		return nodes, nil
+	})
+	foreignKeysNode := s.newLazyNode("Foreign Keys", func(ctx context.Context) ([]*tview.TreeNode, error) {
+		foreignKeys, err := s.loader.foreignKeysGetter.GetForeignKeys(ctx, catalogTableName(s.loader.dbmsType, table))
+		if err != nil {
+			return nil, err
+		}
+		nodes := make([]*tview.TreeNode, 0, len(foreignKeys))
+		for _, fk := range foreignKeys {
+			nodes = append(nodes, tview.NewTreeNode(fk.String()).SetReference(fk).SetSelectable(true))
+		}
+		return nodes, nil
+	})
+	triggersNode := s.newLazyNode("Triggers", func(ctx context.Context) ([]*tview.TreeNode, error) {
+		triggers, err := s.loader.triggersGetter.GetTriggers(ctx, catalogTableName(s.loader.dbmsType, table))
+		if err != nil {
+			return nil, err
+		}
+		nodes := make([]*tview.TreeNode, 0, len(triggers))
+		for _, trigger := range triggers {
+			nodes = append(nodes, tview.NewTreeNode(trigger.String()).SetReference(trigger).SetSelectable(true))
+		}
+		return nodes, nil
+	})
+
+	tableNode.SetChildren([]*tview.TreeNode{columnsNode, indexesNode, foreignKeysNode, triggersNode})
+	tableNode.SetExpanded(false)
+	// When a table node is selected, toggle its details.
+	tableNode.SetSelectedFunc(func() {
+		tableNode.SetExpanded(!tableNode.IsExpanded())
+		s.SetCurrentNode(tableNode)
+	})
+}
+
+// newObjectsNode returns the node that lists database objects of the given type.
+// Views and materialized views refer to *model.Table, so they can be queried like tables.
+func (s *sidebar) newObjectsNode(objectType model.ObjectType) *tview.TreeNode {
+	return s.newLazyNode(string(objectType), func(ctx context.Context) ([]*tview.TreeNode, error) {
+		names, err := s.loader.objectsGetter.GetObjects(ctx, objectType)
+		if err != nil {
+			return nil, err
+		}
+		nodes := make([]*tview.TreeNode, 0, len(names))
+		for _, name := range names {
+			node := tview.NewTreeNode(name).SetSelectable(true)
+			if objectType == model.ObjectTypeView || objectType == model.ObjectTypeMaterializedView {
+				node.SetText("▷ " + name).SetReference(model.NewTable(name, model.Header{}, []model.Record{}))
+			}
+			nodes = append(nodes, node)
+		}
+		return nodes, nil
+	})
+}
+
+// newLazyNode returns a collapsible node whose children are loaded when the node is selected for the first time.
+// A failure is shown as a child node, and the next selection retries the load.
+func (s *sidebar) newLazyNode(text string, load func(ctx context.Context) ([]*tview.TreeNode, error)) *tview.TreeNode {
+	node := tview.NewTreeNode(text).SetSelectable(true)
+	loaded := false
+	node.SetSelectedFunc(func() {
+		if loaded {
+			node.SetExpanded(!node.IsExpanded())
+			return
+		}
+
+		colors := s.theme.GetColors()
+		children, err := load(context.Background())
+		switch {
+		case err != nil:
+			children = []*tview.TreeNode{tview.NewTreeNode("error: " + err.Error()).SetSelectable(false)}
+		case len(children) == 0:
+			children = []*tview.TreeNode{tview.NewTreeNode("(none)").SetSelectable(false)}
+			loaded = true
+		default:
+			loaded = true
+		}
+		node.SetChildren(children)
+		node.SetExpanded(true)
+		applyThemeToTreeNodes(node, colors)
+	})
+	return node
+}
+
 func (s *sidebar) applyTheme(theme *Theme) {
 	colors := theme.GetColors()
 
@@ -0,0 +1,174 @@
+package tui
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/nao1215/sqluv/domain/model"
+)
+
+// Draw draws the query text area and highlights the SQL syntax of the query.
+func (q *queryTextArea) Draw(screen tcell.Screen) {
+	q.TextArea.Draw(screen)
+	q.highlight(screen)
+}
+
+// highlight colors the query that the text area has drawn on the screen. tview.TextArea draws the text
+// with one style, so the cells are colored afterwards: the non-space cells on the screen are the non-space
+// characters of the query in order, because the text area wraps the lines and never scrolls horizontally.
+// The selected text keeps the selection style.
+func (q *queryTextArea) highlight(screen tcell.Screen) {
+	text := q.GetText()
+	if text == "" {
+		return // The placeholder is shown.
+	}
+	x, y, width, height := q.GetInnerRect()
+	pos, ok := q.firstVisiblePosition(screen, text)
+	if !ok {
+		return
+	}
+
+	colors := q.theme.GetColors()
+	_, textBackground, _ := q.GetTextStyle().Decompose()
+	highlights := model.HighlightSQL(text, q.sqlDialect, q.keywords)
+	i := 0
+	for row := 0; row < height; row++ {
+		for column := 0; column < width; column++ {
+			mainc, combc, style, cellWidth := screen.GetContent(x+column, y+row)
+			column += cellWidth - 1
+			if unicode.IsSpace(mainc) {
+				continue
+			}
+			pos = skipSpaces(text, pos)
+			r, size := utf8.DecodeRuneInString(text[pos:])
+			if r != mainc {
+				return // The screen is not what the text area has drawn, e.g. for a control character.
+			}
+			for i < len(highlights) && highlights[i].End <= pos {
+				i++
+			}
+			if i == len(highlights) {
+				return
+			}
+			pos += size
+			for _, c := range combc {
+				pos += utf8.RuneLen(c)
+			}
+
+			if _, bg, _ := style.Decompose(); bg != textBackground {
+				continue // Selected.
+			}
+			switch highlights[i].Role {
+			case model.SQLHighlightKeyword:
+				style = style.Foreground(colors.SyntaxKeyword)
+			case model.SQLHighlightIdentifier:
+				style = style.Foreground(colors.SyntaxIdentifier)
+			case model.SQLHighlightString:
+				style = style.Foreground(colors.SyntaxString)
+			case model.SQLHighlightNumber:
+				style = style.Foreground(colors.SyntaxNumber)
+			case model.SQLHighlightComment:
+				style = style.Foreground(colors.SyntaxComment)
+			case model.SQLHighlightParameter:
+				style = style.Foreground(colors.SyntaxParameter)
+			case model.SQLHighlightError:
+				style = style.Background(colors.SyntaxError).Foreground(colors.Background)
+			default:
+				continue
+			}
+			screen.SetContent(x+column-cellWidth+1, y+row, mainc, combc, style)
+		}
+	}
+}
+
+// firstVisiblePosition returns the byte offset in the text of the first character on the screen.
+// If the text is scrolled, the characters before the cursor on the screen are counted back from the cursor.
+// If the cursor is out of the screen, e.g. scrolled with the mouse, the characters on the screen are
+// searched in the text. It returns false if they are not found.
+func (q *queryTextArea) firstVisiblePosition(screen tcell.Screen, text string) (int, bool) {
+	rowOffset, _ := q.GetOffset()
+	if rowOffset == 0 {
+		return 0, true
+	}
+
+	x, y, width, height := q.GetInnerRect()
+	_, cursor, _ := q.GetSelection()
+	cursorRow, cursorColumn, _, _ := q.GetCursor()
+	if cursorColumn >= width {
+		// The cursor after a full row is at the start of the next row.
+		cursorRow, cursorColumn = cursorRow+1, 0
+	}
+	cursorRow -= rowOffset
+	if cursorRow < 0 || cursorRow >= height {
+		return searchVisiblePosition(screen, text, x, y, width, height)
+	}
+
+	visible := 0
+	for row := 0; row <= cursorRow; row++ {
+		for column := 0; column < width && (row < cursorRow || column < cursorColumn); column++ {
+			mainc, _, _, cellWidth := screen.GetContent(x+column, y+row)
+			column += cellWidth - 1
+			if !unicode.IsSpace(mainc) {
+				visible++
+			}
+		}
+	}
+
+	pos := cursor
+	for visible > 0 && pos > 0 {
+		r, size := utf8.DecodeLastRuneInString(text[:pos])
+		pos -= size
+		if !unicode.IsSpace(r) && !unicode.Is(unicode.Mn, r) {
+			visible--
+		}
+	}
+	return pos, visible == 0
+}
+
+// searchVisiblePosition returns the byte offset in the text of the first character in the rectangle of
+// the screen, by searching the non-space characters in the rectangle in the non-space characters of the text.
+func searchVisiblePosition(screen tcell.Screen, text string, x, y, width, height int) (int, bool) {
+	var visible strings.Builder
+	for row := 0; row < height; row++ {
+		for column := 0; column < width; column++ {
+			mainc, combc, _, cellWidth := screen.GetContent(x+column, y+row)
+			column += cellWidth - 1
+			if !unicode.IsSpace(mainc) {
+				visible.WriteRune(mainc)
+				visible.WriteString(string(combc))
+			}
+		}
+	}
+
+	// offsets are the offsets in the text of the bytes of the non-space characters.
+	var characters strings.Builder
+	offsets := make([]int, 0, len(text))
+	for pos, r := range text {
+		if unicode.IsSpace(r) {
+			continue
+		}
+		characters.WriteRune(r)
+		for i := 0; i < utf8.RuneLen(r); i++ {
+			offsets = append(offsets, pos)
+		}
+	}
+	i := strings.Index(characters.String(), visible.String())
+	if visible.Len() == 0 || i < 0 {
+		return 0, false
+	}
+	return offsets[i], true
+}
+
+// skipSpaces returns the offset of the first non-space character at or after pos.
+func skipSpaces(text string, pos int) int {
+	for pos < len(text) {
+		r, size := utf8.DecodeRuneInString(text[pos:])
+		if !unicode.IsSpace(r) {
+			break
+		}
+		pos += size
+	}
+	return pos
+}
@@ -0,0 +1,295 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/nao1215/sqluv/config"
+	"github.com/nao1215/sqluv/domain/model"
+	"github.com/rivo/tview"
+)
+
+// session is the state of a tab. Each tab has its own sidebar, query editor, results,
+// connection and query history. The fields of the active session are also held by TUI,
+// so that the rest of the TUI works on the active tab without knowing about tabs.
+type session struct {
+	title             string
+	home              *home
+	dbmsUsecases      *dbmsUsecases
+	lastExecutionTime float64
+	latestTable       *model.Table
+	queries           []string // queries executed in this tab, oldest first
+}
+
+// tabBar shows the tabs above the home window. A tab can be selected by mouse click.
+type tabBar struct {
+	*tview.TextView
+}
+
+// newTabBar creates a new tab bar. onSelect is called with the index of the clicked tab.
+func newTabBar(theme *Theme, onSelect func(index int)) *tabBar {
+	text := tview.NewTextView().
+		SetRegions(true).
+		SetDynamicColors(false).
+		SetWrap(false)
+	text.SetHighlightedFunc(func(added, _, _ []string) {
+		if len(added) == 0 {
+			return
+		}
+		if index, err := strconv.Atoi(added[0]); err == nil {
+			onSelect(index)
+		}
+	})
+	tb := &tabBar{TextView: text}
+	tb.applyTheme(theme)
+	return tb
+}
+
+// update redraws the tabs and highlights the current one.
+func (tb *tabBar) update(titles []string, current int) {
+	tabs := make([]string, 0, len(titles))
+	for i, title := range titles {
+		tabs = append(tabs, fmt.Sprintf(`["%d"] %d: %s [""]`, i, i+1, tview.Escape(title)))
+	}
+	tb.SetText(strings.Join(tabs, "│"))
+	tb.Highlight(strconv.Itoa(current))
+}
+
+func (tb *tabBar) applyTheme(theme *Theme) {
+	colors := theme.GetColors()
+	tb.SetBackgroundColor(colors.Background)
+	tb.SetTextColor(colors.Foreground)
+}
+
+// currentSession returns the session of the active tab.
+func (t *TUI) currentSession() *session {
+	return t.sessions[t.current]
+}
+
+// saveSession copies the state of the active tab back to its session.
+func (t *TUI) saveSession() {
+	s := t.currentSession()
+	s.home = t.home
+	s.dbmsUsecases = t.dbmsUsecases
+	s.lastExecutionTime = t.lastExecutionTime
+	s.latestTable = t.latestTable
+}
+
+// activateSession makes the tab at index the active tab and shows it.
+func (t *TUI) activateSession(index int) {
+	t.current = index
+	s := t.currentSession()
+	t.home = s.home
+	t.dbmsUsecases = s.dbmsUsecases
+	t.lastExecutionTime = s.lastExecutionTime
+	t.latestTable = s.latestTable
+
+	t.updateTabBar()
+	t.refreshAllComponents()
+	t.app.SetRoot(t.home.flex, true)
+	t.app.SetFocus(t.home.queryTextArea)
+
+	// The local workspace is shared by all tabs, so it may be changed in another tab.
+	if t.dbmsUsecases.isDBConnected || t.hasLocalFiles() {
+		if err := t.refreshLocalTables(context.Background()); err != nil {
+			t.showError(err)
+		}
+	}
+}
+
+// newSession creates an empty tab with its own home window and appends it to the tabs.
+// The new tab is not activated.
+func (t *TUI) newSession(title string) *session {
+	s := &session{
+		title:        title,
+		home:         newHome(t.app, t.theme, t.tabBar),
+		dbmsUsecases: &dbmsUsecases{},
+	}
+	s.home.footer.setDefaulShortcut()
+	s.home.executeButton.SetSelectedFunc(func() {
+		t.executeQuery(context.Background())
+		t.app.SetFocus(t.home.queryTextArea)
+	})
+	s.home.historyButton.SetSelectedFunc(func() {
+		t.showHistoryList()
+	})
+	t.sessions = append(t.sessions, s)
+	return s
+}
+
+// switchTab saves the active tab and activates the tab at index.
+// The connection of the previous tab is kept open.
+func (t *TUI) switchTab(index int) {
+	if index < 0 || index >= len(t.sessions) || index == t.current {
+		return
+	}
+	t.saveSession()
+	t.activateSession(index)
+}
+
+// showNewTabConnectionModal asks for a connection and opens it in a new tab.
+// Canceling the modal returns to the current tab.
+func (t *TUI) showNewTabConnectionModal() {
+	cm := newConnectionModal(t.app, t.theme, t.dbConfig, t.openConnectionTab)
+	cm.SetText("Open a database connection in a new tab.")
+	cm.setCancelFunc(func() {
+		t.app.SetRoot(t.home.flex, true)
+		t.app.SetFocus(t.home.queryTextArea)
+	})
+	t.app.SetRoot(cm.Modal, true)
+}
+
+// openConnectionTab connects to the database in a new tab.
+// If the connection fails, the new tab is discarded and the previous tab is shown again.
+func (t *TUI) openConnectionTab(conn *config.DBConnection) {
+	if conn == nil {
+		return
+	}
+
+	previous := t.current
+	t.saveSession()
+	t.newSession(conn.Name)
+	t.activateSession(len(t.sessions) - 1)
+
+	if err := t.handleDBConnection(conn); err != nil {
+		t.sessions = t.sessions[:len(t.sessions)-1]
+		t.activateSession(previous)
+		t.showError(fmt.Errorf("failed to connect to database: %w", err))
+		return
+	}
+	t.updateTabBar()
+}
+
+// closeTab closes the active tab and its database connection. The last tab cannot be closed.
+func (t *TUI) closeTab() {
+	if len(t.sessions) <= 1 {
+		return
+	}
+	if t.dbmsUsecases.closeDB != nil {
+		t.dbmsUsecases.closeDB()
+	}
+	t.sessions = append(t.sessions[:t.current], t.sessions[t.current+1:]...)
+	t.activateSession(max(t.current-1, 0))
+}
+
+// confirmCloseTab closes the active tab after the user confirms it, if the tab has a database connection
+// or a query that would be lost. Otherwise it closes the tab at once.
+func (t *TUI) confirmCloseTab() {
+	if len(t.sessions) <= 1 {
+		return
+	}
+	var lost []string
+	if t.dbmsUsecases.isDBConnected {
+		lost = append(lost, "its database connection")
+	}
+	if strings.TrimSpace(t.home.queryTextArea.GetText()) != "" {
+		lost = append(lost, "the query in the editor")
+	}
+	if len(lost) == 0 {
+		t.closeTab()
+		return
+	}
+
+	focused := t.app.GetFocus()
+	confirmModal := tview.NewModal().
+		SetText(fmt.Sprintf("Close the tab '%s' and %s?", t.currentSession().title, strings.Join(lost, " and "))).
+		AddButtons([]string{"Close", "Cancel"}).
+		SetDoneFunc(func(_ int, buttonLabel string) {
+			t.app.SetRoot(t.home.flex, true)
+			t.app.SetFocus(focused)
+			if buttonLabel == "Close" {
+				t.closeTab()
+			}
+		})
+
+	colors := t.theme.GetColors()
+	confirmModal.SetBorderStyle(tcell.StyleDefault.
+		Foreground(colors.BorderFocus).
+		Background(colors.Background))
+	confirmModal.SetButtonActivatedStyle(tcell.StyleDefault.
+		Background(colors.ButtonFocus).
+		Foreground(colors.ButtonTextFocus))
+	confirmModal.SetButtonStyle(tcell.StyleDefault.
+		Background(colors.Button).
+		Foreground(colors.ButtonText))
+	confirmModal.SetBackgroundColor(colors.Background)
+	// Focus "Cancel" by default to avoid closing the tab by accident.
+	confirmModal.SetFocus(1)
+
+	pages := tview.NewPages().
+		AddPage("background", t.home.flex, true, true).
+		AddPage("modal", confirmModal, true, true)
+
+	t.app.SetRoot(pages, true)
+	t.app.SetFocus(confirmModal)
+}
+
+// closeAllSessions closes the database connections of all tabs.
+func (t *TUI) closeAllSessions() {
+	t.saveSession()
+	for _, s := range t.sessions {
+		if s.dbmsUsecases.closeDB != nil {
+			s.dbmsUsecases.closeDB()
+		}
+	}
+}
+
+// updateTabBar redraws the tab bar with the current tab titles.
+func (t *TUI) updateTabBar() {
+	titles := make([]string, 0, len(t.sessions))
+	for _, s := range t.sessions {
+		titles = append(titles, s.title)
+	}
+	t.tabBar.update(titles, t.current)
+}
+
+// tabKeyBindings handles the keys to open, close and switch tabs while the home window is shown.
+// It returns nil if the event is consumed. Ctrl+W is left to the query text area, which deletes
+// the word before the cursor with it.
+func (t *TUI) tabKeyBindings(event *tcell.EventKey) *tcell.EventKey {
+	if !t.home.flex.HasFocus() {
+		return event
+	}
+
+	switch {
+	case event.Key() == tcell.KeyCtrlN:
+		t.showNewTabConnectionModal()
+		return nil
+	case event.Key() == tcell.KeyCtrlW && !t.home.queryTextArea.HasFocus():
+		t.confirmCloseTab()
+		return nil
+	case event.Key() == tcell.KeyRight && event.Modifiers()&tcell.ModAlt != 0:
+		t.switchTab((t.current + 1) % len(t.sessions))
+		return nil
+	case event.Key() == tcell.KeyLeft && event.Modifiers()&tcell.ModAlt != 0:
+		t.switchTab((t.current - 1 + len(t.sessions)) % len(t.sessions))
+		return nil
+	case event.Key() == tcell.KeyRune && event.Modifiers()&tcell.ModAlt != 0 && '1' <= event.Rune() && event.Rune() <= '9':
+		t.switchTab(int(event.Rune() - '1'))
+		return nil
+	}
+	return event
+}
+
+// orderByTab moves the histories executed in the active tab to the end, so that they are
+// listed first in the history list (newest first).
+func (t *TUI) orderByTab(histories model.Histories) model.Histories {
+	inTab := make(map[string]bool, len(t.currentSession().queries))
+	for _, query := range t.currentSession().queries {
+		inTab[query] = true
+	}
+
+	ordered := make(model.Histories, 0, len(histories))
+	tabHistories := model.Histories{}
+	for _, h := range histories {
+		if inTab[h.Request] {
+			tabHistories = append(tabHistories, h)
+			continue
+		}
+		ordered = append(ordered, h)
+	}
+	return append(ordered, tabHistories...)
+}
@@ -0,0 +1,275 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/nao1215/sqluv/config"
+	"github.com/nao1215/sqluv/domain/model"
+	"github.com/rivo/tview"
+)
+
+// handleResultTableKey edits the rows of the result table. "e" edits the selected cell, "a" adds a row,
+// "x" marks the selected row for deletion, "w" reviews and writes the edits, and ESC discards them.
+// It returns nil if the key is handled.
+func (t *TUI) handleResultTableKey(event *tcell.EventKey) *tcell.EventKey {
+	q := t.home.resultTable
+	ctx := context.Background()
+	switch {
+	case event.Rune() == 'e':
+		if err := t.startTableEdit(ctx); err != nil {
+			t.showError(err)
+			return nil
+		}
+		if row, col, ok := q.selectedCell(); ok {
+			t.showCellEditDialog(row, col)
+		}
+		return nil
+	case event.Rune() == 'a':
+		if err := t.startTableEdit(ctx); err != nil {
+			t.showError(err)
+			return nil
+		}
+		row := q.edit.AddRow()
+		q.renderEdit()
+		_, col := q.GetSelection()
+		q.Select(row+1, col)
+		return nil
+	case event.Rune() == 'x':
+		if err := t.startTableEdit(ctx); err != nil {
+			t.showError(err)
+			return nil
+		}
+		if row, _, ok := q.selectedCell(); ok {
+			q.edit.ToggleDelete(row)
+			q.renderEdit()
+		}
+		return nil
+	case event.Rune() == 'w':
+		if q.edit == nil || !q.edit.HasChanges() {
+			t.showError(errors.New("there are no edits to write"))
+			return nil
+		}
+		t.showTableEditReview(ctx)
+		return nil
+	case event.Key() == tcell.KeyEscape && q.edit != nil:
+		q.stopEdit()
+		return nil
+	}
+	return event
+}
+
+// startTableEdit starts editing the result if it is not being edited. Only the result of a SELECT
+// from one table of the connected database can be edited, and the result must have the primary key.
+func (t *TUI) startTableEdit(ctx context.Context) error {
+	q := t.home.resultTable
+	if q.edit != nil {
+		return nil
+	}
+	if !t.dbmsUsecases.isDBConnected || q.query == nil || q.result == nil {
+		return errors.New("only the result of a query to the connected database can be edited")
+	}
+	if t.dbmsUsecases.readOnly {
+		return errors.New("the connection is read-only, so the result cannot be edited")
+	}
+	ref, _, ok := q.query.SourceTable()
+	refs := q.query.TableReferences(config.NewDialect(t.dbmsUsecases.conn.Type).SQLDialect())
+	if !ok || len(refs) != 1 {
+		return errors.New("only the result of a SELECT from one table can be edited (no joins, subqueries in FROM, aggregations or DISTINCT)")
+	}
+
+	// An unquoted name is folded by the DBMS, and a quoted name is not.
+	quoted := strings.ContainsAny(ref[len(ref)-1:], "\"`]")
+	columns, primaryKey, err := t.tableColumns(ctx, refs[0], quoted)
+	if err != nil {
+		return err
+	}
+	for _, c := range q.result.Header() {
+		if !slices.ContainsFunc(columns, func(col string) bool { return strings.EqualFold(col, c) }) {
+			return fmt.Errorf("%s is not a column of table %s, so the result cannot be edited", c, ref)
+		}
+	}
+	edit, err := model.NewTableEdit(ref, q.result, primaryKey)
+	if err != nil {
+		return err
+	}
+	q.startEdit(edit)
+	return nil
+}
+
+// tableColumns returns the columns and the primary key columns of the table that the query refers to.
+// A table qualified with the schema is looked up in the tables of the connection, so that the table
+// of the same name in the current schema is not edited instead. If the name is not quoted, the name
+// folded by the DBMS (lower case for PostgreSQL and upper case for Oracle Database) is tried next.
+func (t *TUI) tableColumns(ctx context.Context, ref model.TableReference, quoted bool) ([]string, []string, error) {
+	names := []string{ref.Name}
+	if folded := config.NewDialect(t.dbmsUsecases.conn.Type).FoldIdentifier(ref.Name); !quoted && folded != ref.Name {
+		names = append(names, folded)
+	}
+	if ref.Schema != "" {
+		table := t.remoteTable(ref.Schema, ref.Name)
+		if table == nil {
+			return nil, nil, fmt.Errorf("table %s.%s is not found in the tables of the connection", ref.Schema, ref.Name)
+		}
+		names = []string{catalogTableName(t.dbmsUsecases.conn.Type, table)}
+	}
+	for _, n := range names {
+		ddl, err := t.dbmsUsecases.ddlGetter.GetTableDDL(ctx, n)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(ddl) == 0 || len(ddl[0].Records()) == 0 {
+			continue
+		}
+		columns := []string{}
+		primaryKey := []string{}
+		// The DDL table has "Column Name" first and "PrimaryKey" last.
+		for _, r := range ddl[0].Records() {
+			columns = append(columns, r[0])
+			if r[len(r)-1] == "PRI" {
+				primaryKey = append(primaryKey, r[0])
+			}
+		}
+		return columns, primaryKey, nil
+	}
+	return nil, nil, fmt.Errorf("table %s is not found", ref.Name)
+}
+
+// showCellEditDialog asks for the new value of the cell.
+func (t *TUI) showCellEditDialog(row, col int) {
+	q := t.home.resultTable
+	edit := q.edit
+	if edit.IsDeleted(row) {
+		t.showError(errors.New("the row is marked for deletion; press x to unmark it"))
+		return
+	}
+	header := edit.Table().Header()
+	if col >= len(header) {
+		return
+	}
+	colors := t.theme.GetColors()
+	current := edit.Value(row, col)
+
+	closeDialog := func() {
+		t.app.SetRoot(t.home.flex, true)
+		t.app.SetFocus(q)
+	}
+	form := tview.NewForm()
+	form.AddInputField("Value", current.Value, 0, nil, nil).
+		AddCheckbox("NULL", current.Null, nil).
+		AddButton("OK", func() {
+			v := model.CellValue{
+				Value: form.GetFormItem(0).(*tview.InputField).GetText(),
+				Null:  form.GetFormItem(1).(*tview.Checkbox).IsChecked(),
+			}
+			if err := edit.SetCell(row, col, v); err != nil {
+				t.showError(err)
+				return
+			}
+			closeDialog()
+			q.renderEdit()
+		}).
+		AddButton("Cancel", closeDialog)
+	form.SetCancelFunc(closeDialog)
+
+	form.SetBorder(true).
+		SetTitle(" Edit " + header[col] + " ").
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderStyle(tcell.StyleDefault.
+			Background(colors.Background).
+			Foreground(colors.BorderFocus))
+	form.SetButtonActivatedStyle(tcell.StyleDefault.
+		Background(colors.ButtonFocus).
+		Foreground(colors.ButtonTextFocus)).
+		SetButtonStyle(tcell.StyleDefault.
+			Background(colors.Button).
+			Foreground(colors.ButtonText)).
+		SetFieldStyle(tcell.StyleDefault.
+			Background(colors.Background).
+			Foreground(colors.Foreground)).
+		SetBackgroundColor(colors.Background)
+
+	// Center the form over the home screen.
+	modal := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(form, 9, 0, true).
+			AddItem(nil, 0, 1, false), 60, 0, true).
+		AddItem(nil, 0, 1, false)
+	pages := tview.NewPages().
+		AddPage("background", t.home.flex, true, true).
+		AddPage("modal", modal, true, true)
+	t.app.SetRoot(pages, true)
+	t.app.SetFocus(form)
+}
+
+// showTableEditReview shows the statements that write the edits, and executes them in a transaction
+// if the user applies them. The query is executed again to show the written rows.
+func (t *TUI) showTableEditReview(ctx context.Context) {
+	q := t.home.resultTable
+	stmts, err := t.dbmsUsecases.editor.EditStatements(q.edit)
+	if err != nil {
+		t.showError(err)
+		return
+	}
+	colors := t.theme.GetColors()
+
+	previews := make([]string, 0, len(stmts))
+	for _, s := range stmts {
+		previews = append(previews, s.Preview+";")
+	}
+	text := tview.NewTextView().
+		SetDynamicColors(false).
+		SetScrollable(true).
+		SetWrap(true).
+		SetText(strings.Join(previews, "\n"))
+	text.SetTitle(fmt.Sprintf(" %d statement(s) to be executed in one transaction ", len(stmts))).
+		SetTitleAlign(tview.AlignLeft).
+		SetBorder(true).
+		SetBorderColor(colors.Border).
+		SetBackgroundColor(colors.Background)
+	text.SetTextColor(colors.Foreground)
+
+	back := func() {
+		t.app.SetRoot(t.home.flex, true)
+		t.app.SetFocus(q)
+	}
+	query := q.query
+	form := tview.NewForm().
+		AddButton("Apply", func() {
+			n, err := t.dbmsUsecases.editor.ApplyEditStatements(ctx, stmts)
+			if err != nil {
+				text.SetText(fmt.Sprintf("Failed to apply the edits, and nothing was written: %s\n\n%s",
+					err.Error(), strings.Join(previews, "\n")))
+				return
+			}
+			back()
+			if err := t.executeDBMSQuery(ctx, query); err != nil {
+				t.showError(err)
+				return
+			}
+			t.showRowsAffectedInfo(n)
+		}).
+		AddButton("Cancel", back)
+	form.SetCancelFunc(back)
+	form.SetButtonActivatedStyle(tcell.StyleDefault.
+		Background(colors.ButtonFocus).
+		Foreground(colors.ButtonTextFocus)).
+		SetButtonStyle(tcell.StyleDefault.
+			Background(colors.Button).
+			Foreground(colors.ButtonText)).
+		SetBackgroundColor(colors.Background)
+	// Focus "Cancel" by default to avoid writing the edits by accident.
+	form.SetFocus(1)
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(text, 0, 1, false).
+		AddItem(form, 3, 0, true)
+	t.app.SetRoot(layout, true)
+	t.app.SetFocus(form)
+}
@@ -27,33 +27,68 @@ func (t *Theme) GetColors() ThemeColors {
 	scheme := t.color.CurrentScheme
 
 	return ThemeColors{
-		Background:      config.GetTcellColor(scheme.Background),
-		Foreground:      config.GetTcellColor(scheme.Foreground),
-		Border:          config.GetTcellColor(scheme.Border),
-		BorderFocus:     config.GetTcellColor(scheme.BorderFocus),
-		Selection:       config.GetTcellColor(scheme.Selection),
-		SelectionText:   config.GetTcellColor(scheme.SelectionText),
-		Header:          config.GetTcellColor(scheme.Header),
-		Button:          config.GetTcellColor(scheme.Button),
-		ButtonFocus:     config.GetTcellColor(scheme.ButtonFocus),
-		ButtonText:      config.GetTcellColor(scheme.ButtonText),
-		ButtonTextFocus: config.GetTcellColor(scheme.ButtonTextFocus),
+		Background:       config.GetTcellColor(scheme.Background),
+		Foreground:       config.GetTcellColor(scheme.Foreground),
+		Border:           config.GetTcellColor(scheme.Border),
+		BorderFocus:      config.GetTcellColor(scheme.BorderFocus),
+		Selection:        config.GetTcellColor(scheme.Selection),
+		SelectionText:    config.GetTcellColor(scheme.SelectionText),
+		Header:           config.GetTcellColor(scheme.Header),
+		Button:           config.GetTcellColor(scheme.Button),
+		ButtonFocus:      config.GetTcellColor(scheme.ButtonFocus),
+		ButtonText:       config.GetTcellColor(scheme.ButtonText),
+		ButtonTextFocus:  config.GetTcellColor(scheme.ButtonTextFocus),
+		SyntaxKeyword:    config.GetTcellColor(scheme.SyntaxKeyword),
+		SyntaxIdentifier: config.GetTcellColor(scheme.SyntaxIdentifier),
+		SyntaxString:     config.GetTcellColor(scheme.SyntaxString),
+		SyntaxNumber:     config.GetTcellColor(scheme.SyntaxNumber),
+		SyntaxComment:    config.GetTcellColor(scheme.SyntaxComment),
+		SyntaxParameter:  config.GetTcellColor(scheme.SyntaxParameter),
+		SyntaxError:      config.GetTcellColor(scheme.SyntaxError),
+		DiffAdded:        config.GetTcellColor(scheme.DiffAdded),
+		DiffRemoved:      config.GetTcellColor(scheme.DiffRemoved),
+		DiffChanged:      config.GetTcellColor(scheme.DiffChanged),
+	}
+}
+
+// environmentColor returns the color of the environment tag. It returns false for an untagged connection.
+// The colors do not depend on the color scheme, so that production always looks the same.
+func environmentColor(env config.Environment) (tcell.Color, bool) {
+	switch env {
+	case config.EnvironmentDevelopment:
+		return tcell.ColorGreen, true
+	case config.EnvironmentStaging:
+		return tcell.ColorOrange, true
+	case config.EnvironmentProduction:
+		return tcell.ColorRed, true
+	default:
+		return tcell.ColorDefault, false
 	}
 }
 
 // ThemeColors holds the actual tcell.Color values for the application
 type ThemeColors struct {
-	Background      tcell.Color
-	Foreground      tcell.Color
-	Border          tcell.Color
-	BorderFocus     tcell.Color
-	Selection       tcell.Color
-	SelectionText   tcell.Color
-	Header          tcell.Color
-	Button          tcell.Color
-	ButtonFocus     tcell.Color
-	ButtonText      tcell.Color
-	ButtonTextFocus tcell.Color
+	Background       tcell.Color
+	Foreground       tcell.Color
+	Border           tcell.Color
+	BorderFocus      tcell.Color
+	Selection        tcell.Color
+	SelectionText    tcell.Color
+	Header           tcell.Color
+	Button           tcell.Color
+	ButtonFocus      tcell.Color
+	ButtonText       tcell.Color
+	ButtonTextFocus  tcell.Color
+	SyntaxKeyword    tcell.Color
+	SyntaxIdentifier tcell.Color
+	SyntaxString     tcell.Color
+	SyntaxNumber     tcell.Color
+	SyntaxComment    tcell.Color
+	SyntaxParameter  tcell.Color
+	SyntaxError      tcell.Color
+	DiffAdded        tcell.Color
+	DiffRemoved      tcell.Color
+	DiffChanged      tcell.Color
 }
 
 // ShowColorSchemeSelector displays a modal for selecting the color scheme
@@ -28,6 +28,7 @@ type (
 		tableCreator   usecase.TableCreator
 		tablesGetter   usecase.TablesGetter
 		ddlGetter      usecase.TableDDLGetter
+		stmtGetter     usecase.CreateTableStatementGetter
 		sqlExecutor    usecase.SQLExecutor
 		recordInserter usecase.RecordsInserter
 	}
@@ -37,11 +38,23 @@ type (
 		queryExecutor usecase.QueryExecutor
 		tablesGetter  usecase.TablesGetter
 		ddlGetter     usecase.TableDDLInRemoteGetter
+		stmtGetter    usecase.CreateTableStatementInRemoteGetter
 		fileWriter    usecase.FileWriter
+		schemaLoader  *schemaLoader
+		schemasGetter usecase.SchemasGetter
+		importer      usecase.RemoteTableImporter
+		streamer      usecase.RemoteQueryStreamer
+		erGenerator   usecase.ERDiagramGenerator
+		planGetter    usecase.QueryPlanGetter
+		editor        usecase.RemoteTableEditor
+		conn          config.DBConnection   // connection settings used to switch schemas
+		db            config.DBMS           // database used to compare schemas with another tab
+		monitor       *config.HealthMonitor // health check of the connection, which is shown in the footer
 
 		closeDB       func() // Added field for database cleanup function
 		isDBConnected bool   // Flag to track if we're connected to a database
 		databaseName  string // Name of the connected database
+		readOnly      bool   // Flag to track if the connection rejects modifications
 	}
 
 	// historyUsecases represents use cases for history operations
@@ -55,6 +68,7 @@ type (
 // TUI represents a text-based user interface.
 type TUI struct {
 	files           []*model.File      // list of file paths that import to SQLite3 in-memory mode.
+	dsn             string             // connection URL connected at startup instead of showing the connection list.
 	app             *tview.Application // TUI application.
 	home            *home              // home component of the TUI.
 	localUsecases   *localUsecases
@@ -62,6 +76,10 @@ type TUI struct {
 	historyUsecases *historyUsecases
 	dbConfig        *config.DBConfig // Database configuration manager
 	theme           *Theme
+	tabBar          *tabBar
+	sessions        []*session      // tabs. The active tab's state is also held in home, dbmsUsecases, etc.
+	current         int             // index of the active tab
+	importedTables  map[string]bool // local tables copied from a database by IMPORT
 
 	lastExecutionTime float64      // Time taken to execute the last query
 	latestTable       *model.Table // Latest table fetched from the database
@@ -75,6 +93,7 @@ func NewTUI(
 	tableCreator usecase.TableCreator,
 	tablesGetter usecase.TablesGetter,
 	ddlGetter usecase.TableDDLGetter,
+	stmtGetter usecase.CreateTableStatementGetter,
 	sqlExecuter usecase.SQLExecutor,
 	recordInserter usecase.RecordsInserter,
 	historyTableCreator usecase.HistoryTableCreator,
@@ -88,7 +107,7 @@ func NewTUI(
 
 	tui := &TUI{
 		files: arg.Files(),
-		home:  newHome(app, theme),
+		dsn:   arg.DSN(),
 		app:   app,
 		localUsecases: &localUsecases{
 			fileReader:     fileReader,
@@ -96,20 +115,24 @@ func NewTUI(
 			tableCreator:   tableCreator,
 			tablesGetter:   tablesGetter,
 			ddlGetter:      ddlGetter,
+			stmtGetter:     stmtGetter,
 			sqlExecutor:    sqlExecuter,
 			recordInserter: recordInserter,
 		},
-		dbmsUsecases: &dbmsUsecases{
-			fileWriter: fileWriter,
-		},
 		historyUsecases: &historyUsecases{
 			historyTableCreator: historyTableCreator,
 			historyCreator:      historyCreator,
 			historyLister:       historyLister,
 		},
-		dbConfig: dbConfig,
-		theme:    theme,
+		dbConfig:       dbConfig,
+		theme:          theme,
+		importedTables: map[string]bool{},
 	}
+	tui.tabBar = newTabBar(theme, tui.switchTab)
+	first := tui.newSession("local")
+	tui.home = first.home
+	tui.dbmsUsecases = first.dbmsUsecases
+	tui.updateTabBar()
 
 	tui.app.SetInputCapture(tui.keyBindings)
 	tui.app.SetMouseCapture(tui.mouseHandler)
@@ -123,6 +146,7 @@ func NewTUI(
 // Run runs the TUI.
 func (t *TUI) Run() error {
 	ctx := context.Background()
+	defer t.closeAllSessions()
 	t.app.SetRoot(t.home.flex, true)
 	t.home.footer.setDefaulShortcut()
 
@@ -130,40 +154,49 @@ func (t *TUI) Run() error {
 		return fmt.Errorf("failed to create history table: %w", err)
 	}
 
+	if t.dsn != "" {
+		conn, err := config.ParseDSN(t.dsn)
+		if err != nil {
+			return err
+		}
+		conn.Name = conn.Database
+		if err := t.handleDBConnection(&conn); err != nil {
+			return err
+		}
+		return t.app.Run()
+	}
+
 	if t.hasLocalFiles() {
 		if err := t.importFiles(ctx); err != nil {
 			return err
 		}
 	} else {
-		connectionModal := newConnectionModal(t.app, t.theme, t.handleConnectionSelection)
+		connectionModal := newConnectionModal(t.app, t.theme, t.dbConfig, t.handleConnectionSelection)
 		t.app.SetRoot(connectionModal.Modal, true)
 		return t.app.Run()
 	}
 
 	t.app.SetFocus(t.home.queryTextArea)
 	t.home.queryTextArea.applyTheme(t.theme)
-
-	t.home.executeButton.SetSelectedFunc(func() {
-		t.executeQuery(context.Background())
-		t.app.SetFocus(t.home.queryTextArea)
-	})
-	t.home.historyButton.SetSelectedFunc(func() {
-		t.showHistoryList()
-	})
 	t.refreshAllComponents()
 	return t.app.Run()
 }
 
 // handleDBConnection is a generic function to handle database connections
 func (t *TUI) handleDBConnection(conn *config.DBConnection) error {
-	db, closeDB, err := t.newDatabaseConfig(conn)
+	db, closeDB, err := config.NewDBMS(conn)
+	if err != nil {
+		return err
+	}
+	editDB, closeEditDB, err := config.NewTableEditDBMS(conn, db)
 	if err != nil {
+		closeDB()
 		return err
 	}
 
 	// Initialize DBMS usecases
-	queryExecutor := persistence.NewQueryExecutor(db)
-	statementExecutor := persistence.NewStatementExecutor(db)
+	queryExecutor := persistence.NewQueryExecutor(db, conn)
+	statementExecutor := persistence.NewStatementExecutor(db, conn)
 	tablesGetter := persistence.NewTablesGetter(db, conn)
 	tableDDLGetter := persistence.NewTableDDLGetter(db, conn)
 
@@ -171,15 +204,57 @@ func (t *TUI) handleDBConnection(conn *config.DBConnection) error {
 		queryExecutor: interactor.NewQueryExecutor(queryExecutor, statementExecutor),
 		tablesGetter:  interactor.NewTablesGetter(tablesGetter),
 		ddlGetter:     interactor.NewTableDDLInRemoteGetter(tableDDLGetter),
+		stmtGetter:    interactor.NewCreateTableStatementInRemoteGetter(persistence.NewCreateTableStatementGetter(db, conn)),
+		schemaLoader: &schemaLoader{
+			dbmsType:          conn.Type,
+			objectsGetter:     interactor.NewObjectsGetter(persistence.NewObjectsGetter(db, conn)),
+			indexesGetter:     interactor.NewIndexesGetter(persistence.NewIndexesGetter(db, conn)),
+			foreignKeysGetter: interactor.NewForeignKeysGetter(persistence.NewForeignKeysGetter(db, conn)),
+			triggersGetter:    interactor.NewTriggersGetter(persistence.NewTriggersGetter(db, conn)),
+		},
+		schemasGetter: interactor.NewSchemasGetter(persistence.NewSchemasGetter(db, conn)),
+		importer:      interactor.NewRemoteTableImporter(persistence.NewRemoteTableImporter(db, conn)),
+		streamer:      interactor.NewRemoteQueryStreamer(persistence.NewRemoteQueryStreamer(db, conn)),
+		erGenerator:   interactor.NewERDiagramGenerator(persistence.NewTableSchemasGetter(db, conn)),
+		planGetter:    interactor.NewQueryPlanGetter(persistence.NewQueryPlanGetter(db, conn)),
+		editor:        interactor.NewRemoteTableEditor(persistence.NewRemoteTableEditor(editDB, conn)),
+		conn:          *conn,
+		db:            db,
 	}
+	t.home.sidebar.setSchemaLoader(t.dbmsUsecases.schemaLoader)
 
 	// Store the database connection for later use
 	t.dbmsUsecases.databaseName = conn.Database
-	t.dbmsUsecases.closeDB = closeDB
+	if conn.ReadOnly {
+		t.dbmsUsecases.databaseName += " (read-only)"
+	}
+	// The monitor updates the footer of this tab even if another tab is active.
+	footer := t.home.footer
+	monitor := config.NewHealthMonitor(db, conn.Pool.HealthCheckInterval(), func(status config.ConnectionStatus, _ error) {
+		t.app.QueueUpdateDraw(func() {
+			footer.setConnectionStatus(status)
+		})
+	})
+	monitor.Start()
+	t.dbmsUsecases.monitor = monitor
+	t.dbmsUsecases.closeDB = func() {
+		monitor.Stop()
+		closeEditDB()
+		closeDB()
+	}
 	t.dbmsUsecases.isDBConnected = true
+	t.dbmsUsecases.readOnly = conn.ReadOnly
+	t.home.setEnvironment(conn.Environment)
+	t.home.queryTextArea.setDialect(config.NewDialect(conn.Type))
+
+	t.currentSession().title = conn.Name
+	if conn.Name == "" {
+		t.currentSession().title = t.dbmsUsecases.databaseName
+	}
+	t.updateTabBar()
 
 	// Load tables and update the sidebar
-	t.loadDatabaseTables(context.Background(), conn.Database)
+	t.loadDatabaseTables(context.Background(), t.dbmsUsecases.databaseName)
 
 	// Successfully connected to the database
 	t.app.SetRoot(t.home.flex, true)
@@ -189,61 +264,6 @@ func (t *TUI) handleDBConnection(conn *config.DBConnection) error {
 	return nil
 }
 
-// newDatabaseConfig creates a new database configuration
-func (t *TUI) newDatabaseConfig(conn *config.DBConnection) (config.DBMS, func(), error) {
-	var db config.DBMS
-	var closeDB func()
-	var err error
-
-	switch conn.Type {
-	case config.MySQL:
-		mysqlConfig := config.NewMySQLConfig(
-			conn.Host,
-			conn.Port,
-			conn.User,
-			conn.Password,
-			conn.Database,
-		)
-		db, closeDB, err = config.NewMySQLDB(mysqlConfig)
-		if err != nil {
-			return nil, nil, err
-		}
-	case config.PostgreSQL:
-		pgConfig := config.NewPostgreSQLConfig(
-			conn.Host,
-			conn.Port,
-			conn.User,
-			conn.Password,
-			conn.Database,
-		)
-		db, closeDB, err = config.NewPostgreSQLDB(pgConfig)
-		if err != nil {
-			return nil, nil, err
-		}
-	case config.SQLite3:
-		sqliteConfig := config.NewSQLite3Config(conn.Database)
-		db, closeDB, err = config.NewSQLite3DB(sqliteConfig)
-		if err != nil {
-			return nil, nil, err
-		}
-	case config.SQLServer:
-		sqlserverConfig := config.NewSQLServerConfig(
-			conn.Host,
-			conn.Port,
-			conn.User,
-			conn.Password,
-			conn.Database,
-		)
-		db, closeDB, err = config.NewSQLServerDB(sqlserverConfig)
-		if err != nil {
-			return nil, nil, err
-		}
-	default:
-		return nil, nil, fmt.Errorf("unsupported database type: %s", conn.Type)
-	}
-	return db, closeDB, nil
-}
-
 // handleConnectionSelection processes the selected database connection
 func (t *TUI) handleConnectionSelection(conn *config.DBConnection) {
 	if conn == nil {
@@ -259,18 +279,15 @@ func (t *TUI) handleConnectionSelection(conn *config.DBConnection) {
 
 	t.app.SetFocus(t.home.queryTextArea)
 	t.home.queryTextArea.applyTheme(t.theme)
-
-	t.home.executeButton.SetSelectedFunc(func() {
-		t.executeQuery(context.Background())
-		t.app.SetFocus(t.home.queryTextArea)
-	})
-	t.home.historyButton.SetSelectedFunc(func() {
-		t.showHistoryList()
-	})
 }
 
 // showFailedConnectionDialog shows a dialog for failed connections with option to remove
 func (t *TUI) showFailedConnectionDialog(conn *config.DBConnection, err error) {
+	if conn.Source != "" {
+		// The connection is imported from a client configuration file, so it is not removed from dbms.yml.
+		t.showFailedImportedConnectionDialog(conn, err)
+		return
+	}
 	errorModal := tview.NewModal().
 		SetText(fmt.Sprintf("Failed to connect to database: %v\nDo you want to remove this connection from saved configurations?", err)).
 		AddButtons([]string{"Yes", "No"}).
@@ -279,7 +296,33 @@ func (t *TUI) showFailedConnectionDialog(conn *config.DBConnection, err error) {
 				t.removeConnectionFromConfig(conn.Name)
 			}
 			// Return to the connection modal
-			connectionModal := newConnectionModal(t.app, t.theme, t.handleConnectionSelection)
+			connectionModal := newConnectionModal(t.app, t.theme, t.dbConfig, t.handleConnectionSelection)
+			t.app.SetRoot(connectionModal.Modal, true)
+		})
+
+	colors := t.theme.GetColors()
+	errorModal.SetBorderStyle(tcell.StyleDefault.
+		Foreground(colors.BorderFocus).
+		Background(colors.Background))
+	errorModal.SetButtonActivatedStyle(tcell.StyleDefault.
+		Background(colors.ButtonFocus).
+		Foreground(colors.ButtonTextFocus))
+	errorModal.SetButtonStyle(tcell.StyleDefault.
+		Background(colors.Button).
+		Foreground(colors.ButtonText))
+	errorModal.SetBackgroundColor(colors.Background)
+
+	t.app.SetRoot(errorModal, true).SetFocus(errorModal)
+}
+
+// showFailedImportedConnectionDialog shows a dialog for failed connections that are imported from
+// a client configuration file, and returns to the connection modal.
+func (t *TUI) showFailedImportedConnectionDialog(conn *config.DBConnection, err error) {
+	errorModal := tview.NewModal().
+		SetText(fmt.Sprintf("Failed to connect to database: %v\nThe connection is imported from %s.", err, conn.Source)).
+		AddButtons([]string{"OK"}).
+		SetDoneFunc(func(_ int, _ string) {
+			connectionModal := newConnectionModal(t.app, t.theme, t.dbConfig, t.handleConnectionSelection)
 			t.app.SetRoot(connectionModal.Modal, true)
 		})
 
@@ -300,43 +343,19 @@ func (t *TUI) showFailedConnectionDialog(conn *config.DBConnection, err error) {
 
 // removeConnectionFromConfig removes a connection from the saved configurations
 func (t *TUI) removeConnectionFromConfig(connectionName string) {
-	dbConfig, err := config.NewDBConfig()
-	if err != nil {
-		t.showError(fmt.Errorf("could not initialize config manager: %w", err))
-		return
-	}
-
-	err = dbConfig.RemoveConnection(connectionName)
-	if err != nil {
+	if err := t.dbConfig.RemoveConnection(connectionName); err != nil {
 		t.showError(fmt.Errorf("failed to remove connection: %w", err))
 	}
 }
 
 // importFiles imports files into the SQLite3 in-memory database.
 func (t *TUI) importFiles(ctx context.Context) error {
-	tables := []*model.Table{}
-	defer func() {
-		t.home.sidebar.update(tables, "local")
-	}()
-
 	for _, file := range t.files {
-		table, err := t.localUsecases.fileReader.Read(ctx, file)
-		if err != nil {
-			return err
-		}
-		if err := t.localUsecases.tableCreator.CreateTable(ctx, table); err != nil {
-			return err
-		}
-		if err := t.localUsecases.recordInserter.InsertRecords(ctx, table); err != nil {
+		if err := t.attachFile(ctx, file); err != nil {
 			return err
 		}
-		tableList, err := t.localUsecases.tablesGetter.GetTables(ctx)
-		if err != nil {
-			return err
-		}
-		tables = tableList
 	}
-	return nil
+	return t.refreshLocalTables(ctx)
 }
 
 // hasLocalFiles returns true if there are local files.
@@ -355,12 +374,22 @@ func (t *TUI) keyBindings(event *tcell.EventKey) *tcell.EventKey {
 			t.home.footer.setSidebarShortcut()
 			return
 		}
+		if t.home.resultTable.HasFocus() {
+			t.home.footer.setResultTableShortcut(t.home.resultTable.edit != nil)
+			return
+		}
 		if !t.home.footer.isActiveSearch() {
 			t.home.footer.setDefaulShortcut()
 			return
 		}
 	}()
 
+	if t.home.resultTable.HasFocus() {
+		if t.handleResultTableKey(event) == nil {
+			return nil
+		}
+	}
+
 	// If sidebar has focus and "/" is pressed, activate footer search for sidebar fuzzy search.
 	if t.home.sidebar.HasFocus() && event.Rune() == '/' {
 		t.home.footer.ActivateSearch()
@@ -371,6 +400,79 @@ func (t *TUI) keyBindings(event *tcell.EventKey) *tcell.EventKey {
 		return nil
 	}
 
+	// If sidebar has focus and "d" is pressed, show the CREATE statement of the selected table.
+	if t.home.sidebar.HasFocus() && event.Rune() == 'd' {
+		if node := t.home.sidebar.GetCurrentNode(); node != nil {
+			if table, ok := node.GetReference().(*model.Table); ok {
+				t.showCreateTableStatement(context.Background(), table)
+			}
+		}
+		return nil
+	}
+
+	// If sidebar has focus and "i" is pressed, import a file into the selected table (or a new table).
+	if t.home.sidebar.HasFocus() && event.Rune() == 'i' {
+		tableName := ""
+		if node := t.home.sidebar.GetCurrentNode(); node != nil {
+			if table, ok := node.GetReference().(*model.Table); ok && !t.home.sidebar.isLocalTable(table) {
+				tableName = table.Name()
+			}
+		}
+		t.showImportFileDialog(tableName)
+		return nil
+	}
+
+	// If sidebar has focus and "e" is pressed, export the ER diagram of the database (or the selected table).
+	if t.home.sidebar.HasFocus() && event.Rune() == 'e' {
+		tableName := ""
+		if node := t.home.sidebar.GetCurrentNode(); node != nil {
+			if table, ok := node.GetReference().(*model.Table); ok && !t.home.sidebar.isLocalTable(table) {
+				tableName = table.Name()
+			}
+		}
+		t.showERDiagramDialog(tableName)
+		return nil
+	}
+
+	// If sidebar has focus and "r" is pressed, compare the rows of two query results.
+	if t.home.sidebar.HasFocus() && event.Rune() == 'r' {
+		var table *model.Table
+		if node := t.home.sidebar.GetCurrentNode(); node != nil {
+			if tbl, ok := node.GetReference().(*model.Table); ok {
+				table = tbl
+			}
+		}
+		t.showDataDiffDialog(table)
+		return nil
+	}
+
+	// If sidebar has focus and "s" is pressed, compare the schemas of two connections.
+	if t.home.sidebar.HasFocus() && event.Rune() == 's' {
+		t.showSchemaDiffDialog()
+		return nil
+	}
+
+	// If sidebar has focus and "c" is pressed, copy the selected table (or the query) to another connection.
+	if t.home.sidebar.HasFocus() && event.Rune() == 'c' {
+		var table *model.Table
+		if node := t.home.sidebar.GetCurrentNode(); node != nil {
+			if tbl, ok := node.GetReference().(*model.Table); ok && !t.home.sidebar.isLocalTable(tbl) {
+				table = tbl
+			}
+		}
+		t.showCopyDataDialog(table)
+		return nil
+	}
+
+	// If query text area has focus and Ctrl-Space (or TAB after a word) is pressed, show the completion.
+	if t.home.queryTextArea.HasFocus() && t.isCompletionKey(event) && t.showCompletion() {
+		return nil
+	}
+
+	if event = t.tabKeyBindings(event); event == nil {
+		return nil
+	}
+
 	switch {
 	case event.Key() == tcell.KeyCtrlD:
 		t.app.Stop()
@@ -430,6 +532,9 @@ func (t *TUI) keyBindings(event *tcell.EventKey) *tcell.EventKey {
 	case event.Key() == tcell.KeyCtrlS:
 		t.showSaveDialog()
 		return nil
+	case event.Key() == tcell.KeyCtrlO:
+		t.showAttachFileDialog()
+		return nil
 	case event.Key() == tcell.KeyCtrlE:
 		if t.home.queryTextArea.HasFocus() {
 			t.executeQuery(context.Background())
@@ -439,8 +544,7 @@ func (t *TUI) keyBindings(event *tcell.EventKey) *tcell.EventKey {
 			node := t.home.sidebar.GetCurrentNode()
 			if node != nil {
 				if table, ok := node.GetReference().(*model.Table); ok {
-					query := fmt.Sprintf("SELECT * FROM %s LIMIT 100", table.Name())
-					t.home.queryTextArea.SetText(query, true)
+					t.home.queryTextArea.SetText(t.selectTableQuery(table, sidebarRowLimit), true)
 					t.executeQuery(context.Background())
 					return nil
 				}
@@ -449,6 +553,11 @@ func (t *TUI) keyBindings(event *tcell.EventKey) *tcell.EventKey {
 	case event.Key() == tcell.KeyCtrlH:
 		t.showHistoryList()
 		return nil
+	case event.Key() == tcell.KeyCtrlP:
+		if t.home.queryTextArea.HasFocus() {
+			t.showQueryPlan(context.Background(), false)
+			return nil
+		}
 	case event.Key() == tcell.KeyF1:
 		t.app.SetFocus(t.home.sidebar)
 		return nil
@@ -460,13 +569,15 @@ func (t *TUI) keyBindings(event *tcell.EventKey) *tcell.EventKey {
 		return nil
 
 	case event.Key() == tcell.KeyEscape:
-		if t.home.footer.isActiveSearch() {
-			t.home.footer.SetLabel("")
-			t.home.sidebar.update(t.home.sidebar.allTables, t.home.sidebar.dbName)
-			t.home.footer.DeactivateSearch()
-			t.home.footer.update()
-			t.app.SetFocus(t.home.sidebar)
+		if !t.home.footer.isActiveSearch() {
+			// Let the current screen (e.g. DDL view) handle ESC.
+			return event
 		}
+		t.home.footer.SetLabel("")
+		t.home.sidebar.update(t.home.sidebar.allTables, t.home.sidebar.dbName)
+		t.home.footer.DeactivateSearch()
+		t.home.footer.update()
+		t.app.SetFocus(t.home.sidebar)
 		return nil
 	case event.Key() == tcell.KeyEnter:
 		if !t.home.sidebar.HasFocus() {
@@ -478,9 +589,9 @@ func (t *TUI) keyBindings(event *tcell.EventKey) *tcell.EventKey {
 		}
 		if table, ok := node.GetReference().(*model.Table); ok {
 			ddlTables := []*model.Table{}
-			if t.dbmsUsecases.isDBConnected {
+			if t.dbmsUsecases.isDBConnected && !t.home.sidebar.isLocalTable(table) {
 				var err error
-				ddlTables, err = t.dbmsUsecases.ddlGetter.GetTableDDL(context.Background(), table.Name())
+				ddlTables, err = t.dbmsUsecases.ddlGetter.GetTableDDL(context.Background(), catalogTableName(t.dbmsUsecases.conn.Type, table))
 				if err != nil {
 					t.showError(err)
 					return nil
@@ -497,13 +608,30 @@ func (t *TUI) keyBindings(event *tcell.EventKey) *tcell.EventKey {
 				// Display the first returned DDL table.
 				t.home.resultTable.update(ddlTables[0], t.home.rowStatistics, 0)
 			}
+			return nil
 		}
-		return nil
+		// Let the sidebar expand or collapse the other nodes.
+		return event
 	}
 	return event
 }
 
-// executeQuery executes the SQL query in the query text area
+// sidebarRowLimit is the number of rows selected by Ctrl-E in the sidebar.
+const sidebarRowLimit = 100
+
+// selectTableQuery returns the query that selects the rows of the table in the sidebar. The name
+// is quoted in the dialect of the DBMS, and the rows are limited if limit is greater than 0.
+func (t *TUI) selectTableQuery(table *model.Table, limit int) string {
+	dialect := config.NewDialect(config.SQLite3)
+	if t.dbmsUsecases.isDBConnected && !t.home.sidebar.isLocalTable(table) {
+		dialect = config.NewDialect(t.dbmsUsecases.conn.Type)
+	}
+	return dialect.SelectRows(dialect.QualifiedName(table.Schema(), table.Name()), limit)
+}
+
+// executeQuery executes the SQL query in the query text area.
+// Destructive statements (UPDATE/DELETE without WHERE, DROP, TRUNCATE) are executed
+// only after the user confirms them.
 func (t *TUI) executeQuery(ctx context.Context) {
 	query := t.home.queryTextArea.GetText()
 	sql, err := model.NewSQL(query)
@@ -512,9 +640,34 @@ func (t *TUI) executeQuery(ctx context.Context) {
 		return
 	}
 
-	if t.dbmsUsecases.isDBConnected && t.dbmsUsecases.queryExecutor != nil {
-		err = t.executeDBMSQuery(ctx, sql)
-	} else {
+	// A read-only connection rejects the statement anyway, so there is nothing to confirm.
+	if sql.IsDestructive() && !(t.dbmsUsecases.isDBConnected && t.dbmsUsecases.readOnly) {
+		t.showDestructiveStatementConfirmation(sql, func() {
+			t.runQuery(ctx, sql)
+		})
+		return
+	}
+	t.runQuery(ctx, sql)
+}
+
+// runQuery executes the SQL query against the connected DBMS or the local file data.
+func (t *TUI) runQuery(ctx context.Context, sql *model.SQL) {
+	query := sql.String()
+
+	var err error
+	switch {
+	case sql.IsImport():
+		err = t.executeImport(ctx, sql)
+	case t.dbmsUsecases.isDBConnected && t.dbmsUsecases.queryExecutor != nil:
+		var local bool
+		if local, err = t.prepareLocalQuery(ctx, sql); err == nil {
+			if local {
+				err = t.executeLocalQuery(ctx, sql)
+			} else {
+				err = t.executeDBMSQuery(ctx, sql)
+			}
+		}
+	default:
 		err = t.executeLocalQuery(ctx, sql)
 	}
 	if err != nil {
@@ -537,6 +690,7 @@ func (t *TUI) recordUserRequest(ctx context.Context, request string) error {
 	if err := t.historyUsecases.historyCreator.Create(ctx, model.NewHistory(len(histories)+1, request)); err != nil {
 		return fmt.Errorf("failed to store user input history: %w", err)
 	}
+	t.currentSession().queries = append(t.currentSession().queries, request)
 	return nil
 }
 
@@ -545,6 +699,10 @@ func (t *TUI) executeDBMSQuery(ctx context.Context, sql *model.SQL) error {
 	startTime := time.Now()
 	output, err := t.dbmsUsecases.queryExecutor.ExecuteQuery(ctx, sql)
 	if err != nil {
+		// The error may be caused by a lost connection, so the footer shows the status without waiting for the next check.
+		if monitor := t.dbmsUsecases.monitor; monitor != nil {
+			go monitor.Check(context.Background()) //nolint:errcheck // the error is shown in the footer
+		}
 		return err
 	}
 
@@ -557,12 +715,33 @@ func (t *TUI) executeDBMSQuery(ctx context.Context, sql *model.SQL) error {
 	if output.HasTable() || sql.IsDelete() {
 		t.lastExecutionTime = time.Since(startTime).Seconds()
 		t.home.resultTable.update(output.Table(), t.home.rowStatistics, t.lastExecutionTime)
+		t.home.resultTable.query = sql
 		t.updateRowStatistics(output.Table(), startTime)
 		t.latestTable = output.Table()
 	}
 	return nil
 }
 
+// executeImport copies the remote table of the IMPORT command into the local workspace.
+func (t *TUI) executeImport(ctx context.Context, sql *model.SQL) error {
+	name, err := sql.ImportTable()
+	if err != nil {
+		return err
+	}
+	schema, table, found := strings.Cut(name, ".")
+	if !found {
+		schema, table = "", name
+	}
+	remote := t.remoteTable(schema, table)
+	if remote == nil {
+		return fmt.Errorf("table %s is not found in the database", name)
+	}
+	if err := t.importRemoteTable(ctx, remote); err != nil {
+		return err
+	}
+	return t.refreshLocalTables(ctx)
+}
+
 // executeLocalQuery executes SQL query against local file data
 func (t *TUI) executeLocalQuery(ctx context.Context, sql *model.SQL) error {
 	startTime := time.Now()
@@ -603,6 +782,46 @@ func (t *TUI) showRowsAffectedInfo(rowsAffected int64) {
 	t.app.SetFocus(infoModal)
 }
 
+// showDestructiveStatementConfirmation asks the user whether the destructive statement should be executed.
+func (t *TUI) showDestructiveStatementConfirmation(sql *model.SQL, onConfirm func()) {
+	query := normalizeSpaces(sql.String())
+	if runes := []rune(query); len(runes) > 120 {
+		query = string(runes[:117]) + "..."
+	}
+
+	confirmModal := tview.NewModal().
+		SetText(fmt.Sprintf("This statement may change or remove many rows at once:\n\n%s\n\nDo you really want to execute it?", query)).
+		AddButtons([]string{"Execute", "Cancel"}).
+		SetDoneFunc(func(_ int, buttonLabel string) {
+			t.app.SetRoot(t.home.flex, true)
+			t.app.SetFocus(t.home.queryTextArea)
+			if buttonLabel == "Execute" {
+				onConfirm()
+			}
+		})
+
+	colors := t.theme.GetColors()
+	confirmModal.SetBorderStyle(tcell.StyleDefault.
+		Foreground(colors.BorderFocus).
+		Background(colors.Background))
+	confirmModal.SetButtonActivatedStyle(tcell.StyleDefault.
+		Background(colors.ButtonFocus).
+		Foreground(colors.ButtonTextFocus))
+	confirmModal.SetButtonStyle(tcell.StyleDefault.
+		Background(colors.Button).
+		Foreground(colors.ButtonText))
+	confirmModal.SetBackgroundColor(colors.Background)
+	// Focus "Cancel" by default to avoid executing the statement by accident.
+	confirmModal.SetFocus(1)
+
+	pages := tview.NewPages().
+		AddPage("background", t.home.flex, true, true).
+		AddPage("modal", confirmModal, true, true)
+
+	t.app.SetRoot(pages, true)
+	t.app.SetFocus(confirmModal)
+}
+
 // hasQueryResults checks if the result table has any query results
 func (t *TUI) hasQueryResults() bool {
 	// Check if there's more than just the header row (the "No query results" message)
@@ -633,9 +852,49 @@ func (t *TUI) loadDatabaseTables(ctx context.Context, dbName string) {
 		t.showError(fmt.Errorf("failed to load tables: %w", err))
 		return
 	}
+
+	if t.dbmsUsecases.schemasGetter != nil {
+		schemas, err := t.dbmsUsecases.schemasGetter.GetSchemas(ctx)
+		if err != nil {
+			t.showError(fmt.Errorf("failed to load schemas: %w", err))
+			return
+		}
+		current, err := t.dbmsUsecases.schemasGetter.GetCurrentSchema(ctx)
+		if err != nil {
+			t.showError(fmt.Errorf("failed to get current schema: %w", err))
+			return
+		}
+		t.home.sidebar.setSchemas(schemas, current, t.switchSchema)
+	}
+
+	locals, err := t.localUsecases.tablesGetter.GetTables(ctx)
+	if err != nil {
+		t.showError(fmt.Errorf("failed to load local tables: %w", err))
+		return
+	}
+	t.home.sidebar.setLocalTables(locals)
 	t.home.sidebar.update(tables, dbName)
 }
 
+// switchSchema reconnects to the database using the given schema (database in MySQL and SQL Server,
+// user in Oracle).
+// The current connection is closed only after the new connection succeeds.
+func (t *TUI) switchSchema(schema string) {
+	if !t.dbmsUsecases.isDBConnected {
+		return
+	}
+	conn := t.dbmsUsecases.conn.WithSchema(schema)
+	closeDB := t.dbmsUsecases.closeDB
+	if err := t.handleDBConnection(&conn); err != nil {
+		t.showError(fmt.Errorf("failed to switch to %s: %w", schema, err))
+		return
+	}
+	if closeDB != nil {
+		closeDB()
+	}
+	t.app.SetFocus(t.home.sidebar)
+}
+
 // showHistoryList displays a list of SQL query history and allows selection
 func (t *TUI) showHistoryList() {
 	histories, err := t.historyUsecases.historyLister.List(context.Background())
@@ -647,6 +906,8 @@ func (t *TUI) showHistoryList() {
 		t.showError(errors.New("no query history available"))
 		return
 	}
+	// Queries executed in this tab are listed first.
+	histories = t.orderByTab(histories)
 
 	colors := t.theme.GetColors()
 	// Create a list to display history items
@@ -773,6 +1034,7 @@ func (t *TUI) updateRowStatistics(table *model.Table, startTime time.Time) {
 // refreshAllComponents new method to refresh all components with the current theme
 func (t *TUI) refreshAllComponents() {
 	t.home.applyTheme(t.theme)
+	t.tabBar.applyTheme(t.theme)
 }
 
 // showSaveDialog displays an input form for the file path.
@@ -0,0 +1,210 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/nao1215/sqluv/config"
+	"github.com/nao1215/sqluv/domain/model"
+	"github.com/rivo/tview"
+)
+
+// The local workspace is the SQLite3 in-memory database that holds the files given on the command line,
+// files attached while connected to a DBMS, and remote tables copied by the IMPORT command.
+// A query that refers to a local table is executed in the local workspace, and the remote tables
+// it refers to are copied into the workspace before the execution. This allows joining files with remote tables.
+
+// attachFile reads the file and creates a table with its records in the local workspace.
+func (t *TUI) attachFile(ctx context.Context, file *model.File) error {
+	table, err := t.localUsecases.fileReader.Read(ctx, file)
+	if err != nil {
+		return err
+	}
+	if err := t.localUsecases.tableCreator.CreateTable(ctx, table); err != nil {
+		return err
+	}
+	return t.localUsecases.recordInserter.InsertRecords(ctx, table)
+}
+
+// showAttachFileDialog asks for a file path (or url) and attaches the file to the local workspace.
+func (t *TUI) showAttachFileDialog() {
+	cwd, err := os.Getwd()
+	if err != nil {
+		cwd = ""
+	}
+	colors := t.theme.GetColors()
+
+	form := tview.NewForm()
+	form.AddInputField("File Path", cwd, 0, nil, nil).
+		AddButton("Attach", func() {
+			filePath := form.GetFormItem(0).(*tview.InputField).GetText()
+			ctx := context.Background()
+			f, err := model.NewFile(filePath)
+			if err != nil {
+				t.showError(fmt.Errorf("failed to create file handle: %w", err))
+				return
+			}
+			if err := t.attachFile(ctx, f); err != nil {
+				t.showError(fmt.Errorf("failed to attach file: %w", err))
+				return
+			}
+			if err := t.refreshLocalTables(ctx); err != nil {
+				t.showError(err)
+				return
+			}
+			t.app.SetRoot(t.home.flex, true)
+			t.app.SetFocus(t.home.sidebar)
+		}).
+		AddButton("Cancel", func() {
+			t.app.SetRoot(t.home.flex, true)
+		})
+
+	form.SetBorder(true).
+		SetTitle("Attach CSV/TSV/LTSV File").
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderStyle(tcell.StyleDefault.
+			Background(colors.Background).
+			Foreground(colors.BorderFocus))
+	form.SetButtonActivatedStyle(tcell.StyleDefault.
+		Background(colors.ButtonFocus).
+		Foreground(colors.ButtonTextFocus)).
+		SetButtonStyle(tcell.StyleDefault.
+			Background(colors.Button).
+			Foreground(colors.ButtonText)).
+		SetFieldStyle(tcell.StyleDefault.
+			Background(colors.Background).
+			Foreground(colors.Foreground)).
+		SetBackgroundColor(colors.Background)
+	t.app.SetRoot(form, true)
+}
+
+// refreshLocalTables shows the tables in the local workspace on the sidebar.
+// In a DBMS tab, they are shown below the database tables.
+func (t *TUI) refreshLocalTables(ctx context.Context) error {
+	tables, err := t.localUsecases.tablesGetter.GetTables(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load local tables: %w", err)
+	}
+	if !t.dbmsUsecases.isDBConnected {
+		t.home.sidebar.update(tables, "local")
+		return nil
+	}
+	t.home.sidebar.setLocalTables(tables)
+	t.home.sidebar.update(t.home.sidebar.allTables, t.home.sidebar.dbName)
+	return nil
+}
+
+// importRowLimit is the maximum number of rows of a remote table that is copied into the local workspace.
+const importRowLimit = 100000
+
+// importRemoteTable copies the remote table into the local workspace. The local table is named
+// after the table without the schema. A table that was imported before is replaced, but a table
+// created from a file is never overwritten. A table with more than importRowLimit rows is not copied,
+// because the whole table is held in memory.
+func (t *TUI) importRemoteTable(ctx context.Context, remote *model.Table) error {
+	if !t.dbmsUsecases.isDBConnected {
+		return errors.New("IMPORT needs a database connection")
+	}
+	localName := remote.Name()
+
+	locals, err := t.localTableNames(ctx)
+	if err != nil {
+		return err
+	}
+	if locals[strings.ToUpper(localName)] {
+		if !t.importedTables[strings.ToUpper(localName)] {
+			return fmt.Errorf("table %s already exists in the local workspace", localName)
+		}
+		drop, err := model.NewSQL("DROP TABLE " + config.NewDialect(config.SQLite3).QuoteIdentifier(localName))
+		if err != nil {
+			return err
+		}
+		if _, err := t.localUsecases.sqlExecutor.ExecuteSQL(ctx, drop); err != nil {
+			return err
+		}
+	}
+
+	query, err := model.NewSQL(t.selectTableQuery(remote, importRowLimit+1))
+	if err != nil {
+		return err
+	}
+	output, err := t.dbmsUsecases.queryExecutor.ExecuteQuery(ctx, query)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", remote.QualifiedName(), err)
+	}
+	if len(output.Table().Records()) > importRowLimit {
+		return fmt.Errorf("%s has more than %d rows, which is too many to copy into the local workspace", remote.QualifiedName(), importRowLimit)
+	}
+	table := model.NewTable(localName, output.Table().Header(), output.Table().Records())
+	if err := t.localUsecases.tableCreator.CreateTable(ctx, table); err != nil {
+		return fmt.Errorf("failed to import %s: %w", remote.QualifiedName(), err)
+	}
+	if err := t.localUsecases.recordInserter.InsertRecords(ctx, table); err != nil {
+		return fmt.Errorf("failed to import %s: %w", remote.QualifiedName(), err)
+	}
+	t.importedTables[strings.ToUpper(localName)] = true
+	return nil
+}
+
+// remoteTable returns the table of the database in the sidebar. The names are compared case-insensitively,
+// and schema may be empty. It returns nil if the table is not found.
+func (t *TUI) remoteTable(schema, name string) *model.Table {
+	for _, table := range t.home.sidebar.allTables {
+		if strings.EqualFold(table.Name(), name) && (schema == "" || strings.EqualFold(table.Schema(), schema)) {
+			return table
+		}
+	}
+	return nil
+}
+
+// localTableNames returns the upper-cased names of the tables in the local workspace.
+func (t *TUI) localTableNames(ctx context.Context) (map[string]bool, error) {
+	tables, err := t.localUsecases.tablesGetter.GetTables(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load local tables: %w", err)
+	}
+	names := make(map[string]bool, len(tables))
+	for _, table := range tables {
+		names[strings.ToUpper(table.Name())] = true
+	}
+	return names, nil
+}
+
+// prepareLocalQuery returns true if the query in a DBMS tab refers to a local table that does not
+// exist in the database, such as an attached file. In that case, the remote tables in the FROM and
+// JOIN clauses of the query are imported into the local workspace unless they already exist there.
+// A query that refers only to imported tables is executed in the database.
+func (t *TUI) prepareLocalQuery(ctx context.Context, sql *model.SQL) (bool, error) {
+	locals, err := t.localTableNames(ctx)
+	if err != nil {
+		return false, err
+	}
+	if len(locals) == 0 {
+		return false, nil
+	}
+
+	refs := sql.TableReferences(config.NewDialect(t.dbmsUsecases.conn.Type).SQLDialect())
+	usesLocal := false
+	for _, ref := range refs {
+		usesLocal = usesLocal || (locals[strings.ToUpper(ref.Name)] && t.remoteTable(ref.Schema, ref.Name) == nil)
+	}
+	if !usesLocal {
+		return false, nil
+	}
+
+	for _, ref := range refs {
+		remote := t.remoteTable(ref.Schema, ref.Name)
+		if remote == nil || locals[strings.ToUpper(remote.Name())] {
+			continue
+		}
+		if err := t.importRemoteTable(ctx, remote); err != nil {
+			return false, err
+		}
+		locals[strings.ToUpper(remote.Name())] = true
+	}
+	return true, nil
+}
@@ -15,7 +15,7 @@ type (
 		Read(ctx context.Context, file *model.File) (*model.Table, error)
 	}
 
-	// FileWriter is an interface for writing records to CSV/TSV/LTSV files.
+	// FileWriter is an interface for writing records to CSV/TSV/LTSV/JSON files.
 	FileWriter interface {
 		WriteFile(ctx context.Context, file *model.File, table *model.Table) error
 	}
@@ -34,6 +34,11 @@ type (
 	TableDDLGetter interface {
 		GetTableDDL(ctx context.Context, tableName string) ([]*model.Table, error)
 	}
+
+	// CreateTableStatementGetter gets the CREATE statement of a table.
+	CreateTableStatementGetter interface {
+		GetCreateTableStatement(ctx context.Context, tableName string) (string, error)
+	}
 )
 
 // NewExecuteSQLOutput creates a new ExecuteSQLOutput.
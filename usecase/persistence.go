@@ -30,6 +30,78 @@ type (
 	TableDDLInRemoteGetter interface {
 		GetTableDDL(ctx context.Context, tableName string) ([]*model.Table, error)
 	}
+
+	// CreateTableStatementInRemoteGetter gets the CREATE statement of a table (or view) in database,
+	// including constraints, defaults and indexes.
+	CreateTableStatementInRemoteGetter interface {
+		GetCreateTableStatement(ctx context.Context, tableName string) (string, error)
+	}
+
+	// ObjectsGetter gets the names of database objects other than tables, such as views and sequences.
+	ObjectsGetter interface {
+		GetObjects(ctx context.Context, objectType model.ObjectType) ([]string, error)
+	}
+
+	// IndexesGetter gets the indexes of a table in database.
+	IndexesGetter interface {
+		GetIndexes(ctx context.Context, tableName string) ([]*model.Index, error)
+	}
+
+	// ForeignKeysGetter gets the foreign keys of a table in database.
+	ForeignKeysGetter interface {
+		GetForeignKeys(ctx context.Context, tableName string) ([]*model.ForeignKey, error)
+	}
+
+	// TriggersGetter gets the triggers of a table in database.
+	TriggersGetter interface {
+		GetTriggers(ctx context.Context, tableName string) ([]*model.Trigger, error)
+	}
+
+	// RemoteTableImporter imports the records of a file (or a query result) into a table in database.
+	// PlanImport returns the plan without changing the database, so it can be used as a dry-run.
+	// If types is nil, the column types of a new table are inferred from the records.
+	RemoteTableImporter interface {
+		PlanImport(ctx context.Context, table *model.Table, tableName string, types []model.ColumnType) (*model.ImportPlan, error)
+		Import(ctx context.Context, plan *model.ImportPlan) (int64, error)
+	}
+
+	// RemoteQueryStreamer executes a read-only query in database and passes the result to fn in batches
+	// of batchSize records, so that a large result is not held in memory. The types are the column types
+	// of the result mapped from the database types.
+	RemoteQueryStreamer interface {
+		StreamQuery(ctx context.Context, sql *model.SQL, batchSize int, fn func(batch *model.Table, types []model.ColumnType) error) error
+	}
+
+	// SchemaComparer compares the schema of a database with the schema of another database.
+	// The diff describes the changes that turn the "from" schema into the "to" schema, and the
+	// statements apply the changes to the "from" database.
+	SchemaComparer interface {
+		CompareSchemas(ctx context.Context) (*model.SchemaDiff, string, error)
+	}
+
+	// QueryPlanGetter gets the plan of a query as a tree. If analyze is true, the DBMS that supports it
+	// executes the query to measure the actual rows and time.
+	QueryPlanGetter interface {
+		GetQueryPlan(ctx context.Context, sql *model.SQL, analyze bool) (*model.QueryPlan, error)
+	}
+
+	// RemoteTableEditor writes the rows edited in the result grid to a table in database.
+	// EditStatements returns the statements for review without changing the database.
+	RemoteTableEditor interface {
+		EditStatements(edit *model.TableEdit) ([]*model.EditStatement, error)
+		ApplyEditStatements(ctx context.Context, stmts []*model.EditStatement) (int64, error)
+	}
+
+	// ERDiagramGenerator draws the tables of a database and their foreign keys as an ER diagram.
+	ERDiagramGenerator interface {
+		GenerateERDiagram(ctx context.Context, opts model.ERDiagramOptions) (string, error)
+	}
+
+	// SchemasGetter gets the schemas (databases in MySQL and SQL Server) on the server.
+	SchemasGetter interface {
+		GetSchemas(ctx context.Context) ([]string, error)
+		GetCurrentSchema(ctx context.Context) (string, error)
+	}
 )
 
 // NewExecuteQueryOutput creates a new ExecuteSQLOutput.